@@ -1,25 +1,43 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"eventBooker/api"
 	"eventBooker/internal/config"
-	"eventBooker/internal/http-server/handlers/event/confirmBooking"
-	"eventBooker/internal/http-server/handlers/event/createBooking"
-	"eventBooker/internal/http-server/handlers/event/createEvent"
-	"eventBooker/internal/http-server/handlers/event/getAllEvents"
-	"eventBooker/internal/http-server/handlers/event/getEventInfo"
+	"eventBooker/internal/http-server/handlers/docs/openapiSpec"
+	"eventBooker/internal/http-server/handlers/health/liveness"
+	"eventBooker/internal/http-server/handlers/health/readiness"
+	"eventBooker/internal/http-server/middleware/mwdeprecation"
 	"eventBooker/internal/http-server/middleware/mwlogger"
+	"eventBooker/internal/http-server/middleware/mwmetrics"
+	"eventBooker/internal/http-server/middleware/mwratelimit"
+	"eventBooker/internal/http-server/middleware/mwtracing"
+	"eventBooker/internal/http-server/router"
+	"eventBooker/internal/lib/feedtoken"
+	"eventBooker/internal/lib/health"
 	"eventBooker/internal/lib/logger/handlers/slogpretty"
 	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/metrics"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/lib/ratelimit"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/lib/tracing"
+	"eventBooker/internal/lib/validate"
 	"eventBooker/internal/storage/postgres"
+	"eventBooker/migrations"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
+	// Venue and event time zones are resolved with the embedded zone database,
+	// so they work in images without tzdata.
+	_ "time/tzdata"
 )
 
 const (
@@ -28,6 +46,17 @@ const (
 	envProd  = "prod"
 )
 
+const rateLimitPostgres = "postgres"
+
+const expirySweepInterval = 1 * time.Minute
+
+// refundResendDelay gives requests time to send the refunds they record
+// themselves before the sweep resends the ones still pending.
+const refundResendDelay = time.Minute
+
+// minRateLimitIdle keeps short rate limits from churning their buckets.
+const minRateLimitIdle = time.Hour
+
 func main() {
 	cfg := config.MustLoad()
 
@@ -36,37 +65,144 @@ func main() {
 	log.Info("Starting event booker", slog.String("env", cfg.Env))
 	log.Debug("Debug messages are enabled")
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("failed to init tracing", sl.Err(err))
+		os.Exit(1)
+	}
+
 	storage, err := postgres.InitDB(&cfg.Database)
 	if err != nil {
 		log.Error("failed to init storage", sl.Err(err))
 		os.Exit(1)
 	}
 
-	router := chi.NewRouter()
+	schemaVersion, err := migrations.Latest()
+	if err != nil {
+		log.Error("failed to read migrations", sl.Err(err))
+		os.Exit(1)
+	}
+
+	apiSpec, err := api.Load()
+	if err != nil {
+		log.Error("failed to load openapi spec", sl.Err(err))
+		os.Exit(1)
+	}
+
+	sweepHeartbeat := health.NewHeartbeat()
+	var shuttingDown atomic.Bool
+
+	promMetrics := metrics.New()
+	promMetrics.RegisterDB(storage.DB)
+	promMetrics.RegisterSeats(storage, cfg.Metrics.MaxEventSeries)
+
+	bookings := promMetrics.InstrumentBookings(storage)
+
+	requestValidator := validate.New(cfg.Validation)
+
+	limiter := setupRateLimiter(cfg.HTTPServer.RateLimit.Backend, storage)
+	rateLimit := mwratelimit.New(log, limiter, cfg.HTTPServer.RateLimit)
+	rateLimitIdle := mwratelimit.IdleTimeout(cfg.HTTPServer.RateLimit, minRateLimitIdle)
+
+	mux := chi.NewRouter()
 
-	router.Use(middleware.RequestID)
-	router.Use(mwlogger.New(log))
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.URLFormat)
+	mux.Use(middleware.RequestID)
+	mux.Use(mwtracing.New())
+	if cfg.Metrics.Enabled {
+		mux.Use(mwmetrics.New(promMetrics))
+	}
+	mux.Use(mwlogger.New(log))
+	mux.Use(middleware.Recoverer)
+	mux.Use(middleware.URLFormat)
 
 	fs := http.FileServer(http.Dir("./static/"))
-	router.Handle("/static/*", http.StripPrefix("/static/", fs))
+	mux.Handle("/static/*", http.StripPrefix("/static/", fs))
+
+	mux.Get("/healthz", liveness.New())
+	mux.Get("/readyz", readiness.New(log,
+		health.Draining(&shuttingDown),
+		health.Database(storage),
+		health.Schema(storage, schemaVersion),
+		health.Worker("expiry_worker", sweepHeartbeat, 3*expirySweepInterval),
+	))
 
-	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/static/index.html", http.StatusFound)
 	})
 
-	router.Post("/events", createEvent.New(log, storage))
-	router.Post("/events/{id}/book", createBooking.New(log, storage))
-	router.Post("/events/{id}/confirm", confirmBooking.New(log, storage))
-	router.Get("/events/{id}", getEventInfo.New(log, storage))
-	router.Get("/events", getAllEvents.New(log, storage))
+	// middleware.URLFormat strips the extension before routing, so this serves /openapi.json.
+	mux.Get("/openapi", openapiSpec.New(log, apiSpec))
+	mux.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/static/docs.html", http.StatusFound)
+	})
+
+	var calendars *feedtoken.Signer
+	if cfg.Calendar.Secret != "" {
+		calendars = feedtoken.New(cfg.Calendar.Secret)
+	} else {
+		log.Info("calendar secret is not set, user calendar feeds are disabled")
+	}
+
+	var tickets *ticket.Signer
+	if cfg.Tickets.SigningKey != "" {
+		if tickets, err = ticket.ParseKey(cfg.Tickets.SigningKey); err != nil {
+			log.Error("failed to load ticket signing key", sl.Err(err))
+			os.Exit(1)
+		}
+	} else {
+		log.Info("ticket signing key is not set, tickets and check-in are disabled")
+	}
+
+	var payments payment.Provider
+	switch cfg.Payments.Provider {
+	case "":
+		log.Info("payment provider is not set, payments are disabled")
+	case "fake":
+		if cfg.Payments.Fake.Secret == "" {
+			log.Error("fake payment secret is not set")
+			os.Exit(1)
+		}
+		fake := payment.NewFake(cfg.Payments.Fake.Secret, "/payments/fake", cfg.Payments.Fake.WebhookURL)
+		mux.HandleFunc("/payments/fake/{id}", fake.CheckoutHandler(log))
+		payments = fake
+		log.Warn("using the fake payment provider, no money is taken")
+	default:
+		log.Error("unknown payment provider", slog.String("provider", cfg.Payments.Provider))
+		os.Exit(1)
+	}
+
+	apiRoutes := router.API(log, router.Deps{
+		Validator:              requestValidator,
+		Storage:                storage,
+		Bookings:               bookings,
+		RateLimit:              rateLimit,
+		APIKeys:                cfg.Auth.APIKeys,
+		IdempotencyTTL:         cfg.HTTPServer.Idempotency.TTL,
+		IdempotencyLockTimeout: cfg.HTTPServer.Timeout,
+		SeriesHorizon:          cfg.Series.Horizon,
+		Calendars:              calendars,
+		CalendarDomain:         cfg.Calendar.Domain,
+		Tickets:                tickets,
+		Payments:               payments,
+	})
+
+	mux.Route(router.Prefix, apiRoutes)
+
+	// Unversioned aliases kept for existing clients.
+	mux.Group(func(r chi.Router) {
+		r.Use(router.LegacyOnly, mwdeprecation.New(router.Prefix))
+		apiRoutes(r)
+	})
+
+	if cfg.Metrics.Enabled {
+		mux.Handle("/metrics", promMetrics.Handler())
+	}
 
 	log.Info("starting server", slog.String("address", cfg.HTTPServer.Address))
 
 	srv := &http.Server{
 		Addr:         cfg.HTTPServer.Address,
-		Handler:      router,
+		Handler:      mux,
 		ReadTimeout:  cfg.HTTPServer.Timeout,
 		WriteTimeout: cfg.HTTPServer.Timeout,
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
@@ -75,17 +211,59 @@ func main() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT, os.Interrupt)
 
+	done := make(chan struct{})
+
 	go func() {
-		ticker := time.NewTicker(1 * time.Minute)
+		ticker := time.NewTicker(expirySweepInterval)
 		defer ticker.Stop()
 
 		for {
 			select {
 			case <-ticker.C:
-				if err = storage.CancelExpiredBookings(); err != nil {
+				t1 := time.Now()
+				cancelled, err := storage.CancelExpiredBookings(context.Background())
+				if err != nil {
 					log.Error("failed to cancel expired bookings", sl.Err(err))
+				} else {
+					sweepHeartbeat.Beat()
+					promMetrics.ObserveSweep(time.Since(t1), cancelled)
+					if cancelled > 0 {
+						log.Info("expired bookings cancelled", slog.Int64("count", cancelled))
+					}
+				}
+				if err = limiter.Cleanup(context.Background(), rateLimitIdle); err != nil {
+					log.Error("failed to cleanup rate limits", sl.Err(err))
 				}
-			case <-stop:
+				if err = storage.DeleteExpiredIdempotencyKeys(context.Background()); err != nil {
+					log.Error("failed to delete expired idempotency keys", sl.Err(err))
+				}
+				// The horizon moves a day at a time, so series are extended once a day.
+				horizon := time.Now().Add(cfg.Series.Horizon).Truncate(24 * time.Hour)
+				if created, err := storage.ExtendSeries(context.Background(), horizon); err != nil {
+					log.Error("failed to extend event series", sl.Err(err))
+				} else if created > 0 {
+					log.Info("series occurrences created", slog.Int("count", created))
+				}
+				if published, err := storage.PublishScheduledEvents(context.Background()); err != nil {
+					log.Error("failed to publish scheduled events", sl.Err(err))
+				} else if published > 0 {
+					log.Info("scheduled events published", slog.Int64("count", published))
+				}
+				if completed, err := storage.CompleteEvents(context.Background()); err != nil {
+					log.Error("failed to complete past events", sl.Err(err))
+				} else if completed > 0 {
+					log.Info("past events completed", slog.Int64("count", completed))
+				}
+				if payments != nil {
+					sent, err := payment.ResendRefunds(context.Background(), payments, storage, time.Now().Add(-refundResendDelay))
+					if err != nil {
+						log.Error("failed to resend pending refunds", sl.Err(err))
+					}
+					if sent > 0 {
+						log.Info("pending refunds sent", slog.Int("count", sent))
+					}
+				}
+			case <-done:
 				return
 			}
 		}
@@ -102,12 +280,27 @@ func main() {
 
 	log.Info("application stopping", slog.String("signal", sign.String()))
 
-	if err = srv.Shutdown(nil); err != nil {
+	// Fail readiness first and keep serving for a while, so load balancers
+	// notice and stop sending traffic before we stop accepting it.
+	shuttingDown.Store(true)
+	close(done)
+
+	log.Info("draining", slog.String("delay", cfg.HTTPServer.ShutdownDelay.String()))
+	time.Sleep(cfg.HTTPServer.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout)
+	defer cancel()
+
+	if err = srv.Shutdown(ctx); err != nil {
 		log.Error("failed to shutdown server", sl.Err(err))
 	}
 
 	log.Info("application stopped")
 
+	if err = shutdownTracing(ctx); err != nil {
+		log.Error("failed to flush traces", sl.Err(err))
+	}
+
 	if err = storage.Close(); err != nil {
 		log.Error("failed to close postgres connection", sl.Err(err))
 	}
@@ -130,6 +323,14 @@ func setupLogger(env string) *slog.Logger {
 	return log
 }
 
+func setupRateLimiter(backend string, storage *postgres.Storage) ratelimit.Limiter {
+	if backend == rateLimitPostgres {
+		return postgres.NewRateLimiter(storage)
+	}
+
+	return ratelimit.NewMemory()
+}
+
 func setupPrettySlog() *slog.Logger {
 	opts := slogpretty.PrettyHandlerOptions{
 		SlogOpts: &slog.HandlerOptions{
@@ -0,0 +1,593 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"eventBooker/internal/http-server/handlers/event/createEvent"
+	"eventBooker/internal/http-server/handlers/event/importEvents"
+	"eventBooker/internal/http-server/router"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/eventimport"
+	"eventBooker/internal/lib/feedtoken"
+	"eventBooker/internal/models"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var errUsage = errors.New("invalid usage")
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=Storage
+type Storage interface {
+	ListEvents(ctx context.Context, limit, offset, venueID int, day string, includeDrafts bool) ([]models.Event, int, error)
+	GetEvent(ctx context.Context, id int) (*models.Event, error)
+	GetEventWithBookings(ctx context.Context, eventID int) (*models.Event, []models.Booking, error)
+	CreateEvent(ctx context.Context, title string, date time.Time, totalSeats, deadline, venueID int, timezone string, schedule models.Schedule) (int, error)
+	ImportEvents(ctx context.Context, events []models.Event) ([]int, error)
+	UpdateEvent(ctx context.Context, id int, title string, date time.Time, totalSeats, deadline int) error
+	SetEventStatus(ctx context.Context, eventID int, status string) (int, error)
+	PendingBookingPrice(ctx context.Context, eventID int, userID string) (int64, error)
+	ConfirmBooking(ctx context.Context, eventID int, userID string) error
+	CancelBooking(ctx context.Context, eventID int, userID string) error
+	CancelExpiredBookings(ctx context.Context) (int64, error)
+	GrantRole(ctx context.Context, userID, role string) error
+}
+
+type app struct {
+	storage   Storage
+	validator *validator.Validate
+	// calendars is nil when calendar feeds are disabled.
+	calendars *feedtoken.Signer
+	in        io.Reader
+	out       *printer
+}
+
+type runFunc func(ctx context.Context, a *app) error
+
+type command struct {
+	name    string
+	summary string
+	// setup defines the command's flags on fs and returns the function to run once they are parsed.
+	setup func(fs *flag.FlagSet) runFunc
+}
+
+var commands = []command{
+	{name: "events list", summary: "list events ordered by date", setup: eventsList},
+	{name: "events create", summary: "create an event, or a batch of events from a JSON file", setup: eventsCreate},
+	{name: "events import", summary: "import events from a CSV or iCalendar file in one transaction", setup: eventsImport},
+	{name: "events update", summary: "change an event", setup: eventsUpdate},
+	{name: "events cancel", summary: "cancel an event with all of its bookings, same as set-status -status cancelled", setup: eventsCancel},
+	{name: "events set-status", summary: "move an event to another status; cancelling notifies its bookings and records their refunds for the server to send", setup: eventsSetStatus},
+	{name: "bookings list", summary: "list bookings of an event with their expiry", setup: bookingsList},
+	{name: "bookings confirm", summary: "confirm a pending booking", setup: bookingsConfirm},
+	{name: "bookings cancel", summary: "cancel a booking, e.g. force-expire a pending hold; the server sends its refund", setup: bookingsCancel},
+	{name: "sweep run", summary: "cancel expired pending bookings now", setup: sweepRun},
+	{name: "users grant-role", summary: "grant a role to a user", setup: usersGrantRole},
+	{name: "users feed-url", summary: "print the path of a user's calendar feed", setup: usersFeedURL},
+}
+
+// lookup finds the command named by the first two args and returns the rest.
+func lookup(args []string) (command, []string, error) {
+	if len(args) == 0 {
+		return command{}, nil, errors.New("no command given")
+	}
+	if len(args) == 1 {
+		return command{}, nil, fmt.Errorf("unknown command %q", args[0])
+	}
+
+	name := args[0] + " " + args[1]
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, args[2:], nil
+		}
+	}
+
+	return command{}, nil, fmt.Errorf("unknown command %q", name)
+}
+
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+func eventsList(fs *flag.FlagSet) runFunc {
+	limit := fs.Int("limit", 100, "maximum number of events to list")
+	offset := fs.Int("offset", 0, "number of events to skip")
+	venue := fs.Int("venue", 0, "only list events at this venue")
+	day := fs.String("day", "", `only list events starting on this day in their time zone, "today" or YYYY-MM-DD`)
+
+	return func(ctx context.Context, a *app) error {
+		if *limit < 0 || *offset < 0 || *venue < 0 {
+			return usageError("-limit, -offset and -venue must not be negative")
+		}
+		if *day != "" && *day != "today" {
+			if _, err := time.Parse(time.DateOnly, *day); err != nil {
+				return usageError(`-day must be "today" or a date in the format YYYY-MM-DD`)
+			}
+		}
+
+		// Operators see drafts too.
+		events, total, err := a.storage.ListEvents(ctx, *limit, *offset, *venue, *day, true)
+		if err != nil {
+			return err
+		}
+
+		t := table{header: eventHeader}
+		for _, e := range events {
+			t.rows = append(t.rows, eventRow(e))
+		}
+
+		return a.out.print(struct {
+			Events     []models.Event      `json:"events"`
+			Pagination response.Pagination `json:"pagination"`
+		}{
+			Events:     events,
+			Pagination: response.Pagination{Limit: *limit, Offset: *offset, Total: total},
+		}, t)
+	}
+}
+
+type createdEvent struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func eventsCreate(fs *flag.FlagSet) runFunc {
+	title := fs.String("title", "", "event title")
+	date := fs.String("date", "", "event start in RFC 3339, e.g. 2030-06-01T19:00:00Z")
+	seats := fs.Int("seats", 0, "total number of seats, the venue's capacity by default")
+	deadline := fs.Int("deadline", 0, "minutes a booking may stay unconfirmed")
+	venue := fs.Int("venue", 0, "id of the venue the event is held at")
+	timezone := fs.String("timezone", "", "IANA time zone of the event, the venue's or UTC by default")
+	status := fs.String("status", "", "draft to create the event unpublished, published by default")
+	file := fs.String("file", "", `JSON array of events with the fields of POST /events, "-" for stdin`)
+
+	return func(ctx context.Context, a *app) error {
+		var reqs []createEvent.EventRequest
+
+		if *file != "" {
+			if *title != "" || *date != "" || *seats != 0 || *deadline != 0 || *venue != 0 || *timezone != "" || *status != "" {
+				return usageError("-file cannot be combined with event flags")
+			}
+
+			var err error
+			if reqs, err = a.readEvents(*file); err != nil {
+				return err
+			}
+		} else {
+			req := createEvent.EventRequest{Title: *title, TotalSeats: *seats, Deadline: *deadline, VenueID: *venue, Timezone: *timezone, Status: *status}
+			if *date != "" {
+				var err error
+				if req.Date, err = time.Parse(time.RFC3339, *date); err != nil {
+					return usageError("invalid -date: %s", err)
+				}
+			}
+			reqs = append(reqs, req)
+		}
+
+		// Validate the whole batch first so a bad entry does not leave it half created.
+		schedules := make([]models.Schedule, len(reqs))
+		for i := range reqs {
+			var err error
+			if schedules[i], err = a.validateEvent(&reqs[i]); err != nil {
+				if len(reqs) > 1 {
+					return fmt.Errorf("event %d: %w", i+1, err)
+				}
+				return err
+			}
+		}
+
+		created := make([]createdEvent, 0, len(reqs))
+		t := table{header: []string{"ID", "TITLE"}}
+
+		for i, req := range reqs {
+			id, err := a.storage.CreateEvent(ctx, req.Title, req.Date, req.TotalSeats, req.Deadline, req.VenueID, req.Timezone, schedules[i])
+			if err != nil {
+				_ = a.out.print(created, t)
+				return fmt.Errorf("created %d of %d events: %w", len(created), len(reqs), err)
+			}
+
+			created = append(created, createdEvent{ID: id, Title: req.Title})
+			t.rows = append(t.rows, []string{strconv.Itoa(id), req.Title})
+		}
+
+		return a.out.print(created, t)
+	}
+}
+
+func (a *app) readEvents(path string) ([]createEvent.EventRequest, error) {
+	var r io.Reader = a.in
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var reqs []createEvent.EventRequest
+	if err := json.NewDecoder(r).Decode(&reqs); err != nil {
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+	if len(reqs) == 0 {
+		return nil, errors.New("no events to create")
+	}
+
+	return reqs, nil
+}
+
+// validateEvent applies the same rules as POST /events and returns the
+// event's schedule.
+func (a *app) validateEvent(req *createEvent.EventRequest) (models.Schedule, error) {
+	req.Title = strings.TrimSpace(req.Title)
+	req.Timezone = strings.TrimSpace(req.Timezone)
+
+	err := a.validator.Struct(req)
+
+	var validateErr validator.ValidationErrors
+	if errors.As(err, &validateErr) {
+		msgs := make([]string, 0, len(validateErr))
+		for _, fe := range validateErr {
+			msgs = append(msgs, response.FieldMessage(fe))
+		}
+		return models.Schedule{}, errors.New(strings.Join(msgs, ", "))
+	}
+	if err != nil {
+		return models.Schedule{}, err
+	}
+
+	return req.Schedule()
+}
+
+func eventsImport(fs *flag.FlagSet) runFunc {
+	file := fs.String("file", "", `CSV or iCalendar file, "-" for stdin`)
+	format := fs.String("format", "", "csv or ics, detected from the file name by default")
+	dryRun := fs.Bool("dry-run", false, "only validate the events")
+	seats := fs.Int("seats", 0, "total seats of events that do not set them")
+	deadline := fs.Int("deadline", 0, "booking deadline in minutes of events that do not set it")
+
+	return func(ctx context.Context, a *app) error {
+		if *file == "" {
+			return usageError("-file is required")
+		}
+
+		if *format == "" {
+			var err error
+			if *format, err = eventimport.DetectFormat("", *file); err != nil {
+				return usageError("set -format: %s", err)
+			}
+		}
+
+		var r io.Reader = a.in
+		if *file != "-" {
+			f, err := os.Open(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		records, err := eventimport.Parse(r, *format, eventimport.Defaults{TotalSeats: *seats, Deadline: *deadline})
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return errors.New("file has no events")
+		}
+
+		events, rowErrs := importEvents.Check(a.validator, records)
+		if len(rowErrs) > 0 {
+			t := table{header: []string{"LINE", "ERROR"}}
+			for _, re := range rowErrs {
+				for _, fe := range re.Errors {
+					t.rows = append(t.rows, []string{strconv.Itoa(re.Line), fe.Message})
+				}
+			}
+			_ = a.out.print(rowErrs, t)
+
+			return fmt.Errorf("%d of %d events are invalid, nothing imported", len(rowErrs), len(records))
+		}
+
+		if !*dryRun {
+			ids, err := a.storage.ImportEvents(ctx, importEvents.Events(events))
+			if err != nil {
+				return err
+			}
+			for i := range events {
+				events[i].ID = ids[i]
+			}
+		}
+
+		t := table{header: []string{"LINE", "ID", "TITLE", "DATE", "SEATS", "DEADLINE"}}
+		for _, e := range events {
+			id := strconv.Itoa(e.ID)
+			if *dryRun {
+				id = "-"
+			}
+			t.rows = append(t.rows, []string{
+				strconv.Itoa(e.Line), id, e.Title, formatTime(e.Date), strconv.Itoa(e.TotalSeats), strconv.Itoa(e.Deadline) + "m",
+			})
+		}
+
+		return a.out.print(importEvents.ImportResponse{DryRun: *dryRun, Events: events}, t)
+	}
+}
+
+func eventsUpdate(fs *flag.FlagSet) runFunc {
+	id := fs.Int("id", 0, "event id")
+	title := fs.String("title", "", "new title")
+	date := fs.String("date", "", "new start in RFC 3339")
+	seats := fs.Int("seats", 0, "new total number of seats")
+	deadline := fs.Int("deadline", 0, "new minutes a booking may stay unconfirmed")
+
+	return func(ctx context.Context, a *app) error {
+		if *id <= 0 {
+			return usageError("-id is required")
+		}
+
+		event, err := a.storage.GetEvent(ctx, *id)
+		if err != nil {
+			return err
+		}
+
+		req := createEvent.EventRequest{
+			Title:      event.Title,
+			Date:       event.Date,
+			TotalSeats: event.TotalSeats,
+			Deadline:   event.Deadline,
+		}
+
+		changed := false
+		var parseErr error
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "title":
+				req.Title, changed = *title, true
+			case "date":
+				req.Date, parseErr = time.Parse(time.RFC3339, *date)
+				changed = true
+			case "seats":
+				req.TotalSeats, changed = *seats, true
+			case "deadline":
+				req.Deadline, changed = *deadline, true
+			}
+		})
+		if parseErr != nil {
+			return usageError("invalid -date: %s", parseErr)
+		}
+		if !changed {
+			return usageError("nothing to update")
+		}
+
+		if _, err = a.validateEvent(&req); err != nil {
+			return err
+		}
+		if req.TotalSeats < event.BookedSeats {
+			return fmt.Errorf("event has %d confirmed bookings, total seats cannot be less", event.BookedSeats)
+		}
+
+		if err = a.storage.UpdateEvent(ctx, *id, req.Title, req.Date, req.TotalSeats, req.Deadline); err != nil {
+			return err
+		}
+
+		event.Title, event.Date, event.TotalSeats, event.Deadline = req.Title, req.Date, req.TotalSeats, req.Deadline
+
+		return a.out.print(event, table{header: eventHeader, rows: [][]string{eventRow(*event)}})
+	}
+}
+
+func eventsCancel(fs *flag.FlagSet) runFunc {
+	id := fs.Int("id", 0, "event id")
+
+	return func(ctx context.Context, a *app) error {
+		if *id <= 0 {
+			return usageError("-id is required")
+		}
+
+		cancelled, err := a.storage.SetEventStatus(ctx, *id, models.EventCancelled)
+		if err != nil {
+			return err
+		}
+
+		return a.out.message(map[string]any{"id": *id, "status": models.EventCancelled, "cancelled_bookings": cancelled},
+			"event %d cancelled, %d bookings cancelled", *id, cancelled)
+	}
+}
+
+func eventsSetStatus(fs *flag.FlagSet) runFunc {
+	id := fs.Int("id", 0, "event id")
+	status := fs.String("status", "", "status to move the event to: published, sales_closed, cancelled or completed")
+
+	return func(ctx context.Context, a *app) error {
+		if *id <= 0 || *status == "" {
+			return usageError("-id and -status are required")
+		}
+
+		cancelled, err := a.storage.SetEventStatus(ctx, *id, *status)
+		if err != nil {
+			return err
+		}
+
+		return a.out.message(map[string]any{"id": *id, "status": *status, "cancelled_bookings": cancelled},
+			"event %d is %s, %d bookings cancelled", *id, *status, cancelled)
+	}
+}
+
+const statusAll = "all"
+
+type bookingView struct {
+	models.Booking
+	Status    string     `json:"status"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func bookingsList(fs *flag.FlagSet) runFunc {
+	eventID := fs.Int("event", 0, "event id")
+	status := fs.String("status", statusAll, "pending, confirmed or all")
+
+	return func(ctx context.Context, a *app) error {
+		if *eventID <= 0 {
+			return usageError("-event is required")
+		}
+		if *status != statusAll && *status != models.BookingPending && *status != models.BookingConfirmed {
+			return usageError("unknown -status %q", *status)
+		}
+
+		event, bookings, err := a.storage.GetEventWithBookings(ctx, *eventID)
+		if err != nil {
+			return err
+		}
+
+		views := make([]bookingView, 0, len(bookings))
+		t := table{header: []string{"ID", "USER", "STATUS", "CREATED", "EXPIRES"}}
+
+		for _, b := range bookings {
+			v := bookingView{Booking: b, Status: b.Status()}
+			if !b.Confirmed {
+				expires := b.CreatedAt.Add(time.Duration(event.Deadline) * time.Minute)
+				v.ExpiresAt = &expires
+			}
+
+			if *status != statusAll && *status != v.Status {
+				continue
+			}
+
+			expires := "-"
+			if v.ExpiresAt != nil {
+				expires = formatTime(*v.ExpiresAt)
+			}
+
+			views = append(views, v)
+			t.rows = append(t.rows, []string{strconv.Itoa(b.ID), b.UserID, v.Status, formatTime(b.CreatedAt), expires})
+		}
+
+		return a.out.print(views, t)
+	}
+}
+
+func bookingsConfirm(fs *flag.FlagSet) runFunc {
+	eventID, userID := bookingFlags(fs)
+
+	return func(ctx context.Context, a *app) error {
+		if *eventID <= 0 || *userID == "" {
+			return usageError("-event and -user are required")
+		}
+
+		// Priced bookings are confirmed by their payment, not by hand.
+		price, err := a.storage.PendingBookingPrice(ctx, *eventID, *userID)
+		if err != nil {
+			return err
+		}
+		if price > 0 {
+			return fmt.Errorf("payment required")
+		}
+
+		if err = a.storage.ConfirmBooking(ctx, *eventID, *userID); err != nil {
+			return err
+		}
+
+		return a.out.message(bookingResult(*eventID, *userID, models.BookingConfirmed), "booking of %s for event %d confirmed", *userID, *eventID)
+	}
+}
+
+func bookingsCancel(fs *flag.FlagSet) runFunc {
+	eventID, userID := bookingFlags(fs)
+
+	return func(ctx context.Context, a *app) error {
+		if *eventID <= 0 || *userID == "" {
+			return usageError("-event and -user are required")
+		}
+
+		if err := a.storage.CancelBooking(ctx, *eventID, *userID); err != nil {
+			return err
+		}
+
+		return a.out.message(bookingResult(*eventID, *userID, "cancelled"), "booking of %s for event %d cancelled", *userID, *eventID)
+	}
+}
+
+func bookingFlags(fs *flag.FlagSet) (eventID *int, userID *string) {
+	eventID = fs.Int("event", 0, "event id")
+	userID = fs.String("user", "", "user id")
+
+	return eventID, userID
+}
+
+func bookingResult(eventID int, userID, status string) map[string]any {
+	return map[string]any{"event_id": eventID, "user_id": userID, "status": status}
+}
+
+func sweepRun(_ *flag.FlagSet) runFunc {
+	return func(ctx context.Context, a *app) error {
+		cancelled, err := a.storage.CancelExpiredBookings(ctx)
+		if err != nil {
+			return err
+		}
+
+		return a.out.message(map[string]any{"cancelled": cancelled}, "%d expired bookings cancelled", cancelled)
+	}
+}
+
+func usersGrantRole(fs *flag.FlagSet) runFunc {
+	userID := fs.String("user", "", "user id")
+	role := fs.String("role", "", "role to grant: "+strings.Join(models.Roles, ", "))
+
+	return func(ctx context.Context, a *app) error {
+		if *userID == "" || *role == "" {
+			return usageError("-user and -role are required")
+		}
+		if !slices.Contains(models.Roles, *role) {
+			return usageError("unknown role %q, expected one of: %s", *role, strings.Join(models.Roles, ", "))
+		}
+
+		if err := a.storage.GrantRole(ctx, *userID, *role); err != nil {
+			return err
+		}
+
+		return a.out.message(map[string]any{"user_id": *userID, "role": *role}, "role %s granted to %s", *role, *userID)
+	}
+}
+
+func usersFeedURL(fs *flag.FlagSet) runFunc {
+	userID := fs.String("user", "", "user id")
+
+	return func(_ context.Context, a *app) error {
+		if *userID == "" {
+			return usageError("-user is required")
+		}
+		if a.calendars == nil {
+			return errors.New("calendar feeds are disabled, set calendar.secret")
+		}
+
+		path := router.CalendarPath(a.calendars, *userID)
+
+		return a.out.message(map[string]any{"user_id": *userID, "calendar_url": path}, "%s", path)
+	}
+}
+
+var eventHeader = []string{"ID", "TITLE", "DATE", "SEATS", "BOOKED", "DEADLINE", "STATUS"}
+
+func eventRow(e models.Event) []string {
+	return []string{
+		strconv.Itoa(e.ID),
+		e.Title,
+		formatTime(e.Date),
+		strconv.Itoa(e.TotalSeats),
+		strconv.Itoa(e.BookedSeats),
+		strconv.Itoa(e.Deadline) + "m",
+		e.Status,
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"eventBooker/cmd/eventctl/mocks"
+	"eventBooker/internal/config"
+	"eventBooker/internal/lib/feedtoken"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+var testCalendars = feedtoken.New("test-secret")
+
+func TestCommands(t *testing.T) {
+	t.Parallel()
+
+	eventDate := time.Date(2099, 12, 25, 18, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2099, 12, 1, 10, 0, 0, 0, time.UTC)
+	event := models.Event{ID: 1, Title: "Go meetup", Date: eventDate, TotalSeats: 10, BookedSeats: 4, Deadline: 30,
+		Schedule: models.Schedule{Status: models.EventPublished}}
+
+	testCases := []struct {
+		name      string
+		args      []string
+		format    string
+		stdin     string
+		mockSetup func(m *mocks.Storage)
+		wantOut   string
+		wantErr   string
+		wantUsage bool
+	}{
+		{
+			name:   "List events as table",
+			args:   []string{"events", "list", "-limit", "10"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("ListEvents", mock.Anything, 10, 0, 0, "", true).Return([]models.Event{event}, 1, nil)
+			},
+			wantOut: "ID  TITLE      DATE                  SEATS  BOOKED  DEADLINE  STATUS\n" +
+				"1   Go meetup  2099-12-25T18:00:00Z  10     4       30m       published\n",
+		},
+		{
+			name:   "List events as JSON",
+			args:   []string{"events", "list", "-limit", "10", "-offset", "5"},
+			format: formatJSON,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("ListEvents", mock.Anything, 10, 5, 0, "", true).Return([]models.Event{}, 1, nil)
+			},
+			wantOut: "{\n  \"events\": [],\n  \"pagination\": {\n    \"limit\": 10,\n    \"offset\": 5,\n    \"total\": 1\n  }\n}\n",
+		},
+		{
+			name:   "List events at venue",
+			args:   []string{"events", "list", "-venue", "3"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("ListEvents", mock.Anything, 100, 0, 3, "", true).Return([]models.Event{}, 0, nil)
+			},
+			wantOut: "ID  TITLE  DATE  SEATS  BOOKED  DEADLINE  STATUS\n",
+		},
+		{
+			name:   "List events of today",
+			args:   []string{"events", "list", "-day", "today"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("ListEvents", mock.Anything, 100, 0, 0, "today", true).Return([]models.Event{}, 0, nil)
+			},
+			wantOut: "ID  TITLE  DATE  SEATS  BOOKED  DEADLINE  STATUS\n",
+		},
+		{
+			name:      "List events of invalid day",
+			args:      []string{"events", "list", "-day", "2099-13-01"},
+			format:    formatTable,
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   `invalid usage: -day must be "today" or a date in the format YYYY-MM-DD`,
+			wantUsage: true,
+		},
+		{
+			name:   "Create event from flags",
+			args:   []string{"events", "create", "-title", " Go meetup ", "-date", "2099-12-25T18:00:00Z", "-seats", "10", "-deadline", "30"},
+			format: formatJSON,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("CreateEvent", mock.Anything, "Go meetup", eventDate, 10, 30, 0, "", models.Schedule{}).Return(7, nil)
+			},
+			wantOut: "[\n  {\n    \"id\": 7,\n    \"title\": \"Go meetup\"\n  }\n]\n",
+		},
+		{
+			name:   "Create event at venue",
+			args:   []string{"events", "create", "-title", "Go meetup", "-date", "2099-12-25T18:00:00Z", "-deadline", "30", "-venue", "3"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("CreateEvent", mock.Anything, "Go meetup", eventDate, 0, 30, 3, "", models.Schedule{}).Return(8, nil)
+			},
+			wantOut: "ID  TITLE\n8   Go meetup\n",
+		},
+		{
+			name:   "Create event in time zone",
+			args:   []string{"events", "create", "-title", "Go meetup", "-date", "2099-12-25T18:00:00Z", "-seats", "10", "-deadline", "30", "-timezone", "Asia/Tokyo"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("CreateEvent", mock.Anything, "Go meetup", eventDate, 10, 30, 0, "Asia/Tokyo", models.Schedule{}).Return(9, nil)
+			},
+			wantOut: "ID  TITLE\n9   Go meetup\n",
+		},
+		{
+			name:   "Create batch from stdin",
+			args:   []string{"events", "create", "-file", "-"},
+			format: formatTable,
+			stdin: `[
+				{"title": "First", "date": "2099-12-25T18:00:00Z", "total_seats": 10, "deadline": 30},
+				{"title": "Second", "date": "2099-12-26T18:00:00Z", "total_seats": 20, "deadline": 15, "status": "draft"}
+			]`,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("CreateEvent", mock.Anything, "First", eventDate, 10, 30, 0, "", models.Schedule{}).Return(1, nil)
+				m.On("CreateEvent", mock.Anything, "Second", eventDate.Add(24*time.Hour), 20, 15, 0, "", models.Schedule{Status: models.EventDraft}).Return(2, nil)
+			},
+			wantOut: "ID  TITLE\n1   First\n2   Second\n",
+		},
+		{
+			name:   "Invalid batch creates nothing",
+			args:   []string{"events", "create", "-file", "-"},
+			format: formatTable,
+			stdin: `[
+				{"title": "First", "date": "2099-12-25T18:00:00Z", "total_seats": 10, "deadline": 30},
+				{"title": "No", "date": "2099-12-26T18:00:00Z", "total_seats": 20, "deadline": 15}
+			]`,
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   "event 2: field title must be at least 3 characters",
+		},
+		{
+			name:      "Create with file and flags",
+			args:      []string{"events", "create", "-file", "-", "-title", "Go meetup"},
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   "-file cannot be combined with event flags",
+			wantUsage: true,
+		},
+		{
+			name:   "Import CSV",
+			args:   []string{"events", "import", "-file", "-", "-format", "csv", "-deadline", "30"},
+			format: formatTable,
+			stdin:  "title,date,total_seats\nGo meetup,2099-12-25T18:00:00Z,10\n",
+			mockSetup: func(m *mocks.Storage) {
+				m.On("ImportEvents", mock.Anything, []models.Event{{Title: "Go meetup", Date: eventDate, TotalSeats: 10, Deadline: 30}}).
+					Return([]int{5}, nil)
+			},
+			wantOut: "LINE  ID  TITLE      DATE                  SEATS  DEADLINE\n" +
+				"2     5   Go meetup  2099-12-25T18:00:00Z  10     30m\n",
+		},
+		{
+			name:      "Dry run import with invalid rows",
+			args:      []string{"events", "import", "-file", "-", "-format", "csv", "-dry-run"},
+			stdin:     "title,date,total_seats,deadline_minutes\nGo meetup,2099-12-25T18:00:00Z,10,30\nNo,2099-12-25T18:00:00Z,10,30\n",
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   "1 of 2 events are invalid, nothing imported",
+		},
+		{
+			name:      "Import without format",
+			args:      []string{"events", "import", "-file", "-"},
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   "set -format",
+			wantUsage: true,
+		},
+		{
+			name:   "Update event seats",
+			args:   []string{"events", "update", "-id", "1", "-seats", "20"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				e := event
+				m.On("GetEvent", mock.Anything, 1).Return(&e, nil)
+				m.On("UpdateEvent", mock.Anything, 1, "Go meetup", eventDate, 20, 30).Return(nil)
+			},
+			wantOut: "ID  TITLE      DATE                  SEATS  BOOKED  DEADLINE  STATUS\n" +
+				"1   Go meetup  2099-12-25T18:00:00Z  20     4       30m       published\n",
+		},
+		{
+			name: "Update seats below booked",
+			args: []string{"events", "update", "-id", "1", "-seats", "3"},
+			mockSetup: func(m *mocks.Storage) {
+				e := event
+				m.On("GetEvent", mock.Anything, 1).Return(&e, nil)
+			},
+			wantErr: "event has 4 confirmed bookings, total seats cannot be less",
+		},
+		{
+			name: "Update without changes",
+			args: []string{"events", "update", "-id", "1"},
+			mockSetup: func(m *mocks.Storage) {
+				e := event
+				m.On("GetEvent", mock.Anything, 1).Return(&e, nil)
+			},
+			wantErr:   "nothing to update",
+			wantUsage: true,
+		},
+		{
+			name:   "Cancel event",
+			args:   []string{"events", "cancel", "-id", "1"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("SetEventStatus", mock.Anything, 1, "cancelled").Return(3, nil)
+			},
+			wantOut: "event 1 cancelled, 3 bookings cancelled\n",
+		},
+		{
+			name:   "Cancel event with its bookings",
+			args:   []string{"events", "set-status", "-id", "1", "-status", "cancelled"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("SetEventStatus", mock.Anything, 1, "cancelled").Return(2, nil)
+			},
+			wantOut: "event 1 is cancelled, 2 bookings cancelled\n",
+		},
+		{
+			name:      "Set status without status",
+			args:      []string{"events", "set-status", "-id", "1"},
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   "-id and -status are required",
+			wantUsage: true,
+		},
+		{
+			name:   "List pending bookings",
+			args:   []string{"bookings", "list", "-event", "1", "-status", "pending"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				e := event
+				m.On("GetEventWithBookings", mock.Anything, 1).Return(&e, []models.Booking{
+					{ID: 2, EventID: 1, UserID: "bob", CreatedAt: createdAt},
+					{ID: 1, EventID: 1, UserID: "alice", CreatedAt: createdAt, Confirmed: true},
+				}, nil)
+			},
+			wantOut: "ID  USER  STATUS   CREATED               EXPIRES\n" +
+				"2   bob   pending  2099-12-01T10:00:00Z  2099-12-01T10:30:00Z\n",
+		},
+		{
+			name:      "List bookings without event",
+			args:      []string{"bookings", "list"},
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   "-event is required",
+			wantUsage: true,
+		},
+		{
+			name:   "Confirm booking",
+			args:   []string{"bookings", "confirm", "-event", "1", "-user", "bob"},
+			format: formatJSON,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("PendingBookingPrice", mock.Anything, 1, "bob").Return(int64(0), nil)
+				m.On("ConfirmBooking", mock.Anything, 1, "bob").Return(nil)
+			},
+			wantOut: "{\n  \"event_id\": 1,\n  \"status\": \"confirmed\",\n  \"user_id\": \"bob\"\n}\n",
+		},
+		{
+			name: "Confirm unpaid booking",
+			args: []string{"bookings", "confirm", "-event", "1", "-user", "bob"},
+			mockSetup: func(m *mocks.Storage) {
+				m.On("PendingBookingPrice", mock.Anything, 1, "bob").Return(int64(1500), nil)
+			},
+			wantErr: "payment required",
+		},
+		{
+			name: "Cancel missing booking",
+			args: []string{"bookings", "cancel", "-event", "1", "-user", "bob"},
+			mockSetup: func(m *mocks.Storage) {
+				m.On("CancelBooking", mock.Anything, 1, "bob").Return(errors.New("no booking found"))
+			},
+			wantErr: "no booking found",
+		},
+		{
+			name:   "Run sweep",
+			args:   []string{"sweep", "run"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("CancelExpiredBookings", mock.Anything).Return(int64(3), nil)
+			},
+			wantOut: "3 expired bookings cancelled\n",
+		},
+		{
+			name:   "Grant role",
+			args:   []string{"users", "grant-role", "-user", "alice", "-role", "admin"},
+			format: formatTable,
+			mockSetup: func(m *mocks.Storage) {
+				m.On("GrantRole", mock.Anything, "alice", "admin").Return(nil)
+			},
+			wantOut: "role admin granted to alice\n",
+		},
+		{
+			name:      "Grant unknown role",
+			args:      []string{"users", "grant-role", "-user", "alice", "-role", "root"},
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   `unknown role "root"`,
+			wantUsage: true,
+		},
+		{
+			name:      "Print feed URL",
+			args:      []string{"users", "feed-url", "-user", "alice"},
+			format:    formatTable,
+			mockSetup: func(m *mocks.Storage) {},
+			wantOut:   "/api/v1/calendars/" + testCalendars.Sign("alice") + ".ics\n",
+		},
+		{
+			name:      "Feed URL without user",
+			args:      []string{"users", "feed-url"},
+			mockSetup: func(m *mocks.Storage) {},
+			wantErr:   "-user is required",
+			wantUsage: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage := mocks.NewStorage(t)
+			tc.mockSetup(storage)
+
+			cmd, args, err := lookup(tc.args)
+			require.NoError(t, err)
+
+			fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			run := cmd.setup(fs)
+			require.NoError(t, fs.Parse(args))
+
+			var out bytes.Buffer
+			err = run(context.Background(), &app{
+				storage:   storage,
+				validator: testValidator,
+				calendars: testCalendars,
+				in:        strings.NewReader(tc.stdin),
+				out:       &printer{w: &out, format: tc.format},
+			})
+
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				assert.Equal(t, tc.wantUsage, errors.Is(err, errUsage))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOut, out.String())
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	cmd, args, err := lookup([]string{"bookings", "list", "-event", "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "bookings list", cmd.name)
+	assert.Equal(t, []string{"-event", "1"}, args)
+
+	_, _, err = lookup(nil)
+	assert.EqualError(t, err, "no command given")
+
+	_, _, err = lookup([]string{"events"})
+	assert.EqualError(t, err, `unknown command "events"`)
+
+	_, _, err = lookup([]string{"events", "drop"})
+	assert.EqualError(t, err, `unknown command "events drop"`)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/lib/feedtoken"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/storage/postgres"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	// Event time zones are validated against the embedded zone database,
+	// so they work on hosts without tzdata.
+	_ "time/tzdata"
+)
+
+func main() {
+	format := flag.String("o", formatTable, "output format: table or json")
+	flag.Usage = usage
+
+	cfg := config.MustLoad()
+
+	if *format != formatTable && *format != formatJSON {
+		fmt.Fprintf(os.Stderr, "eventctl: unknown output format %q\n", *format)
+		os.Exit(2)
+	}
+
+	cmd, args, err := lookup(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "eventctl:", err)
+		usage()
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("eventctl "+cmd.name, flag.ContinueOnError)
+	run := cmd.setup(fs)
+	if err = fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+
+	storage, err := postgres.InitDB(&cfg.Database)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "eventctl: failed to init storage:", err)
+		os.Exit(1)
+	}
+
+	var calendars *feedtoken.Signer
+	if cfg.Calendar.Secret != "" {
+		calendars = feedtoken.New(cfg.Calendar.Secret)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err = run(ctx, &app{
+		storage:   storage,
+		validator: validate.New(cfg.Validation),
+		calendars: calendars,
+		in:        os.Stdin,
+		out:       &printer{w: os.Stdout, format: *format},
+	})
+
+	stop()
+	_ = storage.Close()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "eventctl %s: %s\n", cmd.name, err)
+		if errors.Is(err, errUsage) {
+			fs.Usage()
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+
+	fmt.Fprintln(out, "Usage: eventctl [-config path] [-o table|json] <command> [flags]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-18s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Run eventctl <command> -h for the flags of a command.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Flags:")
+	flag.PrintDefaults()
+}
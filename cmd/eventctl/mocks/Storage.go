@@ -0,0 +1,351 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// CancelBooking provides a mock function with given fields: ctx, eventID, userID
+func (_m *Storage) CancelBooking(ctx context.Context, eventID int, userID string) error {
+	ret := _m.Called(ctx, eventID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelBooking")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, eventID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelExpiredBookings provides a mock function with given fields: ctx
+func (_m *Storage) CancelExpiredBookings(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelExpiredBookings")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmBooking provides a mock function with given fields: ctx, eventID, userID
+func (_m *Storage) ConfirmBooking(ctx context.Context, eventID int, userID string) error {
+	ret := _m.Called(ctx, eventID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmBooking")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, eventID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateEvent provides a mock function with given fields: ctx, title, date, totalSeats, deadline, venueID, timezone, schedule
+func (_m *Storage) CreateEvent(ctx context.Context, title string, date time.Time, totalSeats int, deadline int, venueID int, timezone string, schedule models.Schedule) (int, error) {
+	ret := _m.Called(ctx, title, date, totalSeats, deadline, venueID, timezone, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEvent")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int, int, int, string, models.Schedule) (int, error)); ok {
+		return rf(ctx, title, date, totalSeats, deadline, venueID, timezone, schedule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int, int, int, string, models.Schedule) int); ok {
+		r0 = rf(ctx, title, date, totalSeats, deadline, venueID, timezone, schedule)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, int, int, int, string, models.Schedule) error); ok {
+		r1 = rf(ctx, title, date, totalSeats, deadline, venueID, timezone, schedule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEvent provides a mock function with given fields: ctx, id
+func (_m *Storage) GetEvent(ctx context.Context, id int) (*models.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvent")
+	}
+
+	var r0 *models.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEventWithBookings provides a mock function with given fields: ctx, eventID
+func (_m *Storage) GetEventWithBookings(ctx context.Context, eventID int) (*models.Event, []models.Booking, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventWithBookings")
+	}
+
+	var r0 *models.Event
+	var r1 []models.Booking
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Event, []models.Booking, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Event); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) []models.Booking); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int) error); ok {
+		r2 = rf(ctx, eventID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GrantRole provides a mock function with given fields: ctx, userID, role
+func (_m *Storage) GrantRole(ctx context.Context, userID string, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportEvents provides a mock function with given fields: ctx, events
+func (_m *Storage) ImportEvents(ctx context.Context, events []models.Event) ([]int, error) {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportEvents")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Event) ([]int, error)); ok {
+		return rf(ctx, events)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Event) []int); ok {
+		r0 = rf(ctx, events)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.Event) error); ok {
+		r1 = rf(ctx, events)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListEvents provides a mock function with given fields: ctx, limit, offset, venueID, day, includeDrafts
+func (_m *Storage) ListEvents(ctx context.Context, limit int, offset int, venueID int, day string, includeDrafts bool) ([]models.Event, int, error) {
+	ret := _m.Called(ctx, limit, offset, venueID, day, includeDrafts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEvents")
+	}
+
+	var r0 []models.Event
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, string, bool) ([]models.Event, int, error)); ok {
+		return rf(ctx, limit, offset, venueID, day, includeDrafts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, string, bool) []models.Event); ok {
+		r0 = rf(ctx, limit, offset, venueID, day, includeDrafts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, string, bool) int); ok {
+		r1 = rf(ctx, limit, offset, venueID, day, includeDrafts)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, int, string, bool) error); ok {
+		r2 = rf(ctx, limit, offset, venueID, day, includeDrafts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PendingBookingPrice provides a mock function with given fields: ctx, eventID, userID
+func (_m *Storage) PendingBookingPrice(ctx context.Context, eventID int, userID string) (int64, error) {
+	ret := _m.Called(ctx, eventID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingBookingPrice")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (int64, error)); ok {
+		return rf(ctx, eventID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) int64); ok {
+		r0 = rf(ctx, eventID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, eventID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetEventStatus provides a mock function with given fields: ctx, eventID, status
+func (_m *Storage) SetEventStatus(ctx context.Context, eventID int, status string) (int, error) {
+	ret := _m.Called(ctx, eventID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEventStatus")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (int, error)); ok {
+		return rf(ctx, eventID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) int); ok {
+		r0 = rf(ctx, eventID, status)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, eventID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEvent provides a mock function with given fields: ctx, id, title, date, totalSeats, deadline
+func (_m *Storage) UpdateEvent(ctx context.Context, id int, title string, date time.Time, totalSeats int, deadline int) error {
+	ret := _m.Called(ctx, id, title, date, totalSeats, deadline)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, time.Time, int, int) error); ok {
+		r0 = rf(ctx, id, title, date, totalSeats, deadline)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Storage {
+	mock := &Storage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// print writes v as indented JSON, or t as aligned columns in table mode.
+func (p *printer) print(v any, t table) error {
+	if p.format == formatJSON {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	if len(t.header) > 0 {
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	}
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// message prints a one-line result of a command, or v in JSON mode.
+func (p *printer) message(v any, format string, args ...any) error {
+	return p.print(v, table{rows: [][]string{{fmt.Sprintf(format, args...)}}})
+}
@@ -11,6 +11,14 @@ type Config struct {
 	Env        string     `yaml:"env" env-default:"local"`
 	Database   Database   `yaml:"database"`
 	HTTPServer HTTPServer `yaml:"http_server"`
+	Auth       Auth       `yaml:"auth"`
+	Metrics    Metrics    `yaml:"metrics"`
+	Tracing    Tracing    `yaml:"tracing"`
+	Validation Validation `yaml:"validation"`
+	Calendar   Calendar   `yaml:"calendar"`
+	Tickets    Tickets    `yaml:"tickets"`
+	Payments   Payments   `yaml:"payments"`
+	Series     Series     `yaml:"series"`
 }
 
 type Database struct {
@@ -23,9 +31,84 @@ type Database struct {
 }
 
 type HTTPServer struct {
-	Address     string        `yaml:"address" env-default:"localhost:8080"`
-	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
-	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
+	Address         string        `yaml:"address" env-default:"localhost:8080"`
+	Timeout         time.Duration `yaml:"timeout" env-default:"4s"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" env-default:"60s"`
+	ShutdownDelay   time.Duration `yaml:"shutdown_delay" env-default:"5s"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"10s"`
+	RateLimit       RateLimit     `yaml:"rate_limit"`
+	Idempotency     Idempotency   `yaml:"idempotency"`
+}
+
+type Idempotency struct {
+	TTL time.Duration `yaml:"ttl" env-default:"24h"`
+}
+
+type RateLimit struct {
+	Enabled bool                  `yaml:"enabled" env-default:"false"`
+	Backend string                `yaml:"backend" env-default:"memory"`
+	Routes  map[string]RouteLimit `yaml:"routes"`
+}
+
+type RouteLimit struct {
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+	Burst    int           `yaml:"burst"`
+	KeyBy    []string      `yaml:"key_by"`
+}
+
+type Auth struct {
+	// APIKeys maps the API keys sent in the X-API-Key header to the users they authenticate.
+	// Requests without a key are anonymous and cannot use routes requiring a role.
+	APIKeys map[string]string `yaml:"api_keys"`
+}
+
+type Metrics struct {
+	Enabled        bool `yaml:"enabled" env-default:"true"`
+	MaxEventSeries int  `yaml:"max_event_series" env-default:"100"`
+}
+
+type Tracing struct {
+	Exporter    string  `yaml:"exporter" env-default:"none"`
+	Endpoint    string  `yaml:"endpoint" env-default:"localhost:4318"`
+	Insecure    bool    `yaml:"insecure" env-default:"true"`
+	ServiceName string  `yaml:"service_name" env-default:"event-booker"`
+	SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+}
+
+type Validation struct {
+	MaxSeats int `yaml:"max_seats" env-default:"10000"`
+}
+
+type Calendar struct {
+	// Secret signs private feed URLs of users' bookings. Feeds are disabled while it is empty.
+	Secret string `yaml:"secret" env:"CALENDAR_SECRET"`
+	// Domain makes event UIDs globally unique, e.g. event-1@events.example.com.
+	Domain string `yaml:"domain" env-default:"event-booker"`
+}
+
+type Tickets struct {
+	// SigningKey is a base64 encoded 32 byte Ed25519 seed. Tickets and check-in are disabled while it is empty.
+	SigningKey string `yaml:"signing_key" env:"TICKET_SIGNING_KEY"`
+}
+
+type Payments struct {
+	// Provider takes payments for priced ticket types: "fake" for local development.
+	// Payments are disabled while it is empty and priced bookings are confirmed without paying.
+	Provider string      `yaml:"provider" env:"PAYMENT_PROVIDER"`
+	Fake     FakePayment `yaml:"fake"`
+}
+
+type FakePayment struct {
+	// Secret signs the callbacks of the fake checkout page. It is required with the fake provider.
+	Secret string `yaml:"secret" env:"FAKE_PAYMENT_SECRET"`
+	// WebhookURL is where the fake checkout page sends callbacks, i.e. this server's webhook.
+	WebhookURL string `yaml:"webhook_url" env:"FAKE_PAYMENT_WEBHOOK_URL" env-default:"http://localhost:8080/api/v1/payments/webhook"`
+}
+
+type Series struct {
+	// Horizon is how far ahead the occurrences of recurring event series are created as events.
+	Horizon time.Duration `yaml:"horizon" env-default:"2160h"`
 }
 
 func MustLoad() *Config {
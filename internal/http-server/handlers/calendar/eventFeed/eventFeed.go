@@ -0,0 +1,69 @@
+package eventFeed
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/ical"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=EventGetter
+type EventGetter interface {
+	GetEvent(ctx context.Context, id int) (*models.Event, error)
+}
+
+// New serves a single event as an iCalendar file, for GET /events/{id}.ics.
+func New(log *slog.Logger, getter EventGetter, domain string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.calendar.eventFeed.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		event, err := getter.GetEvent(r.Context(), eventID)
+		if err != nil {
+			log.Error("failed to get event", sl.Err(err))
+
+			if err.Error() == "event not found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get event")
+			return
+		}
+
+		w.Header().Set("Content-Type", ical.ContentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="event-`+eventIdStr+`.ics"`)
+
+		err = ical.Write(w, ical.Calendar{
+			Name:   event.Title,
+			Events: []ical.Event{ical.FromEvent(*event, domain, time.Now())},
+		})
+		if err != nil {
+			log.Error("failed to write calendar", sl.Err(err))
+		}
+	}
+}
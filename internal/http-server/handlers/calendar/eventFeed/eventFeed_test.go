@@ -0,0 +1,117 @@
+package eventFeed
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/calendar/eventFeed/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFeedHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		url            string
+		mockSetup      func(m *mocks.EventGetter)
+		expectedStatus int
+		expectedLines  []string
+		expectedBody   string
+	}{
+		{
+			name: "Success",
+			url:  "/api/v1/events/1.ics",
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEvent", mock.Anything, 1).Return(&models.Event{
+					ID:          1,
+					Title:       "Go meetup; talks, pizza",
+					Date:        time.Date(2099, 6, 1, 18, 30, 0, 0, time.FixedZone("MSK", 3*60*60)),
+					TotalSeats:  50,
+					BookedSeats: 20,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedLines: []string{
+				"BEGIN:VCALENDAR\r\n",
+				`X-WR-CALNAME:Go meetup\; talks\, pizza` + "\r\n",
+				"UID:event-1@test.example\r\n",
+				"DTSTART:20990601T153000Z\r\n",
+				`SUMMARY:Go meetup\; talks\, pizza` + "\r\n",
+				"DESCRIPTION:30 of 50 seats available\r\n",
+				"END:VCALENDAR\r\n",
+			},
+		},
+		{
+			name:           "Invalid event ID format",
+			url:            "/api/v1/events/abc.ics",
+			mockSetup:      func(m *mocks.EventGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name: "Event not found",
+			url:  "/api/v1/events/42.ics",
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEvent", mock.Anything, 42).Return(nil, errors.New("event not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name: "Storage error",
+			url:  "/api/v1/events/1.ics",
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEvent", mock.Anything, 1).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get event","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewEventGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Use(middleware.URLFormat)
+			r.Get("/api/v1/events/{id}", New(logger, mockGetter, "test.example"))
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedStatus != http.StatusOK {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+				return
+			}
+
+			assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="event-1.ics"`, rr.Header().Get("Content-Disposition"))
+			for _, line := range tc.expectedLines {
+				assert.Contains(t, rr.Body.String(), line)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// EventGetter is an autogenerated mock type for the EventGetter type
+type EventGetter struct {
+	mock.Mock
+}
+
+// GetEvent provides a mock function with given fields: ctx, id
+func (_m *EventGetter) GetEvent(ctx context.Context, id int) (*models.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvent")
+	}
+
+	var r0 *models.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventGetter creates a new instance of EventGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventGetter {
+	mock := &EventGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventsGetter is an autogenerated mock type for the EventsGetter type
+type EventsGetter struct {
+	mock.Mock
+}
+
+// GetAllEvents provides a mock function with given fields: ctx
+func (_m *EventsGetter) GetAllEvents(ctx context.Context) ([]models.Event, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllEvents")
+	}
+
+	var r0 []models.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Event, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Event); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventsGetter creates a new instance of EventsGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventsGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventsGetter {
+	mock := &EventsGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
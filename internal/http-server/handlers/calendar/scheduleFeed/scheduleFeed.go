@@ -0,0 +1,47 @@
+package scheduleFeed
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/ical"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const calendarName = "Event Booker"
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=EventsGetter
+type EventsGetter interface {
+	GetAllEvents(ctx context.Context) ([]models.Event, error)
+}
+
+// New serves every event as a subscribable iCalendar feed, for GET /events.ics.
+func New(log *slog.Logger, getter EventsGetter, domain string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.calendar.scheduleFeed.New"
+
+		log = log.With(slog.String("op", op))
+
+		events, err := getter.GetAllEvents(r.Context())
+		if err != nil {
+			log.Error("failed to get events", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get events")
+			return
+		}
+
+		now := time.Now()
+		cal := ical.Calendar{Name: calendarName}
+		for _, e := range events {
+			cal.Events = append(cal.Events, ical.FromEvent(e, domain, now))
+		}
+
+		w.Header().Set("Content-Type", ical.ContentType)
+
+		if err = ical.Write(w, cal); err != nil {
+			log.Error("failed to write calendar", sl.Err(err))
+		}
+	}
+}
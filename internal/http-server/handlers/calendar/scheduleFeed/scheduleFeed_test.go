@@ -0,0 +1,96 @@
+package scheduleFeed
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/calendar/scheduleFeed/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleFeedHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		mockSetup      func(m *mocks.EventsGetter)
+		expectedStatus int
+		expectedEvents int
+		expectedBody   string
+	}{
+		{
+			name: "Success",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("GetAllEvents", mock.Anything).Return([]models.Event{
+					{ID: 1, Title: "Go meetup", Date: time.Date(2099, 6, 1, 18, 0, 0, 0, time.UTC), TotalSeats: 50},
+					{ID: 2, Title: "Rust meetup", Date: time.Date(2099, 7, 1, 18, 0, 0, 0, time.UTC), TotalSeats: 30},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedEvents: 2,
+		},
+		{
+			name: "No events",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("GetAllEvents", mock.Anything).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Storage error",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("GetAllEvents", mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get events","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewEventsGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Use(middleware.URLFormat)
+			r.Get("/api/v1/events", New(logger, mockGetter, "test.example"))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/events.ics", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedStatus != http.StatusOK {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+				return
+			}
+
+			body := rr.Body.String()
+			assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+			assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\n"))
+			assert.Contains(t, body, "X-WR-CALNAME:Event Booker\r\n")
+			assert.Equal(t, tc.expectedEvents, strings.Count(body, "BEGIN:VEVENT\r\n"))
+			assert.True(t, strings.HasSuffix(body, "END:VCALENDAR\r\n"))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserEventsGetter is an autogenerated mock type for the UserEventsGetter type
+type UserEventsGetter struct {
+	mock.Mock
+}
+
+// GetUserConfirmedEvents provides a mock function with given fields: ctx, userID
+func (_m *UserEventsGetter) GetUserConfirmedEvents(ctx context.Context, userID string) ([]models.Event, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserConfirmedEvents")
+	}
+
+	var r0 []models.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Event, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Event); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUserEventsGetter creates a new instance of UserEventsGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserEventsGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserEventsGetter {
+	mock := &UserEventsGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,63 @@
+package userFeed
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/feedtoken"
+	"eventBooker/internal/lib/ical"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const calendarName = "Event Booker: my bookings"
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=UserEventsGetter
+type UserEventsGetter interface {
+	GetUserConfirmedEvents(ctx context.Context, userID string) ([]models.Event, error)
+}
+
+// New serves the events a user holds confirmed bookings for as a private
+// iCalendar feed. The user is identified by the signed token in the URL.
+func New(log *slog.Logger, getter UserEventsGetter, tokens *feedtoken.Signer, domain string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.calendar.userFeed.New"
+
+		log = log.With(slog.String("op", op))
+
+		userID, err := tokens.Verify(chi.URLParam(r, "token"))
+		if err != nil {
+			log.Warn("invalid feed token")
+			response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "calendar not found")
+			return
+		}
+
+		events, err := getter.GetUserConfirmedEvents(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to get user events", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get events")
+			return
+		}
+
+		now := time.Now()
+		cal := ical.Calendar{Name: calendarName}
+		for _, e := range events {
+			event := ical.FromEvent(e, domain, now)
+			event.Description = ""
+			event.Status = ical.StatusConfirmed
+			cal.Events = append(cal.Events, event)
+		}
+
+		w.Header().Set("Content-Type", ical.ContentType)
+		// The URL is a credential, keep it and the feed out of shared caches.
+		w.Header().Set("Cache-Control", "private, no-store")
+
+		if err = ical.Write(w, cal); err != nil {
+			log.Error("failed to write calendar", sl.Err(err))
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package userFeed
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/calendar/userFeed/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/feedtoken"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSigner = feedtoken.New("test-secret")
+
+func TestUserFeedHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		token          string
+		mockSetup      func(m *mocks.UserEventsGetter)
+		expectedStatus int
+		expectedLines  []string
+		expectedBody   string
+	}{
+		{
+			name:  "Success",
+			token: testSigner.Sign("user123"),
+			mockSetup: func(m *mocks.UserEventsGetter) {
+				m.On("GetUserConfirmedEvents", mock.Anything, "user123").Return([]models.Event{
+					{ID: 7, Title: "Go meetup", Date: time.Date(2099, 6, 1, 18, 0, 0, 0, time.UTC), TotalSeats: 50, BookedSeats: 1},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedLines: []string{
+				"UID:event-7@test.example\r\n",
+				"DTSTART:20990601T180000Z\r\n",
+				"STATUS:CONFIRMED\r\n",
+			},
+		},
+		{
+			name:           "Token signed with another secret",
+			token:          feedtoken.New("other-secret").Sign("user123"),
+			mockSetup:      func(m *mocks.UserEventsGetter) {},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"calendar not found","code":"not_found"}`,
+		},
+		{
+			name:           "Malformed token",
+			token:          "user123",
+			mockSetup:      func(m *mocks.UserEventsGetter) {},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"calendar not found","code":"not_found"}`,
+		},
+		{
+			name:  "Storage error",
+			token: testSigner.Sign("user123"),
+			mockSetup: func(m *mocks.UserEventsGetter) {
+				m.On("GetUserConfirmedEvents", mock.Anything, "user123").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get events","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewUserEventsGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Use(middleware.URLFormat)
+			r.Get("/api/v1/calendars/{token}", New(logger, mockGetter, testSigner, "test.example"))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/calendars/"+tc.token+".ics", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedStatus != http.StatusOK {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+				return
+			}
+
+			assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+			assert.Equal(t, "private, no-store", rr.Header().Get("Cache-Control"))
+			for _, line := range tc.expectedLines {
+				assert.Contains(t, rr.Body.String(), line)
+			}
+			assert.NotContains(t, rr.Body.String(), "DESCRIPTION")
+		})
+	}
+}
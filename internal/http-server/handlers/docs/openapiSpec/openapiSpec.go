@@ -0,0 +1,28 @@
+package openapiSpec
+
+import (
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func New(log *slog.Logger, doc *openapi3.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.docs.openapiSpec.New"
+
+		log := log.With(slog.String("op", op))
+
+		spec, err := doc.MarshalJSON()
+		if err != nil {
+			log.Error("failed to marshal openapi spec", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get openapi spec")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	}
+}
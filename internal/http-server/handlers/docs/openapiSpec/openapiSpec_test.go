@@ -0,0 +1,32 @@
+package openapiSpec
+
+import (
+	"eventBooker/api"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPISpecHandler(t *testing.T) {
+	t.Parallel()
+
+	doc, err := api.Load()
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	New(slogdiscard.NewDiscardLogger(), doc).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	served, err := openapi3.NewLoader().LoadFromData(rr.Body.Bytes())
+	require.NoError(t, err)
+
+	assert.Equal(t, doc.Info.Title, served.Info.Title)
+	assert.NotNil(t, served.Paths.Find("/api/v1/events/{id}/book"))
+}
@@ -0,0 +1,85 @@
+package cancelBooking
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type BookingRequest struct {
+	UserId string `json:"user_id" validate:"required"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=BookingCanceller
+type BookingCanceller interface {
+	CancelBooking(ctx context.Context, eventID int, userID string) error
+}
+
+func New(log *slog.Logger, v *validator.Validate, booking BookingCanceller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.cancelBooking.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		var req BookingRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			if errors.As(err, &validateErr) {
+				log.Error("invalid request", sl.Err(err))
+				response.ValidationError(w, r, validateErr)
+				return
+			}
+		}
+
+		err = booking.CancelBooking(r.Context(), eventID, req.UserId)
+		if err != nil {
+			log.Error("failed to cancel booking", sl.Err(err))
+
+			switch err.Error() {
+			case "no booking found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "no booking found for this user")
+				return
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to cancel booking")
+				return
+			}
+		}
+
+		log.Info("booking cancelled successfully", slog.String("user_id", req.UserId))
+
+		response.OK(w, r, nil)
+	}
+}
@@ -0,0 +1,184 @@
+package cancelBooking
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/event/cancelBooking/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestCancelBookingHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		requestBody    string
+		mockSetup      func(m *mocks.BookingCanceller)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.BookingCanceller) {
+				m.On("CancelBooking", mock.Anything, 1, "user123").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":null,"meta":{}}`,
+		},
+		{
+			name:           "Missing event ID",
+			eventID:        "",
+			requestBody:    `{"user_id": "user123"}`,
+			mockSetup:      func(m *mocks.BookingCanceller) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"event id is required","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "invalid",
+			requestBody:    `{"user_id": "user123"}`,
+			mockSetup:      func(m *mocks.BookingCanceller) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			eventID:        "1",
+			requestBody:    `invalid json`,
+			mockSetup:      func(m *mocks.BookingCanceller) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:        "No booking found",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.BookingCanceller) {
+				m.On("CancelBooking", mock.Anything, 1, "user123").Return(errors.New("no booking found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"no booking found for this user","code":"not_found"}`,
+		},
+		{
+			name:        "Internal server error",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.BookingCanceller) {
+				m.On("CancelBooking", mock.Anything, 1, "user123").Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to cancel booking","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCanceller := mocks.NewBookingCanceller(t)
+			tc.mockSetup(mockCanceller)
+
+			handler := New(logger, testValidator, mockCanceller)
+
+			url := "/api/v1/events/cancel"
+			if tc.eventID != "" {
+				url = "/api/v1/events/" + tc.eventID + "/cancel"
+			}
+
+			req, err := http.NewRequest("POST", url, bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			router := chi.NewRouter()
+			router.Route("/api/v1/events", func(r chi.Router) {
+				r.Route("/{id}", func(r chi.Router) {
+					r.Post("/cancel", handler)
+				})
+				r.Post("/cancel", handler)
+			})
+
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+
+			if tc.eventID != "" {
+				openapitest.ValidateResponse(t, req, rr)
+			}
+
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			}
+
+			if tc.expectedStatus == http.StatusOK ||
+				tc.expectedStatus == http.StatusNotFound ||
+				tc.expectedStatus == http.StatusInternalServerError {
+				mockCanceller.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestHandlerWithChiContext(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+	mockCanceller := mocks.NewBookingCanceller(t)
+	handler := New(logger, testValidator, mockCanceller)
+
+	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"user_id": "test"}`))
+	require.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "123")
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+
+	mockCanceller.On("CancelBooking", mock.Anything, 123, "test").Return(nil)
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockCanceller.AssertExpectations(t)
+}
+
+func TestHandlerWithoutChiContext(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+	mockCanceller := mocks.NewBookingCanceller(t)
+	handler := New(logger, testValidator, mockCanceller)
+
+	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"user_id": "test"}`))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "event id is required")
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BookingCanceller is an autogenerated mock type for the BookingCanceller type
+type BookingCanceller struct {
+	mock.Mock
+}
+
+// CancelBooking provides a mock function with given fields: ctx, eventID, userID
+func (_m *BookingCanceller) CancelBooking(ctx context.Context, eventID int, userID string) error {
+	ret := _m.Called(ctx, eventID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelBooking")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, eventID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBookingCanceller creates a new instance of BookingCanceller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBookingCanceller(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BookingCanceller {
+	mock := &BookingCanceller{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
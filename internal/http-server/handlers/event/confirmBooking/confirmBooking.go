@@ -1,6 +1,7 @@
 package confirmBooking
 
 import (
+	"context"
 	"errors"
 	"eventBooker/internal/lib/api/response"
 	"eventBooker/internal/lib/logger/sl"
@@ -16,16 +17,27 @@ type BookingRequest struct {
 	UserId string `json:"user_id" validate:"required"`
 }
 
-type BookingResponse struct {
-	response.Response
+type ConfirmResponse struct {
+	// CalendarURL is the user's private iCalendar feed of confirmed bookings.
+	CalendarURL string `json:"calendar_url,omitempty"`
+	// TicketURL is the QR code of the booking's ticket.
+	TicketURL string `json:"ticket_url,omitempty"`
+}
+
+// Links build the URLs returned with a confirmed booking. Nil links are left out.
+type Links struct {
+	Calendar func(userID string) string
+	Ticket   func(eventID int) string
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=BookingConfirmer
 type BookingConfirmer interface {
-	ConfirmBooking(eventID int, userID string) error
+	ConfirmBooking(ctx context.Context, eventID int, userID string) error
 }
 
-func New(log *slog.Logger, booking BookingConfirmer) http.HandlerFunc {
+// New confirms a pending booking. The response links the booking's ticket
+// and the user's calendar feed when they are enabled.
+func New(log *slog.Logger, v *validator.Validate, booking BookingConfirmer, links Links) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.event.confirmBooking.New"
 
@@ -34,16 +46,14 @@ func New(log *slog.Logger, booking BookingConfirmer) http.HandlerFunc {
 		eventIdStr := chi.URLParam(r, "id")
 		if eventIdStr == "" {
 			log.Error("event id is required")
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("event id is required"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
 			return
 		}
 
 		eventID, err := strconv.Atoi(eventIdStr)
 		if err != nil {
 			log.Error("invalid event id format", sl.Err(err))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid event id format"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
 			return
 		}
 
@@ -54,51 +64,56 @@ func New(log *slog.Logger, booking BookingConfirmer) http.HandlerFunc {
 		err = render.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err = validator.New().Struct(req); err != nil {
+		if err = v.Struct(req); err != nil {
 			var validateErr validator.ValidationErrors
 			if errors.As(err, &validateErr) {
 				log.Error("invalid request", sl.Err(err))
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, response.ValidationError(validateErr))
+				response.ValidationError(w, r, validateErr)
 				return
 			}
 		}
 
-		err = booking.ConfirmBooking(eventID, req.UserId)
+		err = booking.ConfirmBooking(r.Context(), eventID, req.UserId)
 		if err != nil {
 			log.Error("failed to confirm booking", sl.Err(err))
 
 			switch err.Error() {
 			case "no pending booking found":
-				render.Status(r, http.StatusNotFound)
-				render.JSON(w, r, response.Error("no pending booking found for this user"))
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "no pending booking found for this user")
 				return
 			case "no available seats":
-				render.Status(r, http.StatusConflict)
-				render.JSON(w, r, response.Error("no available seats"))
+				response.Error(w, r, http.StatusConflict, response.CodeNoAvailableSeats, "no available seats")
+				return
+			case "payment required":
+				response.Error(w, r, http.StatusPaymentRequired, response.CodePaymentRequired, "booking is confirmed by paying for it")
 				return
 			default:
-				render.Status(r, http.StatusInternalServerError)
-				render.JSON(w, r, response.Error("failed to confirm booking"))
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to confirm booking")
 				return
 			}
 		}
 
 		log.Info("booking confirmed successfully", slog.String("user_id", req.UserId))
 
-		responseOK(w, r)
-	}
-}
+		if links.Calendar == nil && links.Ticket == nil {
+			response.OK(w, r, nil)
+			return
+		}
 
-func responseOK(w http.ResponseWriter, r *http.Request) {
-	render.JSON(w, r, BookingResponse{
-		Response: response.OK(),
-	})
+		var resp ConfirmResponse
+		if links.Calendar != nil {
+			resp.CalendarURL = links.Calendar(req.UserId)
+		}
+		if links.Ticket != nil {
+			resp.TicketURL = links.Ticket(eventID)
+		}
+
+		response.OK(w, r, resp)
+	}
 }
@@ -3,20 +3,25 @@ package confirmBooking
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
+	"eventBooker/internal/config"
 	"eventBooker/internal/http-server/handlers/event/confirmBooking/mocks"
-	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/api/openapitest"
 	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
 func TestConfirmBookingHandler(t *testing.T) {
 	t.Parallel()
 
@@ -26,7 +31,7 @@ func TestConfirmBookingHandler(t *testing.T) {
 		name           string
 		eventID        string
 		requestBody    string
-		mockSetup      func(mock *mocks.BookingConfirmer)
+		mockSetup      func(m *mocks.BookingConfirmer)
 		expectedStatus int
 		expectedBody   string
 	}{
@@ -34,65 +39,75 @@ func TestConfirmBookingHandler(t *testing.T) {
 			name:        "Success",
 			eventID:     "1",
 			requestBody: `{"user_id": "user123"}`,
-			mockSetup: func(mock *mocks.BookingConfirmer) {
-				mock.On("ConfirmBooking", 1, "user123").Return(nil)
+			mockSetup: func(m *mocks.BookingConfirmer) {
+				m.On("ConfirmBooking", mock.Anything, 1, "user123").Return(nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"status":"OK"}`,
+			expectedBody:   `{"data":null,"meta":{}}`,
 		},
 		{
 			name:           "Missing event ID",
 			eventID:        "",
 			requestBody:    `{"user_id": "user123"}`,
-			mockSetup:      func(mock *mocks.BookingConfirmer) {},
+			mockSetup:      func(m *mocks.BookingConfirmer) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"event id is required"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"event id is required","code":"bad_request"}`,
 		},
 		{
 			name:           "Invalid event ID format",
 			eventID:        "invalid",
 			requestBody:    `{"user_id": "user123"}`,
-			mockSetup:      func(mock *mocks.BookingConfirmer) {},
+			mockSetup:      func(m *mocks.BookingConfirmer) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"invalid event id format"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
 		},
 		{
 			name:           "Invalid JSON",
 			eventID:        "1",
 			requestBody:    `invalid json`,
-			mockSetup:      func(mock *mocks.BookingConfirmer) {},
+			mockSetup:      func(m *mocks.BookingConfirmer) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"failed to decode request"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
 		},
 		{
 			name:        "No pending booking found",
 			eventID:     "1",
 			requestBody: `{"user_id": "user123"}`,
-			mockSetup: func(mock *mocks.BookingConfirmer) {
-				mock.On("ConfirmBooking", 1, "user123").Return(errors.New("no pending booking found"))
+			mockSetup: func(m *mocks.BookingConfirmer) {
+				m.On("ConfirmBooking", mock.Anything, 1, "user123").Return(errors.New("no pending booking found"))
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   `{"status":"Error","error":"no pending booking found for this user"}`,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"no pending booking found for this user","code":"not_found"}`,
 		},
 		{
 			name:        "No available seats",
 			eventID:     "1",
 			requestBody: `{"user_id": "user123"}`,
-			mockSetup: func(mock *mocks.BookingConfirmer) {
-				mock.On("ConfirmBooking", 1, "user123").Return(errors.New("no available seats"))
+			mockSetup: func(m *mocks.BookingConfirmer) {
+				m.On("ConfirmBooking", mock.Anything, 1, "user123").Return(errors.New("no available seats"))
 			},
 			expectedStatus: http.StatusConflict,
-			expectedBody:   `{"status":"Error","error":"no available seats"}`,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"no available seats","code":"no_available_seats"}`,
+		},
+		{
+			name:        "Payment required",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.BookingConfirmer) {
+				m.On("ConfirmBooking", mock.Anything, 1, "user123").Return(errors.New("payment required"))
+			},
+			expectedStatus: http.StatusPaymentRequired,
+			expectedBody:   `{"type":"about:blank","title":"Payment Required","status":402,"detail":"booking is confirmed by paying for it","code":"payment_required"}`,
 		},
 		{
 			name:        "Internal server error",
 			eventID:     "1",
 			requestBody: `{"user_id": "user123"}`,
-			mockSetup: func(mock *mocks.BookingConfirmer) {
-				mock.On("ConfirmBooking", 1, "user123").Return(errors.New("database error"))
+			mockSetup: func(m *mocks.BookingConfirmer) {
+				m.On("ConfirmBooking", mock.Anything, 1, "user123").Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to confirm booking"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to confirm booking","code":"internal_error"}`,
 		},
 	}
 
@@ -104,18 +119,18 @@ func TestConfirmBookingHandler(t *testing.T) {
 			mockConfirmer := mocks.NewBookingConfirmer(t)
 			tc.mockSetup(mockConfirmer)
 
-			handler := New(logger, mockConfirmer)
+			handler := New(logger, testValidator, mockConfirmer, Links{})
 
-			url := "/events/confirm"
+			url := "/api/v1/events/confirm"
 			if tc.eventID != "" {
-				url = "/events/" + tc.eventID + "/confirm"
+				url = "/api/v1/events/" + tc.eventID + "/confirm"
 			}
 
 			req, err := http.NewRequest("POST", url, bytes.NewBufferString(tc.requestBody))
 			require.NoError(t, err)
 
 			router := chi.NewRouter()
-			router.Route("/events", func(r chi.Router) {
+			router.Route("/api/v1/events", func(r chi.Router) {
 				r.Route("/{id}", func(r chi.Router) {
 					r.Post("/confirm", handler)
 				})
@@ -128,6 +143,10 @@ func TestConfirmBookingHandler(t *testing.T) {
 
 			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
 
+			if tc.eventID != "" {
+				openapitest.ValidateResponse(t, req, rr)
+			}
+
 			if tc.expectedBody != "" {
 				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
 			}
@@ -142,23 +161,55 @@ func TestConfirmBookingHandler(t *testing.T) {
 	}
 }
 
-func TestResponseOK(t *testing.T) {
+func TestLinks(t *testing.T) {
 	t.Parallel()
 
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
+	links := Links{
+		Calendar: func(userID string) string { return "/api/v1/calendars/token-of-" + userID + ".ics" },
+		Ticket: func(eventID int) string {
+			return "/api/v1/events/" + strconv.Itoa(eventID) + "/ticket.png"
+		},
+	}
 
-	responseOK(rr, req)
+	testCases := []struct {
+		name         string
+		links        Links
+		expectedBody string
+	}{
+		{
+			name:         "Calendar and ticket",
+			links:        links,
+			expectedBody: `{"data":{"calendar_url":"/api/v1/calendars/token-of-user123.ics","ticket_url":"/api/v1/events/1/ticket.png"},"meta":{}}`,
+		},
+		{
+			name:         "Ticket only",
+			links:        Links{Ticket: links.Ticket},
+			expectedBody: `{"data":{"ticket_url":"/api/v1/events/1/ticket.png"},"meta":{}}`,
+		},
+	}
 
-	assert.Equal(t, http.StatusOK, rr.Code)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
 
-	expectedResponse := response.OK()
-	var actualResponse BookingResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
+			mockConfirmer := mocks.NewBookingConfirmer(t)
+			mockConfirmer.On("ConfirmBooking", mock.Anything, 1, "user123").Return(nil)
+
+			router := chi.NewRouter()
+			router.Post("/api/v1/events/{id}/confirm", New(slogdiscard.NewDiscardLogger(), testValidator, mockConfirmer, tc.links))
 
-	assert.Equal(t, expectedResponse.Status, actualResponse.Status)
-	assert.Equal(t, expectedResponse.Error, actualResponse.Error)
+			req, err := http.NewRequest("POST", "/api/v1/events/1/confirm", bytes.NewBufferString(`{"user_id": "user123"}`))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			openapitest.ValidateResponse(t, req, rr)
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+		})
+	}
 }
 
 func TestHandlerWithChiContext(t *testing.T) {
@@ -166,7 +217,7 @@ func TestHandlerWithChiContext(t *testing.T) {
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockConfirmer := mocks.NewBookingConfirmer(t)
-	handler := New(logger, mockConfirmer)
+	handler := New(logger, testValidator, mockConfirmer, Links{})
 
 	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"user_id": "test"}`))
 	require.NoError(t, err)
@@ -179,7 +230,7 @@ func TestHandlerWithChiContext(t *testing.T) {
 
 	rr := httptest.NewRecorder()
 
-	mockConfirmer.On("ConfirmBooking", 123, "test").Return(nil)
+	mockConfirmer.On("ConfirmBooking", mock.Anything, 123, "test").Return(nil)
 
 	handler.ServeHTTP(rr, req)
 
@@ -192,7 +243,7 @@ func TestHandlerWithoutChiContext(t *testing.T) {
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockConfirmer := mocks.NewBookingConfirmer(t)
-	handler := New(logger, mockConfirmer)
+	handler := New(logger, testValidator, mockConfirmer, Links{})
 
 	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"user_id": "test"}`))
 	require.NoError(t, err)
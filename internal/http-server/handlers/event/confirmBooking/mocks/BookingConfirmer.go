@@ -2,24 +2,28 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // BookingConfirmer is an autogenerated mock type for the BookingConfirmer type
 type BookingConfirmer struct {
 	mock.Mock
 }
 
-// ConfirmBooking provides a mock function with given fields: eventID, userID
-func (_m *BookingConfirmer) ConfirmBooking(eventID int, userID string) error {
-	ret := _m.Called(eventID, userID)
+// ConfirmBooking provides a mock function with given fields: ctx, eventID, userID
+func (_m *BookingConfirmer) ConfirmBooking(ctx context.Context, eventID int, userID string) error {
+	ret := _m.Called(ctx, eventID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ConfirmBooking")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(eventID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, eventID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
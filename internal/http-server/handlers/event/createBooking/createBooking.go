@@ -1,6 +1,7 @@
 package createBooking
 
 import (
+	"context"
 	"errors"
 	"eventBooker/internal/lib/api/response"
 	"eventBooker/internal/lib/logger/sl"
@@ -10,22 +11,25 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type BookingRequest struct {
 	UserId string `json:"user_id" validate:"required"`
-}
-
-type BookingResponse struct {
-	response.Response
+	// TicketTypeID is required for events with ticket types and must be left out otherwise.
+	TicketTypeID int `json:"ticket_type_id,omitempty" validate:"omitempty,gt=0"`
+	// PromoCode discounts the booking. It is matched regardless of case.
+	PromoCode string `json:"promo_code,omitempty" validate:"omitempty,max=50"`
+	// SeatID is required for events with a seat layout and must be left out otherwise.
+	SeatID int `json:"seat_id,omitempty" validate:"omitempty,gt=0"`
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=BookingCreator
 type BookingCreator interface {
-	BookEvent(eventID int, userID string) error
+	BookEvent(ctx context.Context, eventID int, userID string, ticketTypeID int, promoCode string, seatID int) error
 }
 
-func New(log *slog.Logger, booking BookingCreator) http.HandlerFunc {
+func New(log *slog.Logger, v *validator.Validate, booking BookingCreator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.event.createBooking.New"
 
@@ -34,16 +38,14 @@ func New(log *slog.Logger, booking BookingCreator) http.HandlerFunc {
 		eventIdStr := chi.URLParam(r, "id")
 		if eventIdStr == "" {
 			log.Error("event id is required")
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("event id is required"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
 			return
 		}
 
 		eventID, err := strconv.Atoi(eventIdStr)
 		if err != nil {
 			log.Error("invalid event id format", sl.Err(err))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid event id format"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
 			return
 		}
 
@@ -54,51 +56,81 @@ func New(log *slog.Logger, booking BookingCreator) http.HandlerFunc {
 		err = render.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err = validator.New().Struct(req); err != nil {
+		req.PromoCode = strings.ToUpper(strings.TrimSpace(req.PromoCode))
+
+		if err = v.Struct(req); err != nil {
 			var validateErr validator.ValidationErrors
 			if errors.As(err, &validateErr) {
 				log.Error("invalid request", sl.Err(err))
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, response.ValidationError(validateErr))
+				response.ValidationError(w, r, validateErr)
 				return
 			}
 		}
 
-		err = booking.BookEvent(eventID, req.UserId)
+		err = booking.BookEvent(r.Context(), eventID, req.UserId, req.TicketTypeID, req.PromoCode, req.SeatID)
 		if err != nil {
 			log.Error("failed to book event", sl.Err(err))
 
 			switch err.Error() {
 			case "no available seats":
-				render.Status(r, http.StatusConflict)
-				render.JSON(w, r, response.Error("no available seats"))
+				response.Error(w, r, http.StatusConflict, response.CodeNoAvailableSeats, "no available seats")
 				return
 			case "user already has pending booking for this event":
-				render.Status(r, http.StatusConflict)
-				render.JSON(w, r, response.Error("user already has pending booking for this event"))
+				response.Error(w, r, http.StatusConflict, response.CodeDuplicateBooking, "user already has pending booking for this event")
+				return
+			case "event is not on sale":
+				response.Error(w, r, http.StatusConflict, response.CodeSalesClosed, "event is not on sale")
+				return
+			case "ticket type is required":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "ticket_type_id is required for this event")
+				return
+			case "ticket type not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "ticket type not found")
+				return
+			case "ticket type is not on sale":
+				response.Error(w, r, http.StatusConflict, response.CodeSalesClosed, "ticket type is not on sale")
+				return
+			case "seat is required":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "seat_id is required for this event")
+				return
+			case "event has no seat layout":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event has no reserved seating, seat_id must be left out")
+				return
+			case "seat not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "seat not found")
+				return
+			case "seat already taken":
+				response.Error(w, r, http.StatusConflict, response.CodeSeatTaken, "seat already taken")
+				return
+			case "promo code not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "promo code not found")
+				return
+			case "promo code is not active":
+				response.Error(w, r, http.StatusConflict, response.CodePromoCodeRejected, "promo code is not valid at this time")
+				return
+			case "promo code does not apply":
+				response.Error(w, r, http.StatusConflict, response.CodePromoCodeRejected, "promo code does not apply to this event or ticket type")
+				return
+			case "promo code usage limit reached":
+				response.Error(w, r, http.StatusConflict, response.CodePromoCodeRejected, "promo code has been used up")
+				return
+			case "promo code already used":
+				response.Error(w, r, http.StatusConflict, response.CodePromoCodeRejected, "promo code was already used by this user as many times as allowed")
 				return
 			default:
-				render.Status(r, http.StatusInternalServerError)
-				render.JSON(w, r, response.Error("failed to book event"))
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to book event")
 				return
 			}
 		}
 
 		log.Info("event booked successfully", slog.String("user_id", req.UserId))
 
-		responseOK(w, r)
+		response.OK(w, r, nil)
 	}
 }
-
-func responseOK(w http.ResponseWriter, r *http.Request) {
-	render.JSON(w, r, BookingResponse{
-		Response: response.OK(),
-	})
-}
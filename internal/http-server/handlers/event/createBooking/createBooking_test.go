@@ -3,11 +3,12 @@ package createBooking
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
+	"eventBooker/internal/config"
 	"eventBooker/internal/http-server/handlers/event/createBooking/mocks"
-	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/api/openapitest"
 	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -15,9 +16,12 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
 func TestCreateBookingHandler(t *testing.T) {
 	t.Parallel()
 
@@ -27,7 +31,7 @@ func TestCreateBookingHandler(t *testing.T) {
 		name           string
 		eventID        string
 		requestBody    string
-		mockSetup      func(mock *mocks.BookingCreator)
+		mockSetup      func(m *mocks.BookingCreator)
 		expectedStatus int
 		expectedBody   string
 		checkBody      func(t *testing.T, body string)
@@ -36,89 +40,229 @@ func TestCreateBookingHandler(t *testing.T) {
 			name:        "Success",
 			eventID:     "1",
 			requestBody: `{"user_id": "user123"}`,
-			mockSetup: func(mock *mocks.BookingCreator) {
-				mock.On("BookEvent", 1, "user123").Return(nil)
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 0).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"status":"OK"}`,
+			expectedBody:   `{"data":null,"meta":{}}`,
 		},
 		{
 			name:           "Missing event ID",
 			eventID:        "",
 			requestBody:    `{"user_id": "user123"}`,
-			mockSetup:      func(mock *mocks.BookingCreator) {},
+			mockSetup:      func(m *mocks.BookingCreator) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"event id is required"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"event id is required","code":"bad_request"}`,
 		},
 		{
 			name:           "Invalid event ID format",
 			eventID:        "invalid",
 			requestBody:    `{"user_id": "user123"}`,
-			mockSetup:      func(mock *mocks.BookingCreator) {},
+			mockSetup:      func(m *mocks.BookingCreator) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"invalid event id format"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
 		},
 		{
 			name:           "Invalid JSON",
 			eventID:        "1",
 			requestBody:    `invalid json`,
-			mockSetup:      func(mock *mocks.BookingCreator) {},
+			mockSetup:      func(m *mocks.BookingCreator) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"failed to decode request"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
 		},
 		{
 			name:           "Missing user_id",
 			eventID:        "1",
 			requestBody:    `{}`,
-			mockSetup:      func(mock *mocks.BookingCreator) {},
+			mockSetup:      func(m *mocks.BookingCreator) {},
 			expectedStatus: http.StatusBadRequest,
 			checkBody: func(t *testing.T, body string) {
-				assert.Contains(t, body, `"status":"Error"`)
-				assert.Contains(t, body, `"error":`)
-				assert.Contains(t, body, "UserId")
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"errors":`)
+				assert.Contains(t, body, `"field":"user_id"`)
 			},
 		},
 		{
 			name:           "Empty user_id",
 			eventID:        "1",
 			requestBody:    `{"user_id": ""}`,
-			mockSetup:      func(mock *mocks.BookingCreator) {},
+			mockSetup:      func(m *mocks.BookingCreator) {},
 			expectedStatus: http.StatusBadRequest,
 			checkBody: func(t *testing.T, body string) {
-				assert.Contains(t, body, `"status":"Error"`)
-				assert.Contains(t, body, `"error":`)
-				assert.Contains(t, body, "UserId")
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"errors":`)
+				assert.Contains(t, body, `"field":"user_id"`)
 			},
 		},
 		{
 			name:        "No available seats",
 			eventID:     "1",
 			requestBody: `{"user_id": "user123"}`,
-			mockSetup: func(mock *mocks.BookingCreator) {
-				mock.On("BookEvent", 1, "user123").Return(errors.New("no available seats"))
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 0).Return(errors.New("no available seats"))
 			},
 			expectedStatus: http.StatusConflict,
-			expectedBody:   `{"status":"Error","error":"no available seats"}`,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"no available seats","code":"no_available_seats"}`,
 		},
 		{
 			name:        "User already has pending booking",
 			eventID:     "1",
 			requestBody: `{"user_id": "user123"}`,
-			mockSetup: func(mock *mocks.BookingCreator) {
-				mock.On("BookEvent", 1, "user123").Return(errors.New("user already has pending booking for this event"))
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 0).Return(errors.New("user already has pending booking for this event"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"user already has pending booking for this event","code":"duplicate_booking"}`,
+		},
+		{
+			name:        "Success with ticket type",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "ticket_type_id": 2}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 2, "", 0).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":null,"meta":{}}`,
+		},
+		{
+			name:        "Ticket type required",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 0).Return(errors.New("ticket type is required"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"ticket_type_id is required for this event","code":"bad_request"}`,
+		},
+		{
+			name:        "Ticket type not found",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "ticket_type_id": 9}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 9, "", 0).Return(errors.New("ticket type not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"ticket type not found","code":"not_found"}`,
+		},
+		{
+			name:        "Event not on sale",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 0).Return(errors.New("event is not on sale"))
 			},
 			expectedStatus: http.StatusConflict,
-			expectedBody:   `{"status":"Error","error":"user already has pending booking for this event"}`,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"event is not on sale","code":"sales_closed"}`,
+		},
+		{
+			name:        "Ticket type not on sale",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "ticket_type_id": 2}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 2, "", 0).Return(errors.New("ticket type is not on sale"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"ticket type is not on sale","code":"sales_closed"}`,
+		},
+		{
+			name:        "With promo code",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "ticket_type_id": 2, "promo_code": " earlybird "}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 2, "EARLYBIRD", 0).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":null,"meta":{}}`,
+		},
+		{
+			name:        "Promo code not found",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "ticket_type_id": 2, "promo_code": "NOPE"}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 2, "NOPE", 0).Return(errors.New("promo code not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"promo code not found","code":"not_found"}`,
+		},
+		{
+			name:        "Promo code used up",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "ticket_type_id": 2, "promo_code": "PARTNER"}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 2, "PARTNER", 0).Return(errors.New("promo code usage limit reached"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"promo code has been used up","code":"promo_code_rejected"}`,
+		},
+		{
+			name:        "Promo code does not apply",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "ticket_type_id": 2, "promo_code": "PARTNER"}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 2, "PARTNER", 0).Return(errors.New("promo code does not apply"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"promo code does not apply to this event or ticket type","code":"promo_code_rejected"}`,
+		},
+		{
+			name:        "Success with seat",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "seat_id": 14}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 14).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":null,"meta":{}}`,
+		},
+		{
+			name:        "Seat required",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 0).Return(errors.New("seat is required"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"seat_id is required for this event","code":"bad_request"}`,
+		},
+		{
+			name:        "Seat for general admission event",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "seat_id": 14}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 14).Return(errors.New("event has no seat layout"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"event has no reserved seating, seat_id must be left out","code":"bad_request"}`,
+		},
+		{
+			name:        "Seat not found",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "seat_id": 999}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 999).Return(errors.New("seat not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"seat not found","code":"not_found"}`,
+		},
+		{
+			name:        "Seat already taken",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123", "seat_id": 14}`,
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 14).Return(errors.New("seat already taken"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"seat already taken","code":"seat_taken"}`,
 		},
 		{
 			name:        "Internal server error",
 			eventID:     "1",
 			requestBody: `{"user_id": "user123"}`,
-			mockSetup: func(mock *mocks.BookingCreator) {
-				mock.On("BookEvent", 1, "user123").Return(errors.New("database error"))
+			mockSetup: func(m *mocks.BookingCreator) {
+				m.On("BookEvent", mock.Anything, 1, "user123", 0, "", 0).Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to book event"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to book event","code":"internal_error"}`,
 		},
 	}
 
@@ -130,18 +274,18 @@ func TestCreateBookingHandler(t *testing.T) {
 			mockCreator := mocks.NewBookingCreator(t)
 			tc.mockSetup(mockCreator)
 
-			handler := New(logger, mockCreator)
+			handler := New(logger, testValidator, mockCreator)
 
-			url := "/events/book"
+			url := "/api/v1/events/book"
 			if tc.eventID != "" {
-				url = "/events/" + tc.eventID + "/book"
+				url = "/api/v1/events/" + tc.eventID + "/book"
 			}
 
 			req, err := http.NewRequest("POST", url, bytes.NewBufferString(tc.requestBody))
 			require.NoError(t, err)
 
 			router := chi.NewRouter()
-			router.Route("/events", func(r chi.Router) {
+			router.Route("/api/v1/events", func(r chi.Router) {
 				r.Route("/{id}", func(r chi.Router) {
 					r.Post("/book", handler)
 				})
@@ -154,6 +298,10 @@ func TestCreateBookingHandler(t *testing.T) {
 
 			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
 
+			if tc.eventID != "" {
+				openapitest.ValidateResponse(t, req, rr)
+			}
+
 			if tc.expectedBody != "" {
 				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
 			} else if tc.checkBody != nil {
@@ -169,31 +317,12 @@ func TestCreateBookingHandler(t *testing.T) {
 	}
 }
 
-func TestResponseOK(t *testing.T) {
-	t.Parallel()
-
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
-
-	responseOK(rr, req)
-
-	assert.Equal(t, http.StatusOK, rr.Code)
-
-	expectedResponse := response.OK()
-	var actualResponse BookingResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
-
-	assert.Equal(t, expectedResponse.Status, actualResponse.Status)
-	assert.Equal(t, expectedResponse.Error, actualResponse.Error)
-}
-
 func TestHandlerWithChiContext(t *testing.T) {
 	t.Parallel()
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockCreator := mocks.NewBookingCreator(t)
-	handler := New(logger, mockCreator)
+	handler := New(logger, testValidator, mockCreator)
 
 	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"user_id": "test"}`))
 	require.NoError(t, err)
@@ -206,7 +335,7 @@ func TestHandlerWithChiContext(t *testing.T) {
 
 	rr := httptest.NewRecorder()
 
-	mockCreator.On("BookEvent", 123, "test").Return(nil)
+	mockCreator.On("BookEvent", mock.Anything, 123, "test", 0, "", 0).Return(nil)
 
 	handler.ServeHTTP(rr, req)
 
@@ -219,7 +348,7 @@ func TestHandlerWithoutChiContext(t *testing.T) {
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockCreator := mocks.NewBookingCreator(t)
-	handler := New(logger, mockCreator)
+	handler := New(logger, testValidator, mockCreator)
 
 	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"user_id": "test"}`))
 	require.NoError(t, err)
@@ -237,7 +366,7 @@ func TestValidationErrors(t *testing.T) {
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockCreator := mocks.NewBookingCreator(t)
-	handler := New(logger, mockCreator)
+	handler := New(logger, testValidator, mockCreator)
 
 	testCases := []struct {
 		name           string
@@ -259,11 +388,11 @@ func TestValidationErrors(t *testing.T) {
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			req, err := http.NewRequest("POST", "/events/1/book", bytes.NewBufferString(tc.requestBody))
+			req, err := http.NewRequest("POST", "/api/v1/events/1/book", bytes.NewBufferString(tc.requestBody))
 			require.NoError(t, err)
 
 			router := chi.NewRouter()
-			router.Route("/events", func(r chi.Router) {
+			router.Route("/api/v1/events", func(r chi.Router) {
 				r.Route("/{id}", func(r chi.Router) {
 					r.Post("/book", handler)
 				})
@@ -274,9 +403,9 @@ func TestValidationErrors(t *testing.T) {
 
 			assert.Equal(t, tc.expectedStatus, rr.Code)
 
-			assert.Contains(t, rr.Body.String(), `"status":"Error"`)
-			assert.Contains(t, rr.Body.String(), `"error":`)
-			assert.Contains(t, rr.Body.String(), "UserId")
+			assert.Contains(t, rr.Body.String(), `"code":"validation_failed"`)
+			assert.Contains(t, rr.Body.String(), `"errors":`)
+			assert.Contains(t, rr.Body.String(), `"field":"user_id"`)
 		})
 	}
 }
@@ -286,13 +415,13 @@ func TestExactErrorMessage(t *testing.T) {
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockCreator := mocks.NewBookingCreator(t)
-	handler := New(logger, mockCreator)
+	handler := New(logger, testValidator, mockCreator)
 
-	req, err := http.NewRequest("POST", "/events/1/book", bytes.NewBufferString(`{}`))
+	req, err := http.NewRequest("POST", "/api/v1/events/1/book", bytes.NewBufferString(`{}`))
 	require.NoError(t, err)
 
 	router := chi.NewRouter()
-	router.Route("/events", func(r chi.Router) {
+	router.Route("/api/v1/events", func(r chi.Router) {
 		r.Route("/{id}", func(r chi.Router) {
 			r.Post("/book", handler)
 		})
@@ -307,9 +436,9 @@ func TestExactErrorMessage(t *testing.T) {
 
 	body := rr.Body.String()
 	validMessages := []string{
-		"field UserId is a required field",
-		"Key: 'BookingRequest.UserId' Error:Field validation for 'UserId' failed on the 'required' tag",
-		"UserId is a required field",
+		"field user_id is a required field",
+		"Key: 'BookingRequest.user_id' Error:Field validation for 'user_id' failed on the 'required' tag",
+		"user_id is a required field",
 	}
 
 	hasValidMessage := false
@@ -320,5 +449,5 @@ func TestExactErrorMessage(t *testing.T) {
 		}
 	}
 
-	assert.True(t, hasValidMessage, "Expected validation error about UserId, got: %s", body)
+	assert.True(t, hasValidMessage, "Expected validation error about user_id, got: %s", body)
 }
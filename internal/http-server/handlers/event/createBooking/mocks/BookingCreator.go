@@ -2,24 +2,28 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // BookingCreator is an autogenerated mock type for the BookingCreator type
 type BookingCreator struct {
 	mock.Mock
 }
 
-// BookEvent provides a mock function with given fields: eventID, userID
-func (_m *BookingCreator) BookEvent(eventID int, userID string) error {
-	ret := _m.Called(eventID, userID)
+// BookEvent provides a mock function with given fields: ctx, eventID, userID, ticketTypeID, promoCode, seatID
+func (_m *BookingCreator) BookEvent(ctx context.Context, eventID int, userID string, ticketTypeID int, promoCode string, seatID int) error {
+	ret := _m.Called(ctx, eventID, userID, ticketTypeID, promoCode, seatID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for BookEvent")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(eventID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int, string, int) error); ok {
+		r0 = rf(ctx, eventID, userID, ticketTypeID, promoCode, seatID)
 	} else {
 		r0 = ret.Error(0)
 	}
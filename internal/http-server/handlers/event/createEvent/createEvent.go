@@ -1,34 +1,72 @@
 package createEvent
 
 import (
+	"context"
 	"errors"
 	"eventBooker/internal/lib/api/response"
 	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 )
 
+// EventRequest describes a new event. An event at a venue may leave out
+// total_seats to get the venue's capacity and timezone to get the venue's
+// time zone. An event is published right away unless its status is draft
+// or it has a publish_at, and takes bookings between sales_open_at and
+// sales_close_at, either of which may be left out for no limit.
 type EventRequest struct {
-	Title      string    `json:"title" validate:"required"`
-	Date       time.Time `json:"date" validate:"required"`
-	TotalSeats int       `json:"total_seats" validate:"required"`
-	Deadline   int       `json:"deadline" validate:"required"`
+	Title      string    `json:"title" validate:"required,min=3,max=200"`
+	Date       time.Time `json:"date" validate:"required,future"`
+	TotalSeats int       `json:"total_seats" validate:"required_without=VenueID,omitempty,seats"`
+	Deadline   int       `json:"deadline" validate:"required,gt=0,ltuntil=Date"`
+	VenueID    int       `json:"venue_id,omitempty" validate:"omitempty,gt=0"`
+	Timezone   string    `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	Status     string    `json:"status,omitempty" validate:"omitempty,oneof=draft published"`
+	// PublishAt is when a draft is published, by hand if it is left out.
+	PublishAt    *time.Time `json:"publish_at,omitempty" validate:"omitempty,future"`
+	SalesOpenAt  *time.Time `json:"sales_open_at,omitempty"`
+	SalesCloseAt *time.Time `json:"sales_close_at,omitempty"`
+}
+
+// Schedule returns the lifecycle of the event. An event with a publish_at
+// is a draft until then.
+func (r *EventRequest) Schedule() (models.Schedule, error) {
+	schedule := models.Schedule{
+		Status:       r.Status,
+		PublishAt:    r.PublishAt,
+		SalesOpenAt:  r.SalesOpenAt,
+		SalesCloseAt: r.SalesCloseAt,
+	}
+
+	if r.PublishAt != nil {
+		if r.Status == models.EventPublished {
+			return models.Schedule{}, errors.New("publish_at is only allowed for drafts")
+		}
+		schedule.Status = models.EventDraft
+	}
+
+	if r.SalesOpenAt != nil && r.SalesCloseAt != nil && !r.SalesCloseAt.After(*r.SalesOpenAt) {
+		return models.Schedule{}, errors.New("sales_close_at must be after sales_open_at")
+	}
+
+	return schedule, nil
 }
 
 type EventResponse struct {
-	response.Response
 	EventId int `json:"event_id"`
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=EventCreator
 type EventCreator interface {
-	CreateEvent(title string, date time.Time, totalSeats, deadline int) (int, error)
+	CreateEvent(ctx context.Context, title string, date time.Time, totalSeats, deadline, venueID int, timezone string, schedule models.Schedule) (int, error)
 }
 
-func New(log *slog.Logger, event EventCreator) http.HandlerFunc {
+func New(log *slog.Logger, v *validator.Validate, event EventCreator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.event.createEvent.New"
 
@@ -41,43 +79,52 @@ func New(log *slog.Logger, event EventCreator) http.HandlerFunc {
 		err := render.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err = validator.New().Struct(req); err != nil {
+		req.Title = strings.TrimSpace(req.Title)
+		req.Timezone = strings.TrimSpace(req.Timezone)
+
+		if err = v.Struct(req); err != nil {
 			var validateErr validator.ValidationErrors
 			errors.As(err, &validateErr)
 
 			log.Error("invalid request", sl.Err(err))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.ValidationError(validateErr))
+			response.ValidationError(w, r, validateErr)
 
 			return
 		}
 
-		eventId, err := event.CreateEvent(req.Title, req.Date, req.TotalSeats, req.Deadline)
+		schedule, err := req.Schedule()
+		if err != nil {
+			log.Error("invalid schedule", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, err.Error())
+
+			return
+		}
+
+		eventId, err := event.CreateEvent(r.Context(), req.Title, req.Date, req.TotalSeats, req.Deadline, req.VenueID, req.Timezone, schedule)
 		if err != nil {
 			log.Error("failed to add event", sl.Err(err))
-			render.Status(r, http.StatusInternalServerError)
-			render.JSON(w, r, response.Error("failed to add event"))
+
+			switch err.Error() {
+			case "venue not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "venue not found")
+			case "event exceeds venue capacity":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "total_seats exceeds the capacity of the venue")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to add event")
+			}
 
 			return
 		}
 
 		log.Info("event added", slog.Int("id", eventId))
 
-		responseOK(w, r, eventId)
+		response.OK(w, r, EventResponse{EventId: eventId})
 	}
 }
-
-func responseOK(w http.ResponseWriter, r *http.Request, eventId int) {
-	render.JSON(w, r, EventResponse{
-		Response: response.OK(),
-		EventId:  eventId,
-	})
-}
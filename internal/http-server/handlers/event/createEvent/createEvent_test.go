@@ -4,28 +4,37 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"eventBooker/internal/config"
 	"eventBooker/internal/http-server/handlers/event/createEvent/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/api/response"
 	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
 func TestCreateEventHandler(t *testing.T) {
 	t.Parallel()
 
 	logger := slogdiscard.NewDiscardLogger()
 
-	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
+	testTime := time.Date(2099, 12, 25, 18, 0, 0, 0, time.UTC)
 
 	testCases := []struct {
 		name           string
 		requestBody    string
-		mockSetup      func(mock *mocks.EventCreator)
+		mockSetup      func(m *mocks.EventCreator)
 		expectedStatus int
 		expectedBody   string
 		checkBody      func(t *testing.T, body string)
@@ -34,36 +43,224 @@ func TestCreateEventHandler(t *testing.T) {
 			name: "Success",
 			requestBody: `{
 				"title": "Test Event",
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30
+			}`,
+			mockSetup: func(m *mocks.EventCreator) {
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 0, "", models.Schedule{}).Return(123, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":123},"meta":{}}`,
+		},
+		{
+			name: "Title is trimmed",
+			requestBody: `{
+				"title": "  Test Event\n",
+				"date": "2099-12-25T18:00:00Z",
 				"total_seats": 100,
 				"deadline": 30
 			}`,
-			mockSetup: func(mock *mocks.EventCreator) {
-				mock.On("CreateEvent", "Test Event", testTime, 100, 30).Return(123, nil)
+			mockSetup: func(m *mocks.EventCreator) {
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 0, "", models.Schedule{}).Return(124, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":124},"meta":{}}`,
+		},
+		{
+			name: "Seats from venue",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"deadline": 30,
+				"venue_id": 4
+			}`,
+			mockSetup: func(m *mocks.EventCreator) {
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 0, 30, 4, "", models.Schedule{}).Return(125, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":125},"meta":{}}`,
+		},
+		{
+			name: "Venue not found",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"deadline": 30,
+				"venue_id": 4
+			}`,
+			mockSetup: func(m *mocks.EventCreator) {
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 0, 30, 4, "", models.Schedule{}).Return(0, errors.New("venue not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"venue not found","code":"not_found"}`,
+		},
+		{
+			name: "Seats above venue capacity",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"venue_id": 4
+			}`,
+			mockSetup: func(m *mocks.EventCreator) {
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 4, "", models.Schedule{}).Return(0, errors.New("event exceeds venue capacity"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"total_seats exceeds the capacity of the venue","code":"bad_request"}`,
+		},
+		{
+			name: "With time zone",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"timezone": " Europe/Berlin "
+			}`,
+			mockSetup: func(m *mocks.EventCreator) {
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 0, "Europe/Berlin", models.Schedule{}).Return(126, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"status":"OK","event_id":123}`,
+			expectedBody:   `{"data":{"event_id":126},"meta":{}}`,
+		},
+		{
+			name: "Draft",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"status": "draft"
+			}`,
+			mockSetup: func(m *mocks.EventCreator) {
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 0, "", models.Schedule{Status: "draft"}).Return(127, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":127},"meta":{}}`,
+		},
+		{
+			name: "Scheduled publishing makes a draft",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"publish_at": "2099-12-01T09:00:00Z",
+				"sales_open_at": "2099-12-02T09:00:00Z",
+				"sales_close_at": "2099-12-25T12:00:00Z"
+			}`,
+			mockSetup: func(m *mocks.EventCreator) {
+				publishAt := time.Date(2099, 12, 1, 9, 0, 0, 0, time.UTC)
+				salesOpenAt := time.Date(2099, 12, 2, 9, 0, 0, 0, time.UTC)
+				salesCloseAt := time.Date(2099, 12, 25, 12, 0, 0, 0, time.UTC)
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 0, "", models.Schedule{
+					Status:       "draft",
+					PublishAt:    &publishAt,
+					SalesOpenAt:  &salesOpenAt,
+					SalesCloseAt: &salesCloseAt,
+				}).Return(128, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":128},"meta":{}}`,
+		},
+		{
+			name: "Published with publish time",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"status": "published",
+				"publish_at": "2099-12-01T09:00:00Z"
+			}`,
+			mockSetup:      func(m *mocks.EventCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"publish_at is only allowed for drafts","code":"bad_request"}`,
+		},
+		{
+			name: "Publish time in the past",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"publish_at": "2000-01-01T09:00:00Z"
+			}`,
+			mockSetup:      func(m *mocks.EventCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"field":"publish_at"`)
+			},
+		},
+		{
+			name: "Unknown status",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"status": "cancelled"
+			}`,
+			mockSetup:      func(m *mocks.EventCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"field":"status"`)
+			},
+		},
+		{
+			name: "Sales close before they open",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"sales_open_at": "2099-12-10T09:00:00Z",
+				"sales_close_at": "2099-12-10T09:00:00Z"
+			}`,
+			mockSetup:      func(m *mocks.EventCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"sales_close_at must be after sales_open_at","code":"bad_request"}`,
+		},
+		{
+			name: "Unknown time zone",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30,
+				"timezone": "Europe/Atlantis"
+			}`,
+			mockSetup:      func(m *mocks.EventCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"field":"timezone"`)
+			},
 		},
 		{
 			name:           "Invalid JSON",
 			requestBody:    `invalid json`,
-			mockSetup:      func(mock *mocks.EventCreator) {},
+			mockSetup:      func(m *mocks.EventCreator) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"failed to decode request"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
 		},
 		{
 			name: "Missing title",
 			requestBody: `{
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
 				"total_seats": 100,
 				"deadline": 30
 			}`,
-			mockSetup:      func(mock *mocks.EventCreator) {},
+			mockSetup:      func(m *mocks.EventCreator) {},
 			expectedStatus: http.StatusBadRequest,
 			checkBody: func(t *testing.T, body string) {
-				assert.Contains(t, body, `"status":"Error"`)
-				assert.Contains(t, body, `"error":`)
-				assert.Contains(t, body, "Title")
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"errors":`)
+				assert.Contains(t, body, `"field":"title"`)
 			},
 		},
 		{
@@ -73,58 +270,58 @@ func TestCreateEventHandler(t *testing.T) {
 				"total_seats": 100,
 				"deadline": 30
 			}`,
-			mockSetup:      func(mock *mocks.EventCreator) {},
+			mockSetup:      func(m *mocks.EventCreator) {},
 			expectedStatus: http.StatusBadRequest,
 			checkBody: func(t *testing.T, body string) {
-				assert.Contains(t, body, `"status":"Error"`)
-				assert.Contains(t, body, `"error":`)
-				assert.Contains(t, body, "Date")
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"errors":`)
+				assert.Contains(t, body, `"field":"date"`)
 			},
 		},
 		{
 			name: "Missing total_seats",
 			requestBody: `{
 				"title": "Test Event",
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
 				"deadline": 30
 			}`,
-			mockSetup:      func(mock *mocks.EventCreator) {},
+			mockSetup:      func(m *mocks.EventCreator) {},
 			expectedStatus: http.StatusBadRequest,
 			checkBody: func(t *testing.T, body string) {
-				assert.Contains(t, body, `"status":"Error"`)
-				assert.Contains(t, body, `"error":`)
-				assert.Contains(t, body, "TotalSeats")
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"errors":`)
+				assert.Contains(t, body, `"field":"total_seats"`)
 			},
 		},
 		{
 			name: "Missing deadline",
 			requestBody: `{
 				"title": "Test Event",
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
 				"total_seats": 100
 			}`,
-			mockSetup:      func(mock *mocks.EventCreator) {},
+			mockSetup:      func(m *mocks.EventCreator) {},
 			expectedStatus: http.StatusBadRequest,
 			checkBody: func(t *testing.T, body string) {
-				assert.Contains(t, body, `"status":"Error"`)
-				assert.Contains(t, body, `"error":`)
-				assert.Contains(t, body, "Deadline")
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"errors":`)
+				assert.Contains(t, body, `"field":"deadline"`)
 			},
 		},
 		{
 			name: "Empty title",
 			requestBody: `{
 				"title": "",
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
 				"total_seats": 100,
 				"deadline": 30
 			}`,
-			mockSetup:      func(mock *mocks.EventCreator) {},
+			mockSetup:      func(m *mocks.EventCreator) {},
 			expectedStatus: http.StatusBadRequest,
 			checkBody: func(t *testing.T, body string) {
-				assert.Contains(t, body, `"status":"Error"`)
-				assert.Contains(t, body, `"error":`)
-				assert.Contains(t, body, "Title")
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				assert.Contains(t, body, `"errors":`)
+				assert.Contains(t, body, `"field":"title"`)
 			},
 		},
 		{
@@ -135,23 +332,23 @@ func TestCreateEventHandler(t *testing.T) {
 				"total_seats": 100,
 				"deadline": 30
 			}`,
-			mockSetup:      func(mock *mocks.EventCreator) {},
+			mockSetup:      func(m *mocks.EventCreator) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"failed to decode request"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
 		},
 		{
 			name: "Internal server error",
 			requestBody: `{
 				"title": "Test Event",
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
 				"total_seats": 100,
 				"deadline": 30
 			}`,
-			mockSetup: func(mock *mocks.EventCreator) {
-				mock.On("CreateEvent", "Test Event", testTime, 100, 30).Return(0, errors.New("database error"))
+			mockSetup: func(m *mocks.EventCreator) {
+				m.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 0, "", models.Schedule{}).Return(0, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to add event"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to add event","code":"internal_error"}`,
 		},
 	}
 
@@ -163,9 +360,9 @@ func TestCreateEventHandler(t *testing.T) {
 			mockCreator := mocks.NewEventCreator(t)
 			tc.mockSetup(mockCreator)
 
-			handler := New(logger, mockCreator)
+			handler := New(logger, testValidator, mockCreator)
 
-			req, err := http.NewRequest("POST", "/events", bytes.NewBufferString(tc.requestBody))
+			req, err := http.NewRequest("POST", "/api/v1/events", bytes.NewBufferString(tc.requestBody))
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
@@ -173,6 +370,7 @@ func TestCreateEventHandler(t *testing.T) {
 			handler.ServeHTTP(rr, req)
 
 			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
 
 			if tc.expectedBody != "" {
 				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
@@ -187,31 +385,12 @@ func TestCreateEventHandler(t *testing.T) {
 	}
 }
 
-func TestResponseOK(t *testing.T) {
-	t.Parallel()
-
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
-
-	responseOK(rr, req, 456)
-
-	assert.Equal(t, http.StatusOK, rr.Code)
-
-	var actualResponse EventResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
-
-	assert.Equal(t, "OK", actualResponse.Status)
-	assert.Equal(t, "", actualResponse.Error)
-	assert.Equal(t, 456, actualResponse.EventId)
-}
-
 func TestValidationErrors(t *testing.T) {
 	t.Parallel()
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockCreator := mocks.NewEventCreator(t)
-	handler := New(logger, mockCreator)
+	handler := New(logger, testValidator, mockCreator)
 
 	testCases := []struct {
 		name           string
@@ -223,47 +402,113 @@ func TestValidationErrors(t *testing.T) {
 			name:           "Missing all required fields",
 			requestBody:    `{}`,
 			expectedStatus: http.StatusBadRequest,
-			expectedFields: []string{"Title", "Date", "TotalSeats", "Deadline"},
+			expectedFields: []string{"title", "date", "total_seats", "deadline"},
 		},
 		{
 			name: "Empty title",
 			requestBody: `{
 				"title": "",
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
 				"total_seats": 100,
 				"deadline": 30
 			}`,
 			expectedStatus: http.StatusBadRequest,
-			expectedFields: []string{"Title"},
+			expectedFields: []string{"title"},
 		},
 		{
 			name: "Zero total_seats",
 			requestBody: `{
 				"title": "Test Event",
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
 				"total_seats": 0,
 				"deadline": 30
 			}`,
 			expectedStatus: http.StatusBadRequest,
-			expectedFields: []string{"TotalSeats"},
+			expectedFields: []string{"total_seats"},
 		},
 		{
 			name: "Zero deadline",
 			requestBody: `{
 				"title": "Test Event",
-				"date": "2024-12-25T18:00:00Z",
+				"date": "2099-12-25T18:00:00Z",
 				"total_seats": 100,
 				"deadline": 0
 			}`,
 			expectedStatus: http.StatusBadRequest,
-			expectedFields: []string{"Deadline"},
+			expectedFields: []string{"deadline"},
+		},
+		{
+			name: "Negative total_seats",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": -5,
+				"deadline": 30
+			}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []string{"total_seats"},
+		},
+		{
+			name: "Too many seats",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 1001,
+				"deadline": 30
+			}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []string{"total_seats"},
+		},
+		{
+			name: "Negative deadline",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": -1
+			}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []string{"deadline"},
+		},
+		{
+			name: "Date in the past",
+			requestBody: `{
+				"title": "Test Event",
+				"date": "2020-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30
+			}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []string{"date"},
+		},
+		{
+			name: "Whitespace title",
+			requestBody: `{
+				"title": "   ",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30
+			}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []string{"title"},
+		},
+		{
+			name: "Title too long",
+			requestBody: `{
+				"title": "` + strings.Repeat("a", 201) + `",
+				"date": "2099-12-25T18:00:00Z",
+				"total_seats": 100,
+				"deadline": 30
+			}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []string{"title"},
 		},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			req, err := http.NewRequest("POST", "/events", bytes.NewBufferString(tc.requestBody))
+			req, err := http.NewRequest("POST", "/api/v1/events", bytes.NewBufferString(tc.requestBody))
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
@@ -272,11 +517,11 @@ func TestValidationErrors(t *testing.T) {
 			assert.Equal(t, tc.expectedStatus, rr.Code)
 
 			body := rr.Body.String()
-			assert.Contains(t, body, `"status":"Error"`)
-			assert.Contains(t, body, `"error":`)
+			assert.Contains(t, body, `"code":"validation_failed"`)
+			assert.Contains(t, body, `"errors":`)
 
 			for _, field := range tc.expectedFields {
-				assert.Contains(t, body, field)
+				assert.Contains(t, body, `"field":"`+field+`"`)
 			}
 		})
 	}
@@ -288,20 +533,20 @@ func TestSuccessResponseFormat(t *testing.T) {
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockCreator := mocks.NewEventCreator(t)
-	handler := New(logger, mockCreator)
+	handler := New(logger, testValidator, mockCreator)
 
 	// Mock setup
-	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
-	mockCreator.On("CreateEvent", "Test Event", testTime, 100, 30).Return(789, nil)
+	testTime := time.Date(2099, 12, 25, 18, 0, 0, 0, time.UTC)
+	mockCreator.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 0, "", models.Schedule{}).Return(789, nil)
 
 	// Create request
 	requestBody := `{
 		"title": "Test Event",
-		"date": "2024-12-25T18:00:00Z",
+		"date": "2099-12-25T18:00:00Z",
 		"total_seats": 100,
 		"deadline": 30
 	}`
-	req, err := http.NewRequest("POST", "/events", bytes.NewBufferString(requestBody))
+	req, err := http.NewRequest("POST", "/api/v1/events", bytes.NewBufferString(requestBody))
 	require.NoError(t, err)
 
 	rr := httptest.NewRecorder()
@@ -309,13 +554,11 @@ func TestSuccessResponseFormat(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 
-	var response EventResponse
-	err = json.Unmarshal(rr.Body.Bytes(), &response)
+	var resp EventResponse
+	err = json.Unmarshal(rr.Body.Bytes(), &response.Envelope{Data: &resp})
 	require.NoError(t, err)
 
-	assert.Equal(t, "OK", response.Status)
-	assert.Equal(t, "", response.Error)
-	assert.Equal(t, 789, response.EventId)
+	assert.Equal(t, 789, resp.EventId)
 
 	mockCreator.AssertExpectations(t)
 }
@@ -326,27 +569,27 @@ func TestEventCreatorErrorHandling(t *testing.T) {
 
 	logger := slogdiscard.NewDiscardLogger()
 	mockCreator := mocks.NewEventCreator(t)
-	handler := New(logger, mockCreator)
+	handler := New(logger, testValidator, mockCreator)
 
 	// Mock setup - возвращаем ошибку
-	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
-	mockCreator.On("CreateEvent", "Test Event", testTime, 100, 30).Return(0, errors.New("some database error"))
+	testTime := time.Date(2099, 12, 25, 18, 0, 0, 0, time.UTC)
+	mockCreator.On("CreateEvent", mock.Anything, "Test Event", testTime, 100, 30, 0, "", models.Schedule{}).Return(0, errors.New("some database error"))
 
 	// Create request
 	requestBody := `{
 		"title": "Test Event",
-		"date": "2024-12-25T18:00:00Z",
+		"date": "2099-12-25T18:00:00Z",
 		"total_seats": 100,
 		"deadline": 30
 	}`
-	req, err := http.NewRequest("POST", "/events", bytes.NewBufferString(requestBody))
+	req, err := http.NewRequest("POST", "/api/v1/events", bytes.NewBufferString(requestBody))
 	require.NoError(t, err)
 
 	rr := httptest.NewRecorder()
 	handler.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
-	assert.JSONEq(t, `{"status":"Error","error":"failed to add event"}`, rr.Body.String())
+	assert.JSONEq(t, `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to add event","code":"internal_error"}`, rr.Body.String())
 
 	mockCreator.AssertExpectations(t)
 }
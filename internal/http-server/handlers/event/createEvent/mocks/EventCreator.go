@@ -3,9 +3,13 @@
 package mocks
 
 import (
-	time "time"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+
+	time "time"
 )
 
 // EventCreator is an autogenerated mock type for the EventCreator type
@@ -13,9 +17,9 @@ type EventCreator struct {
 	mock.Mock
 }
 
-// CreateEvent provides a mock function with given fields: title, date, totalSeats, deadline
-func (_m *EventCreator) CreateEvent(title string, date time.Time, totalSeats int, deadline int) (int, error) {
-	ret := _m.Called(title, date, totalSeats, deadline)
+// CreateEvent provides a mock function with given fields: ctx, title, date, totalSeats, deadline, venueID, timezone, schedule
+func (_m *EventCreator) CreateEvent(ctx context.Context, title string, date time.Time, totalSeats int, deadline int, venueID int, timezone string, schedule models.Schedule) (int, error) {
+	ret := _m.Called(ctx, title, date, totalSeats, deadline, venueID, timezone, schedule)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateEvent")
@@ -23,17 +27,17 @@ func (_m *EventCreator) CreateEvent(title string, date time.Time, totalSeats int
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Time, int, int) (int, error)); ok {
-		return rf(title, date, totalSeats, deadline)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int, int, int, string, models.Schedule) (int, error)); ok {
+		return rf(ctx, title, date, totalSeats, deadline, venueID, timezone, schedule)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Time, int, int) int); ok {
-		r0 = rf(title, date, totalSeats, deadline)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int, int, int, string, models.Schedule) int); ok {
+		r0 = rf(ctx, title, date, totalSeats, deadline, venueID, timezone, schedule)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Time, int, int) error); ok {
-		r1 = rf(title, date, totalSeats, deadline)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, int, int, int, string, models.Schedule) error); ok {
+		r1 = rf(ctx, title, date, totalSeats, deadline, venueID, timezone, schedule)
 	} else {
 		r1 = ret.Error(1)
 	}
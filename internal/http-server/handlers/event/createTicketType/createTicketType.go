@@ -0,0 +1,118 @@
+package createTicketType
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TicketTypeRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	// Price is in the minor units of Currency, e.g. cents.
+	Price      int64      `json:"price" validate:"gte=0"`
+	Currency   string     `json:"currency" validate:"required,iso4217"`
+	Capacity   int        `json:"capacity" validate:"required,seats"`
+	SalesStart *time.Time `json:"sales_start,omitempty"`
+	SalesEnd   *time.Time `json:"sales_end,omitempty"`
+}
+
+type TicketTypeResponse struct {
+	TicketTypeID int `json:"ticket_type_id"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=TicketTypeCreator
+type TicketTypeCreator interface {
+	CreateTicketType(ctx context.Context, t models.TicketType) (int, error)
+}
+
+// New adds a priced ticket type to an event. Once an event has ticket
+// types, every booking must name one of them.
+func New(log *slog.Logger, v *validator.Validate, ticketTypes TicketTypeCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.createTicketType.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		var req TicketTypeRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		req.Name = strings.TrimSpace(req.Name)
+		req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		if req.SalesStart != nil && req.SalesEnd != nil && !req.SalesEnd.After(*req.SalesStart) {
+			log.Error("sales window ends before it starts")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "sales_end must be after sales_start")
+			return
+		}
+
+		id, err := ticketTypes.CreateTicketType(r.Context(), models.TicketType{
+			EventID:    eventID,
+			Name:       req.Name,
+			Price:      req.Price,
+			Currency:   req.Currency,
+			Capacity:   req.Capacity,
+			SalesStart: req.SalesStart,
+			SalesEnd:   req.SalesEnd,
+		})
+		if err != nil {
+			log.Error("failed to create ticket type", sl.Err(err))
+
+			switch err.Error() {
+			case "event not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+			case "ticket type already exists":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "event already has a ticket type with this name")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to create ticket type")
+			}
+			return
+		}
+
+		log.Info("ticket type created", slog.Int("id", id))
+
+		response.OK(w, r, TicketTypeResponse{TicketTypeID: id})
+	}
+}
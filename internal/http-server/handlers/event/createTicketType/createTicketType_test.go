@@ -0,0 +1,171 @@
+package createTicketType
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/event/createTicketType/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestCreateTicketTypeHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	salesStart := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	salesEnd := time.Date(2099, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		requestBody    string
+		mockSetup      func(m *mocks.TicketTypeCreator)
+		expectedStatus int
+		expectedBody   string
+		checkBody      func(t *testing.T, body string)
+	}{
+		{
+			name:        "Success",
+			eventID:     "1",
+			requestBody: `{"name": " Student ", "price": 500, "currency": "eur", "capacity": 20}`,
+			mockSetup: func(m *mocks.TicketTypeCreator) {
+				m.On("CreateTicketType", mock.Anything, models.TicketType{
+					EventID:  1,
+					Name:     "Student",
+					Price:    500,
+					Currency: "EUR",
+					Capacity: 20,
+				}).Return(3, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"ticket_type_id":3},"meta":{}}`,
+		},
+		{
+			name:        "Success with sales window",
+			eventID:     "1",
+			requestBody: `{"name": "Early bird", "price": 0, "currency": "USD", "capacity": 10, "sales_start": "2099-01-01T00:00:00Z", "sales_end": "2099-06-01T00:00:00Z"}`,
+			mockSetup: func(m *mocks.TicketTypeCreator) {
+				m.On("CreateTicketType", mock.Anything, models.TicketType{
+					EventID:    1,
+					Name:       "Early bird",
+					Currency:   "USD",
+					Capacity:   10,
+					SalesStart: &salesStart,
+					SalesEnd:   &salesEnd,
+				}).Return(4, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"ticket_type_id":4},"meta":{}}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "abc",
+			requestBody:    `{"name": "VIP", "price": 9900, "currency": "EUR", "capacity": 5}`,
+			mockSetup:      func(m *mocks.TicketTypeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			eventID:        "1",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.TicketTypeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid fields",
+			eventID:        "1",
+			requestBody:    `{"name": "  ", "price": -1, "currency": "XXZ", "capacity": 0}`,
+			mockSetup:      func(m *mocks.TicketTypeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"code":"validation_failed"`)
+				for _, field := range []string{"name", "price", "currency", "capacity"} {
+					assert.Contains(t, body, `"field":"`+field+`"`)
+				}
+			},
+		},
+		{
+			name:           "Sales end before start",
+			eventID:        "1",
+			requestBody:    `{"name": "VIP", "price": 9900, "currency": "EUR", "capacity": 5, "sales_start": "2099-06-01T00:00:00Z", "sales_end": "2099-01-01T00:00:00Z"}`,
+			mockSetup:      func(m *mocks.TicketTypeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"sales_end must be after sales_start","code":"bad_request"}`,
+		},
+		{
+			name:        "Event not found",
+			eventID:     "9",
+			requestBody: `{"name": "VIP", "price": 9900, "currency": "EUR", "capacity": 5}`,
+			mockSetup: func(m *mocks.TicketTypeCreator) {
+				m.On("CreateTicketType", mock.Anything, mock.Anything).Return(0, errors.New("event not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name:        "Duplicate name",
+			eventID:     "1",
+			requestBody: `{"name": "VIP", "price": 9900, "currency": "EUR", "capacity": 5}`,
+			mockSetup: func(m *mocks.TicketTypeCreator) {
+				m.On("CreateTicketType", mock.Anything, mock.Anything).Return(0, errors.New("ticket type already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"event already has a ticket type with this name","code":"conflict"}`,
+		},
+		{
+			name:        "Storage error",
+			eventID:     "1",
+			requestBody: `{"name": "VIP", "price": 9900, "currency": "EUR", "capacity": 5}`,
+			mockSetup: func(m *mocks.TicketTypeCreator) {
+				m.On("CreateTicketType", mock.Anything, mock.Anything).Return(0, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to create ticket type","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCreator := mocks.NewTicketTypeCreator(t)
+			tc.mockSetup(mockCreator)
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/events/{id}/ticket-types", New(logger, testValidator, mockCreator))
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/events/"+tc.eventID+"/ticket-types", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			} else if tc.checkBody != nil {
+				tc.checkBody(t, rr.Body.String())
+			}
+		})
+	}
+}
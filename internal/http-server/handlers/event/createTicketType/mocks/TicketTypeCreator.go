@@ -0,0 +1,58 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// TicketTypeCreator is an autogenerated mock type for the TicketTypeCreator type
+type TicketTypeCreator struct {
+	mock.Mock
+}
+
+// CreateTicketType provides a mock function with given fields: ctx, t
+func (_m *TicketTypeCreator) CreateTicketType(ctx context.Context, t models.TicketType) (int, error) {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTicketType")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.TicketType) (int, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.TicketType) int); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.TicketType) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTicketTypeCreator creates a new instance of TicketTypeCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTicketTypeCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TicketTypeCreator {
+	mock := &TicketTypeCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,47 +1,125 @@
 package getAllEvents
 
 import (
+	"context"
+	"eventBooker/internal/http-server/middleware/mwauth"
 	"eventBooker/internal/lib/api/response"
 	"eventBooker/internal/lib/logger/sl"
 	"eventBooker/internal/models"
-	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+// maxLimit caps the page size a client can request.
+const maxLimit = 500
+
 type EventsResponse struct {
-	response.Response
 	Events []models.Event `json:"events"`
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=EventsGetter
 type EventsGetter interface {
-	GetAllEvents() ([]models.Event, error)
+	ListEvents(ctx context.Context, limit, offset, venueID int, day string, includeDrafts bool) ([]models.Event, int, error)
+	HasRole(ctx context.Context, userID, role string) (bool, error)
 }
 
+// New lists the events. Drafts are hidden from the public and listed with
+// drafts=true only for a user authenticated with the admin role.
 func New(log *slog.Logger, eventsGetter EventsGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.event.getAllEvents.New"
 
 		log = log.With(slog.String("op", op))
 
-		events, err := eventsGetter.GetAllEvents()
+		limit, err := queryInt(r, "limit", 0)
+		if err != nil || limit < 0 || limit > maxLimit {
+			log.Error("invalid limit", slog.String("limit", r.URL.Query().Get("limit")))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "limit must be between 0 and "+strconv.Itoa(maxLimit))
+			return
+		}
+
+		offset, err := queryInt(r, "offset", 0)
+		if err != nil || offset < 0 {
+			log.Error("invalid offset", slog.String("offset", r.URL.Query().Get("offset")))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "offset must be a non-negative integer")
+			return
+		}
+
+		// venueID 0 lists the events of all venues.
+		venueID, err := queryInt(r, "venue_id", 0)
+		if err != nil || venueID < 0 || (venueID == 0 && r.URL.Query().Has("venue_id")) {
+			log.Error("invalid venue id", slog.String("venue_id", r.URL.Query().Get("venue_id")))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "venue_id must be a positive integer")
+			return
+		}
+
+		// day is evaluated in the time zone of each event, so "today" follows
+		// the calendar of the place an event is held at.
+		day := r.URL.Query().Get("day")
+		if day != "" && day != "today" {
+			if _, err = time.Parse(time.DateOnly, day); err != nil {
+				log.Error("invalid day", slog.String("day", day))
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "day must be today or a date in the format YYYY-MM-DD")
+				return
+			}
+		}
+
+		includeDrafts := false
+		if drafts := r.URL.Query().Get("drafts"); drafts != "" {
+			includeDrafts, err = strconv.ParseBool(drafts)
+			if err != nil {
+				log.Error("invalid drafts", slog.String("drafts", drafts))
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "drafts must be true or false")
+				return
+			}
+		}
+
+		if includeDrafts {
+			userID := mwauth.UserID(r.Context())
+			if userID == "" {
+				log.Error("drafts requested anonymously")
+				response.Error(w, r, http.StatusUnauthorized, response.CodeUnauthorized, "api key required")
+				return
+			}
+
+			isAdmin, err := eventsGetter.HasRole(r.Context(), userID, models.RoleAdmin)
+			if err != nil {
+				log.Error("failed to get user roles", sl.Err(err))
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get events")
+				return
+			}
+
+			if !isAdmin {
+				log.Error("drafts requested by a user without the admin role", slog.String("user_id", userID))
+				response.Error(w, r, http.StatusForbidden, response.CodeForbidden, "admin role required")
+				return
+			}
+		}
+
+		events, total, err := eventsGetter.ListEvents(r.Context(), limit, offset, venueID, day, includeDrafts)
 		if err != nil {
 			log.Error("failed to get events", sl.Err(err))
-			render.Status(r, http.StatusInternalServerError)
-			render.JSON(w, r, response.Error("failed to get events"))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get events")
 			return
 		}
 
 		log.Info("events retrieved successfully", slog.Int("count", len(events)))
 
-		responseOK(w, r, events)
+		response.List(w, r, EventsResponse{Events: events}, response.Pagination{
+			Limit:  limit,
+			Offset: offset,
+			Total:  total,
+		})
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, events []models.Event) {
-	render.JSON(w, r, EventsResponse{
-		Response: response.OK(),
-		Events:   events,
-	})
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+
+	return strconv.Atoi(value)
 }
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"eventBooker/internal/http-server/handlers/event/getAllEvents/mocks"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/api/response"
 	"eventBooker/internal/lib/logger/handlers/slogdiscard"
 	"eventBooker/internal/models"
 	"net/http"
@@ -12,6 +15,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,67 +39,68 @@ func TestGetAllEventsHandler(t *testing.T) {
 			Date:        testTime.Add(24 * time.Hour),
 			TotalSeats:  200,
 			BookedSeats: 75,
+			Timezone:    "Asia/Tokyo",
 		},
 	}
+	testEvents[1].Localize()
 
 	testCases := []struct {
 		name           string
-		mockSetup      func(mock *mocks.EventsGetter)
+		mockSetup      func(m *mocks.EventsGetter)
 		expectedStatus int
 		expectedBody   string
 		checkBody      func(t *testing.T, body string)
 	}{
 		{
 			name: "Success with events",
-			mockSetup: func(mock *mocks.EventsGetter) {
-				mock.On("GetAllEvents").Return(testEvents, nil)
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("ListEvents", mock.Anything, 0, 0, 0, "", false).Return(testEvents, len(testEvents), nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkBody: func(t *testing.T, body string) {
-				var response EventsResponse
-				err := json.Unmarshal([]byte(body), &response)
+				var resp EventsResponse
+				err := json.Unmarshal([]byte(body), &response.Envelope{Data: &resp})
 				require.NoError(t, err)
 
-				assert.Equal(t, "OK", response.Status)
-				assert.Equal(t, "", response.Error)
-				assert.Len(t, response.Events, 2)
-				assert.Equal(t, 1, response.Events[0].ID)
-				assert.Equal(t, "Test Event 1", response.Events[0].Title)
-				assert.Equal(t, 2, response.Events[1].ID)
-				assert.Equal(t, "Test Event 2", response.Events[1].Title)
+				assert.Len(t, resp.Events, 2)
+				assert.Equal(t, 1, resp.Events[0].ID)
+				assert.Equal(t, "Test Event 1", resp.Events[0].Title)
+				assert.Equal(t, 2, resp.Events[1].ID)
+				assert.Equal(t, "Test Event 2", resp.Events[1].Title)
+				assert.Equal(t, "Asia/Tokyo", resp.Events[1].Timezone)
+				require.NotNil(t, resp.Events[1].LocalDate)
+				assert.Equal(t, "2024-12-27T03:00:00+09:00", resp.Events[1].LocalDate.Format(time.RFC3339))
 			},
 		},
 		{
 			name: "Success with empty events",
-			mockSetup: func(mock *mocks.EventsGetter) {
-				mock.On("GetAllEvents").Return([]models.Event{}, nil)
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("ListEvents", mock.Anything, 0, 0, 0, "", false).Return([]models.Event{}, len([]models.Event{}), nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkBody: func(t *testing.T, body string) {
-				var response EventsResponse
-				err := json.Unmarshal([]byte(body), &response)
+				var resp EventsResponse
+				err := json.Unmarshal([]byte(body), &response.Envelope{Data: &resp})
 				require.NoError(t, err)
 
-				assert.Equal(t, "OK", response.Status)
-				assert.Equal(t, "", response.Error)
-				assert.Empty(t, response.Events)
+				assert.Empty(t, resp.Events)
 			},
 		},
 		{
 			name: "Internal server error",
-			mockSetup: func(mock *mocks.EventsGetter) {
-				mock.On("GetAllEvents").Return(nil, errors.New("database error"))
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("ListEvents", mock.Anything, 0, 0, 0, "", false).Return(nil, 0, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get events"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get events","code":"internal_error"}`,
 		},
 		{
 			name: "Nil events with error",
-			mockSetup: func(mock *mocks.EventsGetter) {
-				mock.On("GetAllEvents").Return(nil, errors.New("connection failed"))
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("ListEvents", mock.Anything, 0, 0, 0, "", false).Return(nil, 0, errors.New("connection failed"))
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get events"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get events","code":"internal_error"}`,
 		},
 	}
 
@@ -109,7 +114,7 @@ func TestGetAllEventsHandler(t *testing.T) {
 
 			handler := New(logger, mockGetter)
 
-			req, err := http.NewRequest("GET", "/events", nil)
+			req, err := http.NewRequest("GET", "/api/v1/events", nil)
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
@@ -117,6 +122,7 @@ func TestGetAllEventsHandler(t *testing.T) {
 			handler.ServeHTTP(rr, req)
 
 			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
 
 			if tc.expectedBody != "" {
 				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
@@ -129,79 +135,6 @@ func TestGetAllEventsHandler(t *testing.T) {
 	}
 }
 
-func TestResponseOK(t *testing.T) {
-	t.Parallel()
-
-	// Test data
-	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
-	testEvents := []models.Event{
-		{
-			ID:          1,
-			Title:       "Test Event",
-			Date:        testTime,
-			TotalSeats:  100,
-			BookedSeats: 50,
-		},
-	}
-
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
-
-	responseOK(rr, req, testEvents)
-
-	assert.Equal(t, http.StatusOK, rr.Code)
-
-	var actualResponse EventsResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
-
-	assert.Equal(t, "OK", actualResponse.Status)
-	assert.Equal(t, "", actualResponse.Error)
-	require.Len(t, actualResponse.Events, 1)
-	assert.Equal(t, 1, actualResponse.Events[0].ID)
-	assert.Equal(t, "Test Event", actualResponse.Events[0].Title)
-	assert.Equal(t, 100, actualResponse.Events[0].TotalSeats)
-	assert.Equal(t, 50, actualResponse.Events[0].BookedSeats)
-}
-
-func TestEmptyEventsResponse(t *testing.T) {
-	t.Parallel()
-
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
-
-	responseOK(rr, req, []models.Event{})
-
-	assert.Equal(t, http.StatusOK, rr.Code)
-
-	var actualResponse EventsResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
-
-	assert.Equal(t, "OK", actualResponse.Status)
-	assert.Equal(t, "", actualResponse.Error)
-	assert.Empty(t, actualResponse.Events)
-}
-
-func TestNilEventsResponse(t *testing.T) {
-	t.Parallel()
-
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
-
-	responseOK(rr, req, nil)
-
-	assert.Equal(t, http.StatusOK, rr.Code)
-
-	var actualResponse EventsResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
-
-	assert.Equal(t, "OK", actualResponse.Status)
-	assert.Equal(t, "", actualResponse.Error)
-	assert.Empty(t, actualResponse.Events)
-}
-
 func TestErrorScenarios(t *testing.T) {
 	t.Parallel()
 
@@ -217,19 +150,19 @@ func TestErrorScenarios(t *testing.T) {
 			name:           "Database connection error",
 			mockError:      errors.New("database connection failed"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get events"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get events","code":"internal_error"}`,
 		},
 		{
 			name:           "Timeout error",
 			mockError:      errors.New("request timeout"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get events"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get events","code":"internal_error"}`,
 		},
 		{
 			name:           "Unknown error",
 			mockError:      errors.New("unknown error occurred"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get events"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get events","code":"internal_error"}`,
 		},
 	}
 
@@ -237,11 +170,11 @@ func TestErrorScenarios(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			mockGetter := mocks.NewEventsGetter(t)
-			mockGetter.On("GetAllEvents").Return(nil, tc.mockError)
+			mockGetter.On("ListEvents", mock.Anything, 0, 0, 0, "", false).Return(nil, 0, tc.mockError)
 
 			handler := New(logger, mockGetter)
 
-			req, err := http.NewRequest("GET", "/events", nil)
+			req, err := http.NewRequest("GET", "/api/v1/events", nil)
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
@@ -264,7 +197,7 @@ func TestHandlerWorksWithAnyHTTPMethod(t *testing.T) {
 	testEvents := []models.Event{
 		{ID: 1, Title: "Test Event"},
 	}
-	mockGetter.On("GetAllEvents").Return(testEvents, nil)
+	mockGetter.On("ListEvents", mock.Anything, 0, 0, 0, "", false).Return(testEvents, len(testEvents), nil)
 
 	handler := New(logger, mockGetter)
 
@@ -272,7 +205,7 @@ func TestHandlerWorksWithAnyHTTPMethod(t *testing.T) {
 
 	for _, method := range methods {
 		t.Run(method, func(t *testing.T) {
-			req, err := http.NewRequest(method, "/events", nil)
+			req, err := http.NewRequest(method, "/api/v1/events", nil)
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
@@ -280,17 +213,16 @@ func TestHandlerWorksWithAnyHTTPMethod(t *testing.T) {
 
 			assert.Equal(t, http.StatusOK, rr.Code)
 
-			var response EventsResponse
-			err = json.Unmarshal(rr.Body.Bytes(), &response)
+			var resp EventsResponse
+			err = json.Unmarshal(rr.Body.Bytes(), &response.Envelope{Data: &resp})
 			require.NoError(t, err)
 
-			assert.Equal(t, "OK", response.Status)
-			assert.Len(t, response.Events, 1)
-			assert.Equal(t, 1, response.Events[0].ID)
+			assert.Len(t, resp.Events, 1)
+			assert.Equal(t, 1, resp.Events[0].ID)
 		})
 	}
 
-	mockGetter.AssertNumberOfCalls(t, "GetAllEvents", len(methods))
+	mockGetter.AssertNumberOfCalls(t, "ListEvents", len(methods))
 }
 
 func TestHandlerWorksWithDifferentURLs(t *testing.T) {
@@ -300,13 +232,13 @@ func TestHandlerWorksWithDifferentURLs(t *testing.T) {
 	mockGetter := mocks.NewEventsGetter(t)
 
 	testEvents := []models.Event{}
-	mockGetter.On("GetAllEvents").Return(testEvents, nil)
+	mockGetter.On("ListEvents", mock.Anything, 0, 0, 0, "", false).Return(testEvents, len(testEvents), nil)
 
 	handler := New(logger, mockGetter)
 
 	urls := []string{
-		"/events",
-		"/events/",
+		"/api/v1/events",
+		"/api/v1/events/",
 		"/api/events",
 		"/",
 		"/some/path",
@@ -324,5 +256,161 @@ func TestHandlerWorksWithDifferentURLs(t *testing.T) {
 		})
 	}
 
-	mockGetter.AssertNumberOfCalls(t, "GetAllEvents", len(urls))
+	mockGetter.AssertNumberOfCalls(t, "ListEvents", len(urls))
+}
+
+func TestPagination(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		query          string
+		userID         string
+		mockSetup      func(m *mocks.EventsGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "Page of events",
+			query: "?limit=1&offset=2",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("ListEvents", mock.Anything, 1, 2, 0, "", false).Return([]models.Event{}, 5, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"events":[]},"meta":{"pagination":{"limit":1,"offset":2,"total":5}}}`,
+		},
+		{
+			name:           "Limit is not a number",
+			query:          "?limit=ten",
+			mockSetup:      func(m *mocks.EventsGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"limit must be between 0 and 500","code":"bad_request"}`,
+		},
+		{
+			name:           "Limit is too big",
+			query:          "?limit=501",
+			mockSetup:      func(m *mocks.EventsGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"limit must be between 0 and 500","code":"bad_request"}`,
+		},
+		{
+			name:           "Negative offset",
+			query:          "?offset=-1",
+			mockSetup:      func(m *mocks.EventsGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"offset must be a non-negative integer","code":"bad_request"}`,
+		},
+		{
+			name:  "Events of a venue",
+			query: "?venue_id=4",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("ListEvents", mock.Anything, 0, 0, 4, "", false).Return([]models.Event{}, 0, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"events":[]},"meta":{"pagination":{"limit":0,"offset":0,"total":0}}}`,
+		},
+		{
+			name:  "Events of today",
+			query: "?day=today",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("ListEvents", mock.Anything, 0, 0, 0, "today", false).Return([]models.Event{}, 0, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"events":[]},"meta":{"pagination":{"limit":0,"offset":0,"total":0}}}`,
+		},
+		{
+			name:  "Events of a date",
+			query: "?day=2030-06-01",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("ListEvents", mock.Anything, 0, 0, 0, "2030-06-01", false).Return([]models.Event{}, 0, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"events":[]},"meta":{"pagination":{"limit":0,"offset":0,"total":0}}}`,
+		},
+		{
+			name:           "Invalid day",
+			query:          "?day=tomorrow",
+			mockSetup:      func(m *mocks.EventsGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"day must be today or a date in the format YYYY-MM-DD","code":"bad_request"}`,
+		},
+		{
+			name:   "Drafts for an admin",
+			query:  "?drafts=true",
+			userID: "alice",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("HasRole", mock.Anything, "alice", models.RoleAdmin).Return(true, nil)
+				m.On("ListEvents", mock.Anything, 0, 0, 0, "", true).Return([]models.Event{}, 0, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"events":[]},"meta":{"pagination":{"limit":0,"offset":0,"total":0}}}`,
+		},
+		{
+			name:   "Drafts for a user without the admin role",
+			query:  "?drafts=true",
+			userID: "bob",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("HasRole", mock.Anything, "bob", models.RoleAdmin).Return(false, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"type":"about:blank","title":"Forbidden","status":403,"detail":"admin role required","code":"forbidden"}`,
+		},
+		{
+			name:   "Roles storage error",
+			query:  "?drafts=true",
+			userID: "alice",
+			mockSetup: func(m *mocks.EventsGetter) {
+				m.On("HasRole", mock.Anything, "alice", models.RoleAdmin).Return(false, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get events","code":"internal_error"}`,
+		},
+		{
+			name:           "Drafts for an anonymous user",
+			query:          "?drafts=true",
+			mockSetup:      func(m *mocks.EventsGetter) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"api key required","code":"unauthorized"}`,
+		},
+		{
+			name:           "Invalid drafts",
+			query:          "?drafts=yes",
+			userID:         "alice",
+			mockSetup:      func(m *mocks.EventsGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"drafts must be true or false","code":"bad_request"}`,
+		},
+		{
+			name:           "Venue ID is zero",
+			query:          "?venue_id=0",
+			mockSetup:      func(m *mocks.EventsGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"venue_id must be a positive integer","code":"bad_request"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewEventsGetter(t)
+			tc.mockSetup(mockGetter)
+
+			handler := New(logger, mockGetter)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/events"+tc.query, nil)
+			if tc.userID != "" {
+				req = req.WithContext(mwauth.WithUserID(req.Context(), tc.userID))
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+		})
+	}
 }
@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	models "eventBooker/internal/models"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
 )
 
 // EventsGetter is an autogenerated mock type for the EventsGetter type
@@ -13,34 +15,69 @@ type EventsGetter struct {
 	mock.Mock
 }
 
-// GetAllEvents provides a mock function with no fields
-func (_m *EventsGetter) GetAllEvents() ([]models.Event, error) {
-	ret := _m.Called()
+// HasRole provides a mock function with given fields: ctx, userID, role
+func (_m *EventsGetter) HasRole(ctx context.Context, userID string, role string) (bool, error) {
+	ret := _m.Called(ctx, userID, role)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllEvents")
+		panic("no return value specified for HasRole")
 	}
 
-	var r0 []models.Event
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]models.Event, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, role)
 	}
-	if rf, ok := ret.Get(0).(func() []models.Event); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListEvents provides a mock function with given fields: ctx, limit, offset, venueID, day, includeDrafts
+func (_m *EventsGetter) ListEvents(ctx context.Context, limit int, offset int, venueID int, day string, includeDrafts bool) ([]models.Event, int, error) {
+	ret := _m.Called(ctx, limit, offset, venueID, day, includeDrafts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEvents")
+	}
+
+	var r0 []models.Event
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, string, bool) ([]models.Event, int, error)); ok {
+		return rf(ctx, limit, offset, venueID, day, includeDrafts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, string, bool) []models.Event); ok {
+		r0 = rf(ctx, limit, offset, venueID, day, includeDrafts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, string, bool) int); ok {
+		r1 = rf(ctx, limit, offset, venueID, day, includeDrafts)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, int, string, bool) error); ok {
+		r2 = rf(ctx, limit, offset, venueID, day, includeDrafts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // NewEventsGetter creates a new instance of EventsGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
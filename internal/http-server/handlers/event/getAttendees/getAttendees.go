@@ -0,0 +1,262 @@
+package getAttendees
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+
+	statusAll = "all"
+
+	// flushEvery is how many rows are sent to the client at once.
+	flushEvery = 100
+	// writeTimeout replaces the server write timeout, which is too short for large events.
+	writeTimeout = 5 * time.Minute
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=AttendeesStreamer
+type AttendeesStreamer interface {
+	StreamBookings(ctx context.Context, eventID int, status string, fn func(models.Booking) error) error
+}
+
+// New streams the bookings of an event as a door list in CSV, JSON or NDJSON,
+// chosen by the format query parameter or the URL extension. Rows are written
+// as they are read from storage, so the list is never held in memory.
+func New(log *slog.Logger, streamer AttendeesStreamer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.getAttendees.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format, _ = r.Context().Value(middleware.URLFormatCtxKey).(string)
+		}
+		if format == "" {
+			format = FormatJSON
+		}
+
+		enc, err := newEncoder(format, w, middleware.GetReqID(r.Context()))
+		if err != nil {
+			log.Error("invalid format", slog.String("format", format))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, err.Error())
+			return
+		}
+
+		status := r.URL.Query().Get("status")
+		switch status {
+		case "", statusAll:
+			status = ""
+		case models.BookingPending, models.BookingConfirmed:
+		default:
+			log.Error("invalid status", slog.String("status", status))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "status must be one of: pending, confirmed, all")
+			return
+		}
+
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+
+		var (
+			started bool
+			count   int
+		)
+		// start is deferred until storage found the event, so a missing one still gets a 404.
+		start := func() error {
+			started = true
+
+			w.Header().Set("Content-Type", enc.contentType())
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="event-%d-attendees.%s"`, eventID, format))
+			w.WriteHeader(http.StatusOK)
+
+			return enc.begin()
+		}
+
+		err = streamer.StreamBookings(r.Context(), eventID, status, func(b models.Booking) error {
+			if !started {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+
+			if err := enc.row(b); err != nil {
+				return err
+			}
+
+			count++
+			if count%flushEvery == 0 {
+				if err := enc.flush(); err != nil {
+					return err
+				}
+				_ = rc.Flush()
+			}
+
+			return nil
+		})
+		if err == nil && !started {
+			err = start()
+		}
+		if err == nil {
+			err = enc.end()
+		}
+
+		if err != nil {
+			if started {
+				// The status line is already sent. Abort the response so the client
+				// sees a broken download instead of a silently truncated list.
+				log.Error("attendee export interrupted", sl.Err(err), slog.Int("rows", count))
+				panic(http.ErrAbortHandler)
+			}
+
+			log.Error("failed to export attendees", sl.Err(err))
+
+			if err.Error() == "event not found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to export attendees")
+			return
+		}
+
+		log.Info("attendees exported", slog.String("format", format), slog.Int("rows", count))
+	}
+}
+
+type encoder interface {
+	contentType() string
+	begin() error
+	row(b models.Booking) error
+	flush() error
+	end() error
+}
+
+func newEncoder(format string, w io.Writer, requestID string) (encoder, error) {
+	switch format {
+	case FormatCSV:
+		return &csvEncoder{w: w, cw: csv.NewWriter(w)}, nil
+	case FormatJSON:
+		return &jsonEncoder{w: w, requestID: requestID}, nil
+	case FormatNDJSON:
+		return &ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("format must be one of: %s, %s, %s", FormatCSV, FormatJSON, FormatNDJSON)
+	}
+}
+
+var csvHeader = []string{"booking_id", "user_id", "status", "created_at"}
+
+// csvEncoder writes a byte order mark first, so spreadsheet apps such as Excel read the file as UTF-8.
+type csvEncoder struct {
+	w  io.Writer
+	cw *csv.Writer
+}
+
+func (e *csvEncoder) contentType() string { return "text/csv; charset=utf-8" }
+
+func (e *csvEncoder) begin() error {
+	if _, err := io.WriteString(e.w, "\ufeff"); err != nil {
+		return err
+	}
+
+	return e.cw.Write(csvHeader)
+}
+
+func (e *csvEncoder) row(b models.Booking) error {
+	return e.cw.Write([]string{strconv.Itoa(b.ID), b.UserID, b.Status(), b.CreatedAt.UTC().Format(time.RFC3339)})
+}
+
+func (e *csvEncoder) flush() error {
+	e.cw.Flush()
+	return e.cw.Error()
+}
+
+func (e *csvEncoder) end() error { return e.flush() }
+
+// jsonEncoder writes the usual response envelope, {"data":{"attendees":[...]},"meta":{...}}, one row at a time.
+type jsonEncoder struct {
+	w         io.Writer
+	requestID string
+	rows      int
+}
+
+func (e *jsonEncoder) contentType() string { return response.ContentTypeJSON }
+
+func (e *jsonEncoder) begin() error {
+	_, err := io.WriteString(e.w, `{"data":{"attendees":[`)
+	return err
+}
+
+func (e *jsonEncoder) row(b models.Booking) error {
+	raw, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	if e.rows > 0 {
+		raw = append([]byte{','}, raw...)
+	}
+	e.rows++
+
+	_, err = e.w.Write(raw)
+	return err
+}
+
+func (e *jsonEncoder) flush() error { return nil }
+
+func (e *jsonEncoder) end() error {
+	meta, err := json.Marshal(response.Meta{RequestID: e.requestID})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(e.w, `]},"meta":%s}`, meta)
+	return err
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) contentType() string { return "application/x-ndjson" }
+
+func (e *ndjsonEncoder) begin() error { return nil }
+
+func (e *ndjsonEncoder) row(b models.Booking) error { return e.enc.Encode(b) }
+
+func (e *ndjsonEncoder) flush() error { return nil }
+
+func (e *ndjsonEncoder) end() error { return nil }
@@ -0,0 +1,207 @@
+package getAttendees
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/http-server/handlers/event/getAttendees/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testBookings = []models.Booking{
+	{ID: 1, EventID: 1, UserID: "alice", CreatedAt: time.Date(2099, 6, 1, 10, 0, 0, 0, time.UTC), Confirmed: true},
+	{ID: 2, EventID: 1, UserID: "bob, jr.", CreatedAt: time.Date(2099, 6, 1, 11, 0, 0, 0, time.UTC)},
+}
+
+// streams returns a Run func that feeds bookings to the callback passed to StreamBookings.
+func streams(bookings ...models.Booking) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(3).(func(models.Booking) error)
+		for _, b := range bookings {
+			if err := fn(b); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestGetAttendeesHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name                string
+		url                 string
+		mockSetup           func(m *mocks.AttendeesStreamer)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name: "JSON by default",
+			url:  "/api/v1/events/1/attendees",
+			mockSetup: func(m *mocks.AttendeesStreamer) {
+				m.On("StreamBookings", mock.Anything, 1, "", mock.Anything).Run(streams(testBookings...)).Return(nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody: `{"data":{"attendees":[
+				{"id":1,"event_id":1,"user_id":"alice","created_at":"2099-06-01T10:00:00Z","confirmed":true},
+				{"id":2,"event_id":1,"user_id":"bob, jr.","created_at":"2099-06-01T11:00:00Z","confirmed":false}
+			]},"meta":{}}`,
+		},
+		{
+			name: "Empty JSON list",
+			url:  "/api/v1/events/1/attendees?format=json",
+			mockSetup: func(m *mocks.AttendeesStreamer) {
+				m.On("StreamBookings", mock.Anything, 1, "", mock.Anything).Return(nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        `{"data":{"attendees":[]},"meta":{}}`,
+		},
+		{
+			name: "CSV of confirmed bookings",
+			url:  "/api/v1/events/1/attendees?format=csv&status=confirmed",
+			mockSetup: func(m *mocks.AttendeesStreamer) {
+				m.On("StreamBookings", mock.Anything, 1, models.BookingConfirmed, mock.Anything).Run(streams(testBookings[0])).Return(nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "\ufeffbooking_id,user_id,status,created_at\n" +
+				"1,alice,confirmed,2099-06-01T10:00:00Z\n",
+		},
+		{
+			name: "NDJSON",
+			url:  "/api/v1/events/1/attendees?format=ndjson&status=all",
+			mockSetup: func(m *mocks.AttendeesStreamer) {
+				m.On("StreamBookings", mock.Anything, 1, "", mock.Anything).Run(streams(testBookings...)).Return(nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody: `{"id":1,"event_id":1,"user_id":"alice","created_at":"2099-06-01T10:00:00Z","confirmed":true}` + "\n" +
+				`{"id":2,"event_id":1,"user_id":"bob, jr.","created_at":"2099-06-01T11:00:00Z","confirmed":false}` + "\n",
+		},
+		{
+			name:                "Unknown format",
+			url:                 "/api/v1/events/1/attendees?format=xlsx",
+			mockSetup:           func(m *mocks.AttendeesStreamer) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Bad Request","status":400,"detail":"format must be one of: csv, json, ndjson","code":"bad_request"}`,
+		},
+		{
+			name:                "Unknown status",
+			url:                 "/api/v1/events/1/attendees?status=expired",
+			mockSetup:           func(m *mocks.AttendeesStreamer) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Bad Request","status":400,"detail":"status must be one of: pending, confirmed, all","code":"bad_request"}`,
+		},
+		{
+			name: "Event not found",
+			url:  "/api/v1/events/42/attendees?format=csv",
+			mockSetup: func(m *mocks.AttendeesStreamer) {
+				m.On("StreamBookings", mock.Anything, 42, "", mock.Anything).Return(errors.New("event not found"))
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name: "Storage error before the first row",
+			url:  "/api/v1/events/1/attendees",
+			mockSetup: func(m *mocks.AttendeesStreamer) {
+				m.On("StreamBookings", mock.Anything, 1, "", mock.Anything).Return(errors.New("database error"))
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to export attendees","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStreamer := mocks.NewAttendeesStreamer(t)
+			tc.mockSetup(mockStreamer)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/events/{id}/attendees", New(logger, mockStreamer))
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.Equal(t, tc.expectedContentType, rr.Header().Get("Content-Type"))
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedStatus != http.StatusOK {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+				return
+			}
+
+			assert.Contains(t, rr.Header().Get("Content-Disposition"), "attachment")
+			if tc.expectedContentType == "application/json" {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			} else {
+				assert.Equal(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			}
+		})
+	}
+}
+
+func TestURLExtension(t *testing.T) {
+	t.Parallel()
+
+	mockStreamer := mocks.NewAttendeesStreamer(t)
+	mockStreamer.On("StreamBookings", mock.Anything, 1, "", mock.Anything).Return(nil)
+
+	r := chi.NewRouter()
+	r.Use(middleware.URLFormat)
+	r.Get("/api/v1/events/{id}/attendees", New(slogdiscard.NewDiscardLogger(), mockStreamer))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/1/attendees.csv", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="event-1-attendees.csv"`, rr.Header().Get("Content-Disposition"))
+}
+
+func TestStreamInterrupted(t *testing.T) {
+	t.Parallel()
+
+	mockStreamer := mocks.NewAttendeesStreamer(t)
+	mockStreamer.On("StreamBookings", mock.Anything, 1, "", mock.Anything).
+		Run(streams(testBookings[0])).
+		Return(errors.New("connection reset"))
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/events/{id}/attendees", New(slogdiscard.NewDiscardLogger(), mockStreamer))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/1/attendees", nil).WithContext(context.Background())
+	rr := httptest.NewRecorder()
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { r.ServeHTTP(rr, req) })
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
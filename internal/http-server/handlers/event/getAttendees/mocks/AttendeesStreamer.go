@@ -0,0 +1,48 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// AttendeesStreamer is an autogenerated mock type for the AttendeesStreamer type
+type AttendeesStreamer struct {
+	mock.Mock
+}
+
+// StreamBookings provides a mock function with given fields: ctx, eventID, status, fn
+func (_m *AttendeesStreamer) StreamBookings(ctx context.Context, eventID int, status string, fn func(models.Booking) error) error {
+	ret := _m.Called(ctx, eventID, status, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamBookings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, func(models.Booking) error) error); ok {
+		r0 = rf(ctx, eventID, status, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAttendeesStreamer creates a new instance of AttendeesStreamer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttendeesStreamer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttendeesStreamer {
+	mock := &AttendeesStreamer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,58 @@
+package getCancellationPolicy
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=PolicyGetter
+type PolicyGetter interface {
+	GetCancellationPolicy(ctx context.Context, eventID int) (*models.CancellationPolicy, error)
+}
+
+// New returns the cancellation policy of the event. Events without one
+// refund in full until they start.
+func New(log *slog.Logger, policies PolicyGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.getCancellationPolicy.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		policy, err := policies.GetCancellationPolicy(r.Context(), eventID)
+		if err != nil {
+			log.Error("failed to get cancellation policy", sl.Err(err))
+
+			if err.Error() == "event not found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get cancellation policy")
+			return
+		}
+
+		response.OK(w, r, policy)
+	}
+}
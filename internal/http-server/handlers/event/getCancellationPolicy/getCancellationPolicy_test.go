@@ -0,0 +1,103 @@
+package getCancellationPolicy
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/event/getCancellationPolicy/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCancellationPolicyHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		mockSetup      func(m *mocks.PolicyGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:    "Success",
+			eventID: "1",
+			mockSetup: func(m *mocks.PolicyGetter) {
+				m.On("GetCancellationPolicy", mock.Anything, 1).Return(&models.CancellationPolicy{
+					EventID:              1,
+					FullRefundHours:      72,
+					PartialRefundPercent: 50,
+					CutoffHours:          24,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":1,"full_refund_hours":72,"partial_refund_percent":50,"cutoff_hours":24},"meta":{}}`,
+		},
+		{
+			name:    "No policy",
+			eventID: "1",
+			mockSetup: func(m *mocks.PolicyGetter) {
+				m.On("GetCancellationPolicy", mock.Anything, 1).Return(&models.CancellationPolicy{EventID: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":1,"full_refund_hours":0,"partial_refund_percent":0,"cutoff_hours":0},"meta":{}}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "abc",
+			mockSetup:      func(m *mocks.PolicyGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:    "Event not found",
+			eventID: "1",
+			mockSetup: func(m *mocks.PolicyGetter) {
+				m.On("GetCancellationPolicy", mock.Anything, 1).Return(nil, errors.New("event not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name:    "Storage error",
+			eventID: "1",
+			mockSetup: func(m *mocks.PolicyGetter) {
+				m.On("GetCancellationPolicy", mock.Anything, 1).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get cancellation policy","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewPolicyGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/events/{id}/cancellation-policy", New(logger, mockGetter))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/events/"+tc.eventID+"/cancellation-policy", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
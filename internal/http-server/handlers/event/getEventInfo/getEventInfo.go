@@ -1,26 +1,30 @@
 package getEventInfo
 
 import (
+	"context"
 	"eventBooker/internal/lib/api/response"
 	"eventBooker/internal/lib/logger/sl"
 	"eventBooker/internal/models"
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 	"strconv"
 )
 
 type EventInfoResponse struct {
-	response.Response
 	Event   *models.Event    `json:"event"`
 	Booking []models.Booking `json:"bookings"`
+	// CheckedIn is the number of bookings whose tickets were used at the door.
+	CheckedIn int `json:"checked_in"`
+	// TicketTypes lists the event's priced tiers with their remaining seats, empty for single-tier events.
+	TicketTypes []models.TicketType `json:"ticket_types"`
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=EventGetter
 type EventGetter interface {
-	GetEventWithBookings(eventID int) (*models.Event, []models.Booking, error)
-	GetAllEvents() ([]models.Event, error)
+	GetEventWithBookings(ctx context.Context, eventID int) (*models.Event, []models.Booking, error)
+	GetAllEvents(ctx context.Context) ([]models.Event, error)
+	GetTicketTypes(ctx context.Context, eventID int) ([]models.TicketType, error)
 }
 
 func New(log *slog.Logger, info EventGetter) http.HandlerFunc {
@@ -32,47 +36,54 @@ func New(log *slog.Logger, info EventGetter) http.HandlerFunc {
 		eventIdStr := chi.URLParam(r, "id")
 		if eventIdStr == "" {
 			log.Error("event id is required")
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("event id is required"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
 			return
 		}
 
 		eventID, err := strconv.Atoi(eventIdStr)
 		if err != nil {
 			log.Error("invalid event id format", sl.Err(err))
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, response.Error("invalid event id format"))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
 			return
 		}
 
 		log = log.With(slog.Int("event_id", eventID))
 
-		event, booking, err := info.GetEventWithBookings(eventID)
+		event, booking, err := info.GetEventWithBookings(r.Context(), eventID)
 		if err != nil {
 			log.Error("failed to get event information", sl.Err(err))
 
 			// Обработка специфичной ошибки
 			if err.Error() == "event not found" {
-				render.Status(r, http.StatusNotFound)
-				render.JSON(w, r, response.Error("event not found"))
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
 				return
 			}
 
-			render.Status(r, http.StatusInternalServerError)
-			render.JSON(w, r, response.Error("failed to get event information"))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get event information")
+			return
+		}
+
+		ticketTypes, err := info.GetTicketTypes(r.Context(), eventID)
+		if err != nil {
+			log.Error("failed to get ticket types", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get event information")
 			return
 		}
 
 		log.Info("event info successfully received", slog.Int("event_id", eventID))
 
-		responseOK(w, r, event, booking)
-	}
-}
+		checkedIn := 0
+		for _, b := range booking {
+			if b.CheckedInAt != nil {
+				checkedIn++
+			}
+		}
 
-func responseOK(w http.ResponseWriter, r *http.Request, event *models.Event, booking []models.Booking) {
-	render.JSON(w, r, EventInfoResponse{
-		Response: response.OK(),
-		Event:    event,
-		Booking:  booking,
-	})
+		response.OK(w, r, EventInfoResponse{
+			Event:       event,
+			Booking:     booking,
+			CheckedIn:   checkedIn,
+			TicketTypes: ticketTypes,
+		})
+	}
 }
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"eventBooker/internal/http-server/handlers/event/getEventInfo/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/api/response"
 	"eventBooker/internal/lib/logger/handlers/slogdiscard"
 	"eventBooker/internal/models"
 	"net/http"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,6 +26,7 @@ func TestGetEventInfoHandler(t *testing.T) {
 	logger := slogdiscard.NewDiscardLogger()
 
 	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
+	checkedInAt := testTime.Add(30 * time.Minute)
 	testEvent := &models.Event{
 		ID:          1,
 		Title:       "Test Event",
@@ -32,10 +36,12 @@ func TestGetEventInfoHandler(t *testing.T) {
 	}
 	testBookings := []models.Booking{
 		{
-			ID:        1,
-			EventID:   1,
-			UserID:    "user1",
-			CreatedAt: testTime,
+			ID:          1,
+			EventID:     1,
+			UserID:      "user1",
+			CreatedAt:   testTime,
+			Confirmed:   true,
+			CheckedInAt: &checkedInAt,
 		},
 		{
 			ID:        2,
@@ -44,11 +50,33 @@ func TestGetEventInfoHandler(t *testing.T) {
 			CreatedAt: testTime.Add(1 * time.Hour),
 		},
 	}
+	testTicketTypes := []models.TicketType{
+		{
+			ID:             1,
+			EventID:        1,
+			Name:           "Standard",
+			Price:          1500,
+			Currency:       "EUR",
+			Capacity:       80,
+			BookedSeats:    45,
+			RemainingSeats: 35,
+		},
+		{
+			ID:             2,
+			EventID:        1,
+			Name:           "VIP",
+			Price:          9900,
+			Currency:       "EUR",
+			Capacity:       20,
+			BookedSeats:    5,
+			RemainingSeats: 15,
+		},
+	}
 
 	testCases := []struct {
 		name           string
 		eventID        string
-		mockSetup      func(mock *mocks.EventGetter)
+		mockSetup      func(m *mocks.EventGetter)
 		expectedStatus int
 		expectedBody   string
 		checkBody      func(t *testing.T, body string)
@@ -56,84 +84,99 @@ func TestGetEventInfoHandler(t *testing.T) {
 		{
 			name:    "Success with event and bookings",
 			eventID: "1",
-			mockSetup: func(mock *mocks.EventGetter) {
-				mock.On("GetEventWithBookings", 1).Return(testEvent, testBookings, nil)
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEventWithBookings", mock.Anything, 1).Return(testEvent, testBookings, nil)
+				m.On("GetTicketTypes", mock.Anything, 1).Return(testTicketTypes, nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkBody: func(t *testing.T, body string) {
-				var response EventInfoResponse
-				err := json.Unmarshal([]byte(body), &response)
+				var resp EventInfoResponse
+				err := json.Unmarshal([]byte(body), &response.Envelope{Data: &resp})
 				require.NoError(t, err)
 
-				assert.Equal(t, "OK", response.Status)
-				assert.Equal(t, "", response.Error)
-				require.NotNil(t, response.Event)
-				assert.Equal(t, 1, response.Event.ID)
-				assert.Equal(t, "Test Event", response.Event.Title)
-				assert.Len(t, response.Booking, 2)
-				assert.Equal(t, "user1", response.Booking[0].UserID)
-				assert.Equal(t, "user2", response.Booking[1].UserID)
+				require.NotNil(t, resp.Event)
+				assert.Equal(t, 1, resp.Event.ID)
+				assert.Equal(t, "Test Event", resp.Event.Title)
+				assert.Len(t, resp.Booking, 2)
+				assert.Equal(t, "user1", resp.Booking[0].UserID)
+				assert.Equal(t, "user2", resp.Booking[1].UserID)
+				assert.Equal(t, 1, resp.CheckedIn)
+				require.Len(t, resp.TicketTypes, 2)
+				assert.Equal(t, "VIP", resp.TicketTypes[1].Name)
+				assert.Equal(t, 15, resp.TicketTypes[1].RemainingSeats)
 			},
 		},
 		{
 			name:    "Success with event but no bookings",
 			eventID: "1",
-			mockSetup: func(mock *mocks.EventGetter) {
-				mock.On("GetEventWithBookings", 1).Return(testEvent, []models.Booking{}, nil)
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEventWithBookings", mock.Anything, 1).Return(testEvent, []models.Booking{}, nil)
+				m.On("GetTicketTypes", mock.Anything, 1).Return([]models.TicketType{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkBody: func(t *testing.T, body string) {
-				var response EventInfoResponse
-				err := json.Unmarshal([]byte(body), &response)
+				var resp EventInfoResponse
+				err := json.Unmarshal([]byte(body), &response.Envelope{Data: &resp})
 				require.NoError(t, err)
 
-				assert.Equal(t, "OK", response.Status)
-				assert.Equal(t, "", response.Error)
-				require.NotNil(t, response.Event)
-				assert.Equal(t, 1, response.Event.ID)
-				assert.Empty(t, response.Booking)
+				require.NotNil(t, resp.Event)
+				assert.Equal(t, 1, resp.Event.ID)
+				assert.Empty(t, resp.Booking)
+				assert.Zero(t, resp.CheckedIn)
+				assert.NotNil(t, resp.TicketTypes)
+				assert.Empty(t, resp.TicketTypes)
+			},
+		},
+		{
+			name:    "Ticket types error",
+			eventID: "1",
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEventWithBookings", mock.Anything, 1).Return(testEvent, testBookings, nil)
+				m.On("GetTicketTypes", mock.Anything, 1).Return(nil, errors.New("database error"))
 			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get event information","code":"internal_error"}`,
 		},
 		{
 			name:           "Missing event ID",
 			eventID:        "",
-			mockSetup:      func(mock *mocks.EventGetter) {},
+			mockSetup:      func(m *mocks.EventGetter) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"event id is required"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"event id is required","code":"bad_request"}`,
 		},
 		{
 			name:           "Invalid event ID format",
 			eventID:        "invalid",
-			mockSetup:      func(mock *mocks.EventGetter) {},
+			mockSetup:      func(m *mocks.EventGetter) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"status":"Error","error":"invalid event id format"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
 		},
 		{
 			name:    "Event not found",
 			eventID: "999",
-			mockSetup: func(mock *mocks.EventGetter) {
-				mock.On("GetEventWithBookings", 999).Return(nil, nil, errors.New("event not found"))
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEventWithBookings", mock.Anything, 999).Return(nil, nil, errors.New("event not found"))
 			},
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   `{"status":"Error","error":"event not found"}`,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
 		},
 		{
 			name:    "Internal server error",
 			eventID: "1",
-			mockSetup: func(mock *mocks.EventGetter) {
-				mock.On("GetEventWithBookings", 1).Return(nil, nil, errors.New("database error"))
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEventWithBookings", mock.Anything, 1).Return(nil, nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get event information"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get event information","code":"internal_error"}`,
 		},
 		{
 			name:    "Other specific error",
 			eventID: "1",
-			mockSetup: func(mock *mocks.EventGetter) {
-				mock.On("GetEventWithBookings", 1).Return(nil, nil, errors.New("connection timeout"))
+			mockSetup: func(m *mocks.EventGetter) {
+				m.On("GetEventWithBookings", mock.Anything, 1).Return(nil, nil, errors.New("connection timeout"))
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get event information"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get event information","code":"internal_error"}`,
 		},
 	}
 
@@ -147,16 +190,16 @@ func TestGetEventInfoHandler(t *testing.T) {
 
 			handler := New(logger, mockGetter)
 
-			url := "/events/info"
+			url := "/api/v1/events/info"
 			if tc.eventID != "" {
-				url = "/events/" + tc.eventID + "/info"
+				url = "/api/v1/events/" + tc.eventID + "/info"
 			}
 
 			req, err := http.NewRequest("GET", url, nil)
 			require.NoError(t, err)
 
 			router := chi.NewRouter()
-			router.Route("/events", func(r chi.Router) {
+			router.Route("/api/v1/events", func(r chi.Router) {
 				r.Route("/{id}", func(r chi.Router) {
 					r.Get("/info", handler)
 				})
@@ -169,6 +212,11 @@ func TestGetEventInfoHandler(t *testing.T) {
 
 			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
 
+			if tc.eventID != "" {
+				// The test router mounts the handler under /info, validate against the real path.
+				openapitest.ValidateResponse(t, httptest.NewRequest(http.MethodGet, "/api/v1/events/"+tc.eventID, nil), rr)
+			}
+
 			if tc.expectedBody != "" {
 				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
 			} else if tc.checkBody != nil {
@@ -184,97 +232,6 @@ func TestGetEventInfoHandler(t *testing.T) {
 	}
 }
 
-func TestResponseOK(t *testing.T) {
-	t.Parallel()
-
-	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
-	testEvent := &models.Event{
-		ID:          1,
-		Title:       "Test Event",
-		Date:        testTime,
-		TotalSeats:  100,
-		BookedSeats: 50,
-	}
-	testBookings := []models.Booking{
-		{
-			ID:        1,
-			EventID:   1,
-			UserID:    "user1",
-			CreatedAt: testTime,
-		},
-	}
-
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
-
-	responseOK(rr, req, testEvent, testBookings)
-
-	assert.Equal(t, http.StatusOK, rr.Code)
-
-	var actualResponse EventInfoResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
-
-	assert.Equal(t, "OK", actualResponse.Status)
-	assert.Equal(t, "", actualResponse.Error)
-	require.NotNil(t, actualResponse.Event)
-	assert.Equal(t, 1, actualResponse.Event.ID)
-	assert.Equal(t, "Test Event", actualResponse.Event.Title)
-	require.Len(t, actualResponse.Booking, 1)
-	assert.Equal(t, "user1", actualResponse.Booking[0].UserID)
-}
-
-func TestResponseWithNilEvent(t *testing.T) {
-	t.Parallel()
-
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
-
-	responseOK(rr, req, nil, []models.Booking{})
-
-	assert.Equal(t, http.StatusOK, rr.Code)
-
-	var actualResponse EventInfoResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
-
-	assert.Equal(t, "OK", actualResponse.Status)
-	assert.Equal(t, "", actualResponse.Error)
-	assert.Nil(t, actualResponse.Event)
-	assert.Empty(t, actualResponse.Booking)
-}
-
-func TestResponseWithNilBookings(t *testing.T) {
-	t.Parallel()
-
-	// Test data
-	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
-	testEvent := &models.Event{
-		ID:          1,
-		Title:       "Test Event",
-		Date:        testTime,
-		TotalSeats:  100,
-		BookedSeats: 50,
-	}
-
-	req := httptest.NewRequest("GET", "/", nil)
-	rr := httptest.NewRecorder()
-
-	responseOK(rr, req, testEvent, nil)
-
-	assert.Equal(t, http.StatusOK, rr.Code)
-
-	var actualResponse EventInfoResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &actualResponse)
-	require.NoError(t, err)
-
-	assert.Equal(t, "OK", actualResponse.Status)
-	assert.Equal(t, "", actualResponse.Error)
-	require.NotNil(t, actualResponse.Event)
-	assert.Equal(t, 1, actualResponse.Event.ID)
-	assert.Empty(t, actualResponse.Booking)
-}
-
 func TestEventGetterErrorScenarios(t *testing.T) {
 	t.Parallel()
 
@@ -292,21 +249,21 @@ func TestEventGetterErrorScenarios(t *testing.T) {
 			eventID:        "1",
 			mockError:      errors.New("event not found"),
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   `{"status":"Error","error":"event not found"}`,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
 		},
 		{
 			name:           "Database error",
 			eventID:        "1",
 			mockError:      errors.New("database connection failed"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get event information"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get event information","code":"internal_error"}`,
 		},
 		{
 			name:           "Timeout error",
 			eventID:        "1",
 			mockError:      errors.New("query timeout"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"status":"Error","error":"failed to get event information"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get event information","code":"internal_error"}`,
 		},
 	}
 
@@ -314,18 +271,18 @@ func TestEventGetterErrorScenarios(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			mockGetter := mocks.NewEventGetter(t)
-			mockGetter.On("GetEventWithBookings", 1).Return(nil, nil, tc.mockError)
+			mockGetter.On("GetEventWithBookings", mock.Anything, 1).Return(nil, nil, tc.mockError)
 
 			handler := New(logger, mockGetter)
 
 			router := chi.NewRouter()
-			router.Route("/events", func(r chi.Router) {
+			router.Route("/api/v1/events", func(r chi.Router) {
 				r.Route("/{id}", func(r chi.Router) {
 					r.Get("/info", handler)
 				})
 			})
 
-			req, err := http.NewRequest("GET", "/events/1/info", nil)
+			req, err := http.NewRequest("GET", "/api/v1/events/1/info", nil)
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
@@ -359,19 +316,19 @@ func TestHandlerWithChiContext(t *testing.T) {
 
 	testEvent := &models.Event{ID: 123, Title: "Test Event"}
 	testBookings := []models.Booking{}
-	mockGetter.On("GetEventWithBookings", 123).Return(testEvent, testBookings, nil)
+	mockGetter.On("GetEventWithBookings", mock.Anything, 123).Return(testEvent, testBookings, nil)
+	mockGetter.On("GetTicketTypes", mock.Anything, 123).Return([]models.TicketType{}, nil)
 
 	handler.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 
-	var response EventInfoResponse
-	err = json.Unmarshal(rr.Body.Bytes(), &response)
+	var resp EventInfoResponse
+	err = json.Unmarshal(rr.Body.Bytes(), &response.Envelope{Data: &resp})
 	require.NoError(t, err)
 
-	assert.Equal(t, "OK", response.Status)
-	require.NotNil(t, response.Event)
-	assert.Equal(t, 123, response.Event.ID)
+	require.NotNil(t, resp.Event)
+	assert.Equal(t, 123, resp.Event.ID)
 
 	mockGetter.AssertExpectations(t)
 }
@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	models "eventBooker/internal/models"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
 )
 
 // EventGetter is an autogenerated mock type for the EventGetter type
@@ -13,9 +15,9 @@ type EventGetter struct {
 	mock.Mock
 }
 
-// GetAllEvents provides a mock function with no fields
-func (_m *EventGetter) GetAllEvents() ([]models.Event, error) {
-	ret := _m.Called()
+// GetAllEvents provides a mock function with given fields: ctx
+func (_m *EventGetter) GetAllEvents(ctx context.Context) ([]models.Event, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllEvents")
@@ -23,19 +25,19 @@ func (_m *EventGetter) GetAllEvents() ([]models.Event, error) {
 
 	var r0 []models.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]models.Event, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Event, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []models.Event); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Event); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -43,9 +45,9 @@ func (_m *EventGetter) GetAllEvents() ([]models.Event, error) {
 	return r0, r1
 }
 
-// GetEventWithBookings provides a mock function with given fields: eventID
-func (_m *EventGetter) GetEventWithBookings(eventID int) (*models.Event, []models.Booking, error) {
-	ret := _m.Called(eventID)
+// GetEventWithBookings provides a mock function with given fields: ctx, eventID
+func (_m *EventGetter) GetEventWithBookings(ctx context.Context, eventID int) (*models.Event, []models.Booking, error) {
+	ret := _m.Called(ctx, eventID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetEventWithBookings")
@@ -54,27 +56,27 @@ func (_m *EventGetter) GetEventWithBookings(eventID int) (*models.Event, []model
 	var r0 *models.Event
 	var r1 []models.Booking
 	var r2 error
-	if rf, ok := ret.Get(0).(func(int) (*models.Event, []models.Booking, error)); ok {
-		return rf(eventID)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Event, []models.Booking, error)); ok {
+		return rf(ctx, eventID)
 	}
-	if rf, ok := ret.Get(0).(func(int) *models.Event); ok {
-		r0 = rf(eventID)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Event); ok {
+		r0 = rf(ctx, eventID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) []models.Booking); ok {
-		r1 = rf(eventID)
+	if rf, ok := ret.Get(1).(func(context.Context, int) []models.Booking); ok {
+		r1 = rf(ctx, eventID)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).([]models.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(int) error); ok {
-		r2 = rf(eventID)
+	if rf, ok := ret.Get(2).(func(context.Context, int) error); ok {
+		r2 = rf(ctx, eventID)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -82,6 +84,36 @@ func (_m *EventGetter) GetEventWithBookings(eventID int) (*models.Event, []model
 	return r0, r1, r2
 }
 
+// GetTicketTypes provides a mock function with given fields: ctx, eventID
+func (_m *EventGetter) GetTicketTypes(ctx context.Context, eventID int) ([]models.TicketType, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTicketTypes")
+	}
+
+	var r0 []models.TicketType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]models.TicketType, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.TicketType); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TicketType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewEventGetter creates a new instance of EventGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEventGetter(t interface {
@@ -0,0 +1,60 @@
+package getSeatMap
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=SeatMapGetter
+type SeatMapGetter interface {
+	GetSeatMap(ctx context.Context, eventID int) (*models.SeatLayout, error)
+}
+
+// New returns the seat layout of the event with each seat marked available,
+// held by a pending booking or booked, for clients to draw the seat map.
+func New(log *slog.Logger, seats SeatMapGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.getSeatMap.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		seatMap, err := seats.GetSeatMap(r.Context(), eventID)
+		if err != nil {
+			log.Error("failed to get seat map", sl.Err(err))
+
+			switch err.Error() {
+			case "event not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+			case "event has no seat layout":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event has no reserved seating")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get seat map")
+			}
+			return
+		}
+
+		response.OK(w, r, seatMap)
+	}
+}
@@ -0,0 +1,114 @@
+package getSeatMap
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/event/getSeatMap/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSeatMapHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		mockSetup      func(m *mocks.SeatMapGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:    "Success",
+			eventID: "1",
+			mockSetup: func(m *mocks.SeatMapGetter) {
+				m.On("GetSeatMap", mock.Anything, 1).Return(&models.SeatLayout{
+					ID:   3,
+					Name: "Main hall",
+					Sections: []models.SeatSection{
+						{Name: "Stalls", Rows: []models.SeatRow{
+							{Name: "A", Seats: []models.Seat{
+								{ID: 10, Number: 1, Status: models.SeatAvailable},
+								{ID: 11, Number: 2, Status: models.SeatHeld},
+								{ID: 12, Number: 3, Status: models.SeatBooked},
+							}},
+						}},
+					},
+					CreatedAt: createdAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":3,"name":"Main hall","sections":[{"name":"Stalls","rows":[{"name":"A","seats":[{"id":10,"number":1,"status":"available"},{"id":11,"number":2,"status":"held"},{"id":12,"number":3,"status":"booked"}]}]}],"created_at":"2026-05-01T12:00:00Z"},"meta":{}}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "abc",
+			mockSetup:      func(m *mocks.SeatMapGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:    "Event not found",
+			eventID: "1",
+			mockSetup: func(m *mocks.SeatMapGetter) {
+				m.On("GetSeatMap", mock.Anything, 1).Return(nil, errors.New("event not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name:    "General admission event",
+			eventID: "1",
+			mockSetup: func(m *mocks.SeatMapGetter) {
+				m.On("GetSeatMap", mock.Anything, 1).Return(nil, errors.New("event has no seat layout"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event has no reserved seating","code":"not_found"}`,
+		},
+		{
+			name:    "Storage error",
+			eventID: "1",
+			mockSetup: func(m *mocks.SeatMapGetter) {
+				m.On("GetSeatMap", mock.Anything, 1).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get seat map","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewSeatMapGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/events/{id}/seats", New(logger, mockGetter))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/events/"+tc.eventID+"/seats", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
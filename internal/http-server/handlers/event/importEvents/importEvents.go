@@ -0,0 +1,237 @@
+package importEvents
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/http-server/handlers/event/createEvent"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/eventimport"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxFileSize bounds the uploaded file, comfortably above eventimport.MaxRecords rows.
+const maxFileSize = 5 << 20
+
+type ImportedEvent struct {
+	// Line is where the event starts in the file.
+	Line int `json:"line"`
+	models.Event
+}
+
+type ImportResponse struct {
+	DryRun bool            `json:"dry_run"`
+	Events []ImportedEvent `json:"events"`
+}
+
+// RowError lists what is wrong with the event starting at Line.
+type RowError struct {
+	Line   int                   `json:"line"`
+	Errors []response.FieldError `json:"errors"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=EventImporter
+type EventImporter interface {
+	ImportEvents(ctx context.Context, events []models.Event) ([]int, error)
+}
+
+// New imports events from a CSV or iCalendar file, sent either as the request
+// body or as the "file" field of a multipart form. Nothing is created unless
+// every event is valid. With ?dry_run=true the events are only validated.
+func New(log *slog.Logger, v *validator.Validate, importer EventImporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.importEvents.New"
+
+		log = log.With(slog.String("op", op))
+
+		query := r.URL.Query()
+
+		dryRun := false
+		if value := query.Get("dry_run"); value != "" {
+			var err error
+			if dryRun, err = strconv.ParseBool(value); err != nil {
+				log.Error("invalid dry_run", slog.String("dry_run", value))
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "dry_run must be a boolean")
+				return
+			}
+		}
+
+		var defaults eventimport.Defaults
+		for name, dst := range map[string]*int{"total_seats": &defaults.TotalSeats, "deadline": &defaults.Deadline} {
+			value := query.Get(name)
+			if value == "" {
+				continue
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				log.Error("invalid default", slog.String(name, value))
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, name+" must be a positive integer")
+				return
+			}
+			*dst = n
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxFileSize)
+
+		file, format, err := openFile(r)
+		if err != nil {
+			log.Error("failed to read import file", sl.Err(err))
+			fileError(w, r, err)
+			return
+		}
+		defer file.Close()
+
+		log = log.With(slog.String("format", format), slog.Bool("dry_run", dryRun))
+
+		records, err := eventimport.Parse(file, format, defaults)
+		if err != nil {
+			log.Error("failed to parse import file", sl.Err(err))
+			fileError(w, r, err)
+			return
+		}
+		if len(records) == 0 {
+			log.Error("import file has no events")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "file has no events")
+			return
+		}
+
+		events, rowErrs := Check(v, records)
+		if len(rowErrs) > 0 {
+			log.Info("import rejected", slog.Int("invalid", len(rowErrs)), slog.Int("total", len(records)))
+			response.ErrorWithDetails(w, r, http.StatusBadRequest, response.CodeValidationFailed,
+				fmt.Sprintf("%d of %d events are invalid", len(rowErrs), len(records)), rowErrs)
+			return
+		}
+
+		if !dryRun {
+			ids, err := importer.ImportEvents(r.Context(), Events(events))
+			if err != nil {
+				log.Error("failed to import events", sl.Err(err))
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to import events")
+				return
+			}
+
+			for i := range events {
+				events[i].ID = ids[i]
+			}
+		}
+
+		log.Info("events imported", slog.Int("count", len(events)))
+
+		response.OK(w, r, ImportResponse{DryRun: dryRun, Events: events})
+	}
+}
+
+// Check validates records by the rules of POST /events. It returns the valid
+// events, and an error for every record that is not.
+func Check(v *validator.Validate, records []eventimport.Record) ([]ImportedEvent, []RowError) {
+	var (
+		events  []ImportedEvent
+		rowErrs []RowError
+	)
+
+	for _, rec := range records {
+		req := createEvent.EventRequest{
+			Title:      strings.TrimSpace(rec.Title),
+			Date:       rec.Date,
+			TotalSeats: rec.TotalSeats,
+			Deadline:   rec.Deadline,
+		}
+
+		errs := slices.Clone(rec.Errors)
+
+		var validateErr validator.ValidationErrors
+		if err := v.Struct(req); errors.As(err, &validateErr) {
+			for _, fe := range response.FieldErrors(validateErr) {
+				// A value that failed to parse is already reported, rules on its zero value are noise.
+				if hasField(rec.Errors, fe.Field) || (fe.Tag == validate.TagLtUntil && hasField(rec.Errors, "date")) {
+					continue
+				}
+				errs = append(errs, fe)
+			}
+		}
+
+		if len(errs) > 0 {
+			rowErrs = append(rowErrs, RowError{Line: rec.Line, Errors: errs})
+			continue
+		}
+
+		events = append(events, ImportedEvent{
+			Line: rec.Line,
+			Event: models.Event{
+				Title:      req.Title,
+				Date:       req.Date,
+				TotalSeats: req.TotalSeats,
+				Deadline:   req.Deadline,
+			},
+		})
+	}
+
+	return events, rowErrs
+}
+
+// Events strips line numbers off checked events.
+func Events(imported []ImportedEvent) []models.Event {
+	events := make([]models.Event, 0, len(imported))
+	for _, e := range imported {
+		events = append(events, e.Event)
+	}
+
+	return events
+}
+
+func fileError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		response.Error(w, r, http.StatusRequestEntityTooLarge, response.CodeBadRequest, "file is too large")
+		return
+	}
+
+	response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, err.Error())
+}
+
+func hasField(errs []response.FieldError, field string) bool {
+	return slices.ContainsFunc(errs, func(fe response.FieldError) bool { return fe.Field == field })
+}
+
+// openFile returns the uploaded file and its format. The format query
+// parameter wins over the media type and the file name.
+func openFile(r *http.Request) (io.ReadCloser, string, error) {
+	format := r.URL.Query().Get("format")
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if format == "" {
+			var err error
+			if format, err = eventimport.DetectFormat(r.Header.Get("Content-Type"), ""); err != nil {
+				return nil, "", err
+			}
+		}
+		return r.Body, format, nil
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read file field: %w", err)
+	}
+
+	if format == "" {
+		if format, err = eventimport.DetectFormat(header.Header.Get("Content-Type"), header.Filename); err != nil {
+			file.Close()
+			return nil, "", err
+		}
+	}
+
+	return file, format, nil
+}
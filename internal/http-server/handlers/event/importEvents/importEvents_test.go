@@ -0,0 +1,196 @@
+package importEvents
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/event/importEvents/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+const testCSV = "title,date,total_seats,deadline_minutes\n" +
+	"Go meetup,2099-06-01T19:00:00Z,100,30\n" +
+	"Rust night,2099-06-02T19:00:00Z,50,15\n"
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Go meetup\r\n" +
+	"DTSTART:20990601T190000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestImportEventsHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	goMeetup := models.Event{Title: "Go meetup", Date: time.Date(2099, 6, 1, 19, 0, 0, 0, time.UTC), TotalSeats: 100, Deadline: 30}
+	rustNight := models.Event{Title: "Rust night", Date: time.Date(2099, 6, 2, 19, 0, 0, 0, time.UTC), TotalSeats: 50, Deadline: 15}
+
+	testCases := []struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		multipartFile  string
+		mockSetup      func(m *mocks.EventImporter)
+		expectedStatus int
+		expectedBody   string
+		checkBody      func(t *testing.T, body string)
+	}{
+		{
+			name:        "CSV body",
+			contentType: "text/csv",
+			body:        testCSV,
+			mockSetup: func(m *mocks.EventImporter) {
+				m.On("ImportEvents", mock.Anything, []models.Event{goMeetup, rustNight}).Return([]int{7, 8}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"dry_run":false,"events":[
+				{"line":2,"id":7,"title":"Go meetup","date":"2099-06-01T19:00:00Z","total_seats":100,"booked_seats":0,"deadline_minutes":30},
+				{"line":3,"id":8,"title":"Rust night","date":"2099-06-02T19:00:00Z","total_seats":50,"booked_seats":0,"deadline_minutes":15}
+			]},"meta":{}}`,
+		},
+		{
+			name:           "Dry run",
+			query:          "?dry_run=true",
+			contentType:    "text/csv",
+			body:           testCSV,
+			mockSetup:      func(m *mocks.EventImporter) {},
+			expectedStatus: http.StatusOK,
+			checkBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"dry_run":true`)
+				assert.Contains(t, body, `"id":0`)
+			},
+		},
+		{
+			name:          "iCalendar upload with defaults",
+			query:         "?total_seats=100&deadline=30",
+			multipartFile: "season.ics",
+			body:          testICS,
+			mockSetup: func(m *mocks.EventImporter) {
+				m.On("ImportEvents", mock.Anything, []models.Event{goMeetup}).Return([]int{9}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"line":2,"id":9`)
+			},
+		},
+		{
+			name:        "Invalid rows reject the whole file",
+			contentType: "text/csv",
+			body: "title,date,total_seats,deadline_minutes\n" +
+				"Go meetup,2099-06-01T19:00:00Z,100,30\n" +
+				"No,2099-06-02T19:00:00Z,5000,15\n" +
+				"Broken,someday,50,15\n",
+			mockSetup:      func(m *mocks.EventImporter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"2 of 3 events are invalid","code":"validation_failed","details":[
+				{"line":3,"errors":[
+					{"field":"title","tag":"min","param":"3","message":"field title must be at least 3 characters"},
+					{"field":"total_seats","tag":"seats","param":"1000","message":"field total_seats must be at most 1000"}
+				]},
+				{"line":4,"errors":[
+					{"field":"date","tag":"format","message":"field date is not a valid date"}
+				]}
+			]}`,
+		},
+		{
+			name:           "Unknown format",
+			contentType:    "application/json",
+			body:           `[]`,
+			mockSetup:      func(m *mocks.EventImporter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"unknown import format, expected CSV or iCalendar","code":"bad_request"}`,
+		},
+		{
+			name:           "No events",
+			contentType:    "text/csv",
+			body:           "title,date\n",
+			mockSetup:      func(m *mocks.EventImporter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"file has no events","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid dry_run",
+			query:          "?dry_run=maybe",
+			contentType:    "text/csv",
+			body:           testCSV,
+			mockSetup:      func(m *mocks.EventImporter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"dry_run must be a boolean","code":"bad_request"}`,
+		},
+		{
+			name:        "Internal server error",
+			contentType: "text/csv",
+			body:        testCSV,
+			mockSetup: func(m *mocks.EventImporter) {
+				m.On("ImportEvents", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to import events","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockImporter := mocks.NewEventImporter(t)
+			tc.mockSetup(mockImporter)
+
+			handler := New(logger, testValidator, mockImporter)
+
+			body, contentType := bytes.NewBufferString(tc.body), tc.contentType
+			if tc.multipartFile != "" {
+				body, contentType = multipartBody(t, tc.multipartFile, tc.body)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/events/import"+tc.query, body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", contentType)
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			} else if tc.checkBody != nil {
+				tc.checkBody(t, rr.Body.String())
+			}
+		})
+	}
+}
+
+func multipartBody(t *testing.T, filename, content string) (*bytes.Buffer, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	fw, err := mw.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = fw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	return &buf, mw.FormDataContentType()
+}
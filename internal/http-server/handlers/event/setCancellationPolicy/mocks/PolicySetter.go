@@ -0,0 +1,47 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PolicySetter is an autogenerated mock type for the PolicySetter type
+type PolicySetter struct {
+	mock.Mock
+}
+
+// SetCancellationPolicy provides a mock function with given fields: ctx, p
+func (_m *PolicySetter) SetCancellationPolicy(ctx context.Context, p models.CancellationPolicy) error {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCancellationPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CancellationPolicy) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPolicySetter creates a new instance of PolicySetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPolicySetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PolicySetter {
+	mock := &PolicySetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
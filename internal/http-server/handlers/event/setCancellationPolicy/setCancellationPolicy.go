@@ -0,0 +1,101 @@
+package setCancellationPolicy
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type PolicyRequest struct {
+	FullRefundHours      int `json:"full_refund_hours" validate:"gte=0"`
+	PartialRefundPercent int `json:"partial_refund_percent" validate:"gte=0,lte=100"`
+	CutoffHours          int `json:"cutoff_hours" validate:"gte=0"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=PolicySetter
+type PolicySetter interface {
+	SetCancellationPolicy(ctx context.Context, p models.CancellationPolicy) error
+}
+
+// New sets how much of a paid booking of the event is refunded when it is
+// cancelled, depending on how long before the event that happens.
+func New(log *slog.Logger, v *validator.Validate, policies PolicySetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.setCancellationPolicy.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		var req PolicyRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		if req.CutoffHours > req.FullRefundHours {
+			log.Error("cutoff is before the full refund deadline")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "cutoff_hours must not exceed full_refund_hours")
+			return
+		}
+
+		policy := models.CancellationPolicy{
+			EventID:              eventID,
+			FullRefundHours:      req.FullRefundHours,
+			PartialRefundPercent: req.PartialRefundPercent,
+			CutoffHours:          req.CutoffHours,
+		}
+
+		if err = policies.SetCancellationPolicy(r.Context(), policy); err != nil {
+			log.Error("failed to set cancellation policy", sl.Err(err))
+
+			if err.Error() == "event not found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to set cancellation policy")
+			return
+		}
+
+		log.Info("cancellation policy set")
+
+		response.OK(w, r, policy)
+	}
+}
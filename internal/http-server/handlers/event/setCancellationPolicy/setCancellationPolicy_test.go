@@ -0,0 +1,130 @@
+package setCancellationPolicy
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/event/setCancellationPolicy/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestSetCancellationPolicyHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	policy := models.CancellationPolicy{
+		EventID:              1,
+		FullRefundHours:      72,
+		PartialRefundPercent: 50,
+		CutoffHours:          24,
+	}
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		requestBody    string
+		mockSetup      func(m *mocks.PolicySetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			eventID:     "1",
+			requestBody: `{"full_refund_hours": 72, "partial_refund_percent": 50, "cutoff_hours": 24}`,
+			mockSetup: func(m *mocks.PolicySetter) {
+				m.On("SetCancellationPolicy", mock.Anything, policy).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":1,"full_refund_hours":72,"partial_refund_percent":50,"cutoff_hours":24},"meta":{}}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "abc",
+			requestBody:    `{}`,
+			mockSetup:      func(m *mocks.PolicySetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			eventID:        "1",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.PolicySetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "Percent above 100",
+			eventID:        "1",
+			requestBody:    `{"full_refund_hours": 72, "partial_refund_percent": 150, "cutoff_hours": 24}`,
+			mockSetup:      func(m *mocks.PolicySetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field partial_refund_percent must be less than or equal to 100","code":"validation_failed","errors":[{"field":"partial_refund_percent","tag":"lte","param":"100","message":"field partial_refund_percent must be less than or equal to 100"}]}`,
+		},
+		{
+			name:           "Cutoff after full refund deadline",
+			eventID:        "1",
+			requestBody:    `{"full_refund_hours": 24, "partial_refund_percent": 50, "cutoff_hours": 72}`,
+			mockSetup:      func(m *mocks.PolicySetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"cutoff_hours must not exceed full_refund_hours","code":"bad_request"}`,
+		},
+		{
+			name:        "Event not found",
+			eventID:     "1",
+			requestBody: `{"full_refund_hours": 72, "partial_refund_percent": 50, "cutoff_hours": 24}`,
+			mockSetup: func(m *mocks.PolicySetter) {
+				m.On("SetCancellationPolicy", mock.Anything, policy).Return(errors.New("event not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name:        "Storage error",
+			eventID:     "1",
+			requestBody: `{"full_refund_hours": 72, "partial_refund_percent": 50, "cutoff_hours": 24}`,
+			mockSetup: func(m *mocks.PolicySetter) {
+				m.On("SetCancellationPolicy", mock.Anything, policy).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to set cancellation policy","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSetter := mocks.NewPolicySetter(t)
+			tc.mockSetup(mockSetter)
+
+			r := chi.NewRouter()
+			r.Put("/api/v1/events/{id}/cancellation-policy", New(logger, testValidator, mockSetter))
+
+			req, err := http.NewRequest(http.MethodPut, "/api/v1/events/"+tc.eventID+"/cancellation-policy", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
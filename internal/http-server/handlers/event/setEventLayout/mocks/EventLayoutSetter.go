@@ -0,0 +1,46 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventLayoutSetter is an autogenerated mock type for the EventLayoutSetter type
+type EventLayoutSetter struct {
+	mock.Mock
+}
+
+// SetEventLayout provides a mock function with given fields: ctx, eventID, layoutID
+func (_m *EventLayoutSetter) SetEventLayout(ctx context.Context, eventID int, layoutID int) error {
+	ret := _m.Called(ctx, eventID, layoutID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEventLayout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, eventID, layoutID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEventLayoutSetter creates a new instance of EventLayoutSetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventLayoutSetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventLayoutSetter {
+	mock := &EventLayoutSetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+package setEventLayout
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type LayoutRequest struct {
+	LayoutID int `json:"layout_id" validate:"required,gt=0"`
+}
+
+type LayoutResponse struct {
+	EventID  int `json:"event_id"`
+	LayoutID int `json:"layout_id"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=EventLayoutSetter
+type EventLayoutSetter interface {
+	SetEventLayout(ctx context.Context, eventID, layoutID int) error
+}
+
+// New switches the event to reserved seating with the seats of the layout,
+// which replace its seat count. It is refused once the event has bookings.
+func New(log *slog.Logger, v *validator.Validate, events EventLayoutSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.setEventLayout.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		var req LayoutRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		if err = events.SetEventLayout(r.Context(), eventID, req.LayoutID); err != nil {
+			log.Error("failed to set event layout", sl.Err(err))
+
+			switch err.Error() {
+			case "event not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+			case "seat layout not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "seat layout not found")
+			case "event already has bookings":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "the layout of an event with bookings cannot be changed")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to set event layout")
+			}
+			return
+		}
+
+		log.Info("event layout set", slog.Int("layout_id", req.LayoutID))
+
+		response.OK(w, r, LayoutResponse{EventID: eventID, LayoutID: req.LayoutID})
+	}
+}
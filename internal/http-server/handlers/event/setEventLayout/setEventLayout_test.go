@@ -0,0 +1,134 @@
+package setEventLayout
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/event/setEventLayout/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestSetEventLayoutHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		requestBody    string
+		mockSetup      func(m *mocks.EventLayoutSetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			eventID:     "1",
+			requestBody: `{"layout_id": 3}`,
+			mockSetup: func(m *mocks.EventLayoutSetter) {
+				m.On("SetEventLayout", mock.Anything, 1, 3).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":1,"layout_id":3},"meta":{}}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "abc",
+			requestBody:    `{"layout_id": 3}`,
+			mockSetup:      func(m *mocks.EventLayoutSetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			eventID:        "1",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.EventLayoutSetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "Missing layout ID",
+			eventID:        "1",
+			requestBody:    `{}`,
+			mockSetup:      func(m *mocks.EventLayoutSetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field layout_id is a required field","code":"validation_failed","errors":[{"field":"layout_id","tag":"required","message":"field layout_id is a required field"}]}`,
+		},
+		{
+			name:        "Event not found",
+			eventID:     "1",
+			requestBody: `{"layout_id": 3}`,
+			mockSetup: func(m *mocks.EventLayoutSetter) {
+				m.On("SetEventLayout", mock.Anything, 1, 3).Return(errors.New("event not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name:        "Layout not found",
+			eventID:     "1",
+			requestBody: `{"layout_id": 3}`,
+			mockSetup: func(m *mocks.EventLayoutSetter) {
+				m.On("SetEventLayout", mock.Anything, 1, 3).Return(errors.New("seat layout not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"seat layout not found","code":"not_found"}`,
+		},
+		{
+			name:        "Event has bookings",
+			eventID:     "1",
+			requestBody: `{"layout_id": 3}`,
+			mockSetup: func(m *mocks.EventLayoutSetter) {
+				m.On("SetEventLayout", mock.Anything, 1, 3).Return(errors.New("event already has bookings"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"the layout of an event with bookings cannot be changed","code":"conflict"}`,
+		},
+		{
+			name:        "Storage error",
+			eventID:     "1",
+			requestBody: `{"layout_id": 3}`,
+			mockSetup: func(m *mocks.EventLayoutSetter) {
+				m.On("SetEventLayout", mock.Anything, 1, 3).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to set event layout","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSetter := mocks.NewEventLayoutSetter(t)
+			tc.mockSetup(mockSetter)
+
+			r := chi.NewRouter()
+			r.Put("/api/v1/events/{id}/layout", New(logger, testValidator, mockSetter))
+
+			req, err := http.NewRequest(http.MethodPut, "/api/v1/events/"+tc.eventID+"/layout", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
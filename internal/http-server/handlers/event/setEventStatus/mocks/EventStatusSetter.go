@@ -0,0 +1,56 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventStatusSetter is an autogenerated mock type for the EventStatusSetter type
+type EventStatusSetter struct {
+	mock.Mock
+}
+
+// SetEventStatus provides a mock function with given fields: ctx, eventID, status
+func (_m *EventStatusSetter) SetEventStatus(ctx context.Context, eventID int, status string) (int, error) {
+	ret := _m.Called(ctx, eventID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEventStatus")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (int, error)); ok {
+		return rf(ctx, eventID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) int); ok {
+		r0 = rf(ctx, eventID, status)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, eventID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventStatusSetter creates a new instance of EventStatusSetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventStatusSetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventStatusSetter {
+	mock := &EventStatusSetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+package setEventStatus
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// StatusRequest moves an event to another status. Events are created as
+// drafts or published, so neither can be moved back to draft.
+type StatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=published sales_closed cancelled completed"`
+}
+
+type StatusResponse struct {
+	EventID int    `json:"event_id"`
+	Status  string `json:"status"`
+	// CancelledBookings is the number of bookings cancelled with the event.
+	CancelledBookings int `json:"cancelled_bookings"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=EventStatusSetter
+type EventStatusSetter interface {
+	SetEventStatus(ctx context.Context, eventID int, status string) (int, error)
+}
+
+// New moves the event to the requested status. Cancelling an event cancels
+// its bookings, notifies their users and refunds paid ones in full.
+func New(log *slog.Logger, v *validator.Validate, events EventStatusSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.event.setEventStatus.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		var req StatusRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		cancelled, err := events.SetEventStatus(r.Context(), eventID, req.Status)
+		if err != nil {
+			log.Error("failed to set event status", sl.Err(err))
+
+			switch err.Error() {
+			case "event not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+			case "invalid status transition":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "the event cannot move to status "+req.Status+" from its current status")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to set event status")
+			}
+			return
+		}
+
+		log.Info("event status set", slog.String("status", req.Status), slog.Int("cancelled_bookings", cancelled))
+
+		response.OK(w, r, StatusResponse{EventID: eventID, Status: req.Status, CancelledBookings: cancelled})
+	}
+}
@@ -0,0 +1,134 @@
+package setEventStatus
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/event/setEventStatus/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestSetEventStatusHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		requestBody    string
+		mockSetup      func(m *mocks.EventStatusSetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Publish",
+			eventID:     "1",
+			requestBody: `{"status": "published"}`,
+			mockSetup: func(m *mocks.EventStatusSetter) {
+				m.On("SetEventStatus", mock.Anything, 1, "published").Return(0, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":1,"status":"published","cancelled_bookings":0},"meta":{}}`,
+		},
+		{
+			name:        "Cancel",
+			eventID:     "1",
+			requestBody: `{"status": "cancelled"}`,
+			mockSetup: func(m *mocks.EventStatusSetter) {
+				m.On("SetEventStatus", mock.Anything, 1, "cancelled").Return(3, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":1,"status":"cancelled","cancelled_bookings":3},"meta":{}}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "abc",
+			requestBody:    `{"status": "published"}`,
+			mockSetup:      func(m *mocks.EventStatusSetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			eventID:        "1",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.EventStatusSetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "Back to draft",
+			eventID:        "1",
+			requestBody:    `{"status": "draft"}`,
+			mockSetup:      func(m *mocks.EventStatusSetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field status must be one of: published, sales_closed, cancelled, completed","code":"validation_failed","errors":[{"field":"status","tag":"oneof","param":"published sales_closed cancelled completed","message":"field status must be one of: published, sales_closed, cancelled, completed"}]}`,
+		},
+		{
+			name:        "Event not found",
+			eventID:     "1",
+			requestBody: `{"status": "published"}`,
+			mockSetup: func(m *mocks.EventStatusSetter) {
+				m.On("SetEventStatus", mock.Anything, 1, "published").Return(0, errors.New("event not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name:        "Invalid transition",
+			eventID:     "1",
+			requestBody: `{"status": "completed"}`,
+			mockSetup: func(m *mocks.EventStatusSetter) {
+				m.On("SetEventStatus", mock.Anything, 1, "completed").Return(0, errors.New("invalid status transition"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"the event cannot move to status completed from its current status","code":"conflict"}`,
+		},
+		{
+			name:        "Storage error",
+			eventID:     "1",
+			requestBody: `{"status": "published"}`,
+			mockSetup: func(m *mocks.EventStatusSetter) {
+				m.On("SetEventStatus", mock.Anything, 1, "published").Return(0, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to set event status","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSetter := mocks.NewEventStatusSetter(t)
+			tc.mockSetup(mockSetter)
+
+			r := chi.NewRouter()
+			r.Put("/api/v1/events/{id}/status", New(logger, testValidator, mockSetter))
+
+			req, err := http.NewRequest(http.MethodPut, "/api/v1/events/"+tc.eventID+"/status", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package liveness
+
+import (
+	"eventBooker/internal/lib/api/response"
+	"net/http"
+)
+
+// New reports that the process is up and serving requests. It deliberately
+// checks no dependencies: a failing database must not get the process restarted.
+func New() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.OK(w, r, nil)
+	}
+}
@@ -0,0 +1,22 @@
+package liveness
+
+import (
+	"eventBooker/internal/lib/api/openapitest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLivenessHandler(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rr := httptest.NewRecorder()
+	New().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":null,"meta":{}}`, rr.Body.String())
+	openapitest.ValidateResponse(t, req, rr)
+}
@@ -0,0 +1,84 @@
+package readiness
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/health"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const checkTimeout = 2 * time.Second
+
+const (
+	checkOK     = "ok"
+	checkFailed = "failed"
+)
+
+type CheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type ReadinessResponse struct {
+	Checks []CheckResult `json:"checks"`
+}
+
+func New(log *slog.Logger, checks ...health.Check) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.health.readiness.New"
+
+		log := log.With(slog.String("op", op))
+
+		results := make([]CheckResult, len(checks))
+
+		var wg sync.WaitGroup
+		for i, check := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = run(r.Context(), check)
+			}()
+		}
+		wg.Wait()
+
+		ready := true
+		for _, res := range results {
+			if res.Status != checkOK {
+				log.Warn("readiness check failed", slog.String("check", res.Name), slog.String("error", res.Error))
+				ready = false
+			}
+		}
+
+		if !ready {
+			response.ErrorWithDetails(w, r, http.StatusServiceUnavailable, response.CodeUnavailable, "not ready", results)
+			return
+		}
+
+		response.OK(w, r, ReadinessResponse{Checks: results})
+	}
+}
+
+func run(ctx context.Context, check health.Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	t1 := time.Now()
+	err := check.Func(ctx)
+
+	res := CheckResult{
+		Name:     check.Name,
+		Status:   checkOK,
+		Duration: time.Since(t1).String(),
+	}
+	if err != nil {
+		res.Status = checkFailed
+		res.Error = err.Error()
+	}
+
+	return res
+}
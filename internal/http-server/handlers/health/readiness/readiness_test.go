@@ -0,0 +1,147 @@
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/health"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDB struct {
+	pingErr error
+	version uint
+	dirty   bool
+}
+
+func (f fakeDB) Ping(context.Context) error { return f.pingErr }
+
+func (f fakeDB) SchemaVersion(context.Context) (uint, bool, error) {
+	return f.version, f.dirty, nil
+}
+
+func TestReadinessHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	staleHeartbeat := &health.Heartbeat{}
+
+	testCases := []struct {
+		name           string
+		db             fakeDB
+		heartbeat      *health.Heartbeat
+		shuttingDown   bool
+		expectedStatus int
+		failedChecks   map[string]string
+	}{
+		{
+			name:           "Ready",
+			db:             fakeDB{version: 4},
+			heartbeat:      health.NewHeartbeat(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Database down",
+			db:             fakeDB{version: 4, pingErr: errors.New("connection refused")},
+			heartbeat:      health.NewHeartbeat(),
+			expectedStatus: http.StatusServiceUnavailable,
+			failedChecks:   map[string]string{"database": "connection refused"},
+		},
+		{
+			name:           "Schema behind",
+			db:             fakeDB{version: 3},
+			heartbeat:      health.NewHeartbeat(),
+			expectedStatus: http.StatusServiceUnavailable,
+			failedChecks:   map[string]string{"schema": "schema version is 3, expected 4"},
+		},
+		{
+			name:           "Dirty migration",
+			db:             fakeDB{version: 4, dirty: true},
+			heartbeat:      health.NewHeartbeat(),
+			expectedStatus: http.StatusServiceUnavailable,
+			failedChecks:   map[string]string{"schema": "migration 4 is dirty"},
+		},
+		{
+			name:           "Stale worker",
+			db:             fakeDB{version: 4},
+			heartbeat:      staleHeartbeat,
+			expectedStatus: http.StatusServiceUnavailable,
+			failedChecks:   map[string]string{"expiry_worker": ""},
+		},
+		{
+			name:           "Shutting down",
+			db:             fakeDB{version: 4},
+			heartbeat:      health.NewHeartbeat(),
+			shuttingDown:   true,
+			expectedStatus: http.StatusServiceUnavailable,
+			failedChecks:   map[string]string{"shutdown": "server is shutting down"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var shuttingDown atomic.Bool
+			shuttingDown.Store(tc.shuttingDown)
+
+			handler := New(logger,
+				health.Draining(&shuttingDown),
+				health.Database(tc.db),
+				health.Schema(tc.db, 4),
+				health.Worker("expiry_worker", tc.heartbeat, time.Minute),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			openapitest.ValidateResponse(t, req, rr)
+
+			var checks []CheckResult
+			if len(tc.failedChecks) == 0 {
+				var body struct {
+					Data ReadinessResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				checks = body.Data.Checks
+			} else {
+				var problem struct {
+					Code    string        `json:"code"`
+					Detail  string        `json:"detail"`
+					Details []CheckResult `json:"details"`
+				}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+				assert.Equal(t, "unavailable", problem.Code)
+				assert.Equal(t, "not ready", problem.Detail)
+				checks = problem.Details
+			}
+			require.Len(t, checks, 4)
+
+			for _, check := range checks {
+				expectedErr, failed := tc.failedChecks[check.Name]
+				if !failed {
+					assert.Equal(t, "ok", check.Status, check.Name)
+					continue
+				}
+
+				assert.Equal(t, "failed", check.Status, check.Name)
+				if expectedErr != "" {
+					assert.Equal(t, expectedErr, check.Error, check.Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package createLayout
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type LayoutRequest struct {
+	Name     string           `json:"name" validate:"required,max=100"`
+	Sections []SectionRequest `json:"sections" validate:"required,min=1,max=50,dive"`
+}
+
+type SectionRequest struct {
+	Name string       `json:"name" validate:"required,max=50"`
+	Rows []RowRequest `json:"rows" validate:"required,min=1,max=200,dive"`
+}
+
+type RowRequest struct {
+	Name string `json:"name" validate:"required,max=20"`
+	// Seats is the number of seats in the row, numbered from 1.
+	Seats int `json:"seats" validate:"gt=0,max=500"`
+}
+
+type LayoutResponse struct {
+	LayoutID int `json:"layout_id"`
+	Seats    int `json:"seats"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=LayoutCreator
+type LayoutCreator interface {
+	CreateSeatLayout(ctx context.Context, layout models.SeatLayout) (int, error)
+}
+
+// New creates a seat layout of named sections made of named rows of seats.
+// A layout can hold as many seats as an event.
+func New(log *slog.Logger, v *validator.Validate, layouts LayoutCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.layout.createLayout.New"
+
+		log = log.With(slog.String("op", op))
+
+		var req LayoutRequest
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.String("name", req.Name), slog.Int("sections", len(req.Sections)))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		seats := 0
+		for _, s := range req.Sections {
+			for _, row := range s.Rows {
+				seats += row.Seats
+			}
+		}
+
+		if err = v.Var(seats, validate.TagSeats); err != nil {
+			log.Error("too many seats", slog.Int("seats", seats))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "layout has more seats than an event can have")
+			return
+		}
+
+		layout := models.SeatLayout{Name: strings.TrimSpace(req.Name)}
+		sections := make(map[string]bool, len(req.Sections))
+		for _, s := range req.Sections {
+			if sections[s.Name] {
+				log.Error("duplicate section", slog.String("section", s.Name))
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "section names must be unique")
+				return
+			}
+			sections[s.Name] = true
+
+			rows := make(map[string]bool, len(s.Rows))
+			for _, row := range s.Rows {
+				if rows[row.Name] {
+					log.Error("duplicate row", slog.String("section", s.Name), slog.String("row", row.Name))
+					response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "row names must be unique within a section")
+					return
+				}
+				rows[row.Name] = true
+
+				for n := 1; n <= row.Seats; n++ {
+					layout.AddSeat(s.Name, row.Name, models.Seat{Number: n})
+				}
+			}
+		}
+
+		id, err := layouts.CreateSeatLayout(r.Context(), layout)
+		if err != nil {
+			log.Error("failed to create seat layout", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to create seat layout")
+			return
+		}
+
+		log.Info("seat layout created", slog.Int("id", id), slog.Int("seats", seats))
+
+		response.OK(w, r, LayoutResponse{LayoutID: id, Seats: seats})
+	}
+}
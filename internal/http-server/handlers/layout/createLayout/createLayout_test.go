@@ -0,0 +1,129 @@
+package createLayout
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/layout/createLayout/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestCreateLayoutHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mocks.LayoutCreator)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			requestBody: `{"name": " Main hall ", "sections": [{"name": "Stalls", "rows": [{"name": "A", "seats": 2}, {"name": "B", "seats": 1}]}, {"name": "Balcony", "rows": [{"name": "A", "seats": 1}]}]}`,
+			mockSetup: func(m *mocks.LayoutCreator) {
+				m.On("CreateSeatLayout", mock.Anything, models.SeatLayout{
+					Name: "Main hall",
+					Sections: []models.SeatSection{
+						{Name: "Stalls", Rows: []models.SeatRow{
+							{Name: "A", Seats: []models.Seat{{Number: 1}, {Number: 2}}},
+							{Name: "B", Seats: []models.Seat{{Number: 1}}},
+						}},
+						{Name: "Balcony", Rows: []models.SeatRow{
+							{Name: "A", Seats: []models.Seat{{Number: 1}}},
+						}},
+					},
+				}).Return(3, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"layout_id":3,"seats":4},"meta":{}}`,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.LayoutCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "No sections",
+			requestBody:    `{"name": "Main hall", "sections": []}`,
+			mockSetup:      func(m *mocks.LayoutCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field sections must be at least 1 items","code":"validation_failed","errors":[{"field":"sections","tag":"min","param":"1","message":"field sections must be at least 1 items"}]}`,
+		},
+		{
+			name:           "Row without seats",
+			requestBody:    `{"name": "Main hall", "sections": [{"name": "Stalls", "rows": [{"name": "A", "seats": 0}]}]}`,
+			mockSetup:      func(m *mocks.LayoutCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field seats must be greater than 0","code":"validation_failed","errors":[{"field":"seats","tag":"gt","param":"0","message":"field seats must be greater than 0"}]}`,
+		},
+		{
+			name:           "Duplicate section",
+			requestBody:    `{"name": "Main hall", "sections": [{"name": "Stalls", "rows": [{"name": "A", "seats": 2}]}, {"name": "Stalls", "rows": [{"name": "B", "seats": 2}]}]}`,
+			mockSetup:      func(m *mocks.LayoutCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"section names must be unique","code":"bad_request"}`,
+		},
+		{
+			name:           "Duplicate row",
+			requestBody:    `{"name": "Main hall", "sections": [{"name": "Stalls", "rows": [{"name": "A", "seats": 2}, {"name": "A", "seats": 2}]}]}`,
+			mockSetup:      func(m *mocks.LayoutCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"row names must be unique within a section","code":"bad_request"}`,
+		},
+		{
+			name:           "Too many seats",
+			requestBody:    `{"name": "Arena", "sections": [{"name": "Floor", "rows": [{"name": "A", "seats": 500}, {"name": "B", "seats": 500}, {"name": "C", "seats": 1}]}]}`,
+			mockSetup:      func(m *mocks.LayoutCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"layout has more seats than an event can have","code":"bad_request"}`,
+		},
+		{
+			name:        "Storage error",
+			requestBody: `{"name": "Main hall", "sections": [{"name": "Stalls", "rows": [{"name": "A", "seats": 2}]}]}`,
+			mockSetup: func(m *mocks.LayoutCreator) {
+				m.On("CreateSeatLayout", mock.Anything, mock.Anything).Return(0, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to create seat layout","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCreator := mocks.NewLayoutCreator(t)
+			tc.mockSetup(mockCreator)
+
+			handler := New(logger, testValidator, mockCreator)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/layouts", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
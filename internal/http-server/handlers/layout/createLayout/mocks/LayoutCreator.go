@@ -0,0 +1,58 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// LayoutCreator is an autogenerated mock type for the LayoutCreator type
+type LayoutCreator struct {
+	mock.Mock
+}
+
+// CreateSeatLayout provides a mock function with given fields: ctx, layout
+func (_m *LayoutCreator) CreateSeatLayout(ctx context.Context, layout models.SeatLayout) (int, error) {
+	ret := _m.Called(ctx, layout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeatLayout")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SeatLayout) (int, error)); ok {
+		return rf(ctx, layout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SeatLayout) int); ok {
+		r0 = rf(ctx, layout)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SeatLayout) error); ok {
+		r1 = rf(ctx, layout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLayoutCreator creates a new instance of LayoutCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLayoutCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LayoutCreator {
+	mock := &LayoutCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,57 @@
+package getLayout
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=LayoutGetter
+type LayoutGetter interface {
+	GetSeatLayout(ctx context.Context, id int) (*models.SeatLayout, error)
+}
+
+// New returns the seat layout with its sections, rows and seats.
+func New(log *slog.Logger, layouts LayoutGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.layout.getLayout.New"
+
+		log = log.With(slog.String("op", op))
+
+		layoutIdStr := chi.URLParam(r, "id")
+		if layoutIdStr == "" {
+			log.Error("layout id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "layout id is required")
+			return
+		}
+
+		layoutID, err := strconv.Atoi(layoutIdStr)
+		if err != nil {
+			log.Error("invalid layout id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid layout id format")
+			return
+		}
+
+		log = log.With(slog.Int("layout_id", layoutID))
+
+		layout, err := layouts.GetSeatLayout(r.Context(), layoutID)
+		if err != nil {
+			log.Error("failed to get seat layout", sl.Err(err))
+
+			if err.Error() == "seat layout not found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "seat layout not found")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get seat layout")
+			return
+		}
+
+		response.OK(w, r, layout)
+	}
+}
@@ -0,0 +1,101 @@
+package getLayout
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/layout/getLayout/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLayoutHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		layoutID       string
+		mockSetup      func(m *mocks.LayoutGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:     "Success",
+			layoutID: "3",
+			mockSetup: func(m *mocks.LayoutGetter) {
+				m.On("GetSeatLayout", mock.Anything, 3).Return(&models.SeatLayout{
+					ID:   3,
+					Name: "Main hall",
+					Sections: []models.SeatSection{
+						{Name: "Stalls", Rows: []models.SeatRow{
+							{Name: "A", Seats: []models.Seat{{ID: 10, Number: 1}, {ID: 11, Number: 2}}},
+						}},
+					},
+					CreatedAt: createdAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":3,"name":"Main hall","sections":[{"name":"Stalls","rows":[{"name":"A","seats":[{"id":10,"number":1},{"id":11,"number":2}]}]}],"created_at":"2026-05-01T12:00:00Z"},"meta":{}}`,
+		},
+		{
+			name:           "Invalid layout ID format",
+			layoutID:       "abc",
+			mockSetup:      func(m *mocks.LayoutGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid layout id format","code":"bad_request"}`,
+		},
+		{
+			name:     "Layout not found",
+			layoutID: "3",
+			mockSetup: func(m *mocks.LayoutGetter) {
+				m.On("GetSeatLayout", mock.Anything, 3).Return(nil, errors.New("seat layout not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"seat layout not found","code":"not_found"}`,
+		},
+		{
+			name:     "Storage error",
+			layoutID: "3",
+			mockSetup: func(m *mocks.LayoutGetter) {
+				m.On("GetSeatLayout", mock.Anything, 3).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get seat layout","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewLayoutGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/layouts/{id}", New(logger, mockGetter))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/layouts/"+tc.layoutID, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// LayoutGetter is an autogenerated mock type for the LayoutGetter type
+type LayoutGetter struct {
+	mock.Mock
+}
+
+// GetSeatLayout provides a mock function with given fields: ctx, id
+func (_m *LayoutGetter) GetSeatLayout(ctx context.Context, id int) (*models.SeatLayout, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeatLayout")
+	}
+
+	var r0 *models.SeatLayout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.SeatLayout, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.SeatLayout); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SeatLayout)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLayoutGetter creates a new instance of LayoutGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLayoutGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LayoutGetter {
+	mock := &LayoutGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,41 @@
+package getNotifications
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=NotificationsGetter
+type NotificationsGetter interface {
+	GetNotifications(ctx context.Context, userID string) ([]models.Notification, error)
+}
+
+// New lists the notifications of the user in the user_id query parameter,
+// newest first, e.g. of events cancelled together with their bookings.
+func New(log *slog.Logger, notifications NotificationsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.notification.getNotifications.New"
+
+		log = log.With(slog.String("op", op))
+
+		userID := r.URL.Query().Get("user_id")
+		if userID == "" {
+			log.Error("user id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "user_id is required")
+			return
+		}
+
+		list, err := notifications.GetNotifications(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to get notifications", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get notifications")
+			return
+		}
+
+		response.OK(w, r, list)
+	}
+}
@@ -0,0 +1,90 @@
+package getNotifications
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/notification/getNotifications/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotificationsHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		query          string
+		mockSetup      func(m *mocks.NotificationsGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "Success",
+			query: "?user_id=alice",
+			mockSetup: func(m *mocks.NotificationsGetter) {
+				m.On("GetNotifications", mock.Anything, "alice").Return([]models.Notification{
+					{ID: 2, UserID: "alice", EventID: 7, Kind: models.NotificationEventCancelled, Message: "Hamlet has been cancelled.", CreatedAt: testTime},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":2,"user_id":"alice","event_id":7,"kind":"event_cancelled","message":"Hamlet has been cancelled.","created_at":"2024-12-25T18:00:00Z"}],"meta":{}}`,
+		},
+		{
+			name:  "No notifications",
+			query: "?user_id=bob",
+			mockSetup: func(m *mocks.NotificationsGetter) {
+				m.On("GetNotifications", mock.Anything, "bob").Return([]models.Notification{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[],"meta":{}}`,
+		},
+		{
+			name:           "Missing user ID",
+			query:          "",
+			mockSetup:      func(m *mocks.NotificationsGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"user_id is required","code":"bad_request"}`,
+		},
+		{
+			name:  "Storage error",
+			query: "?user_id=alice",
+			mockSetup: func(m *mocks.NotificationsGetter) {
+				m.On("GetNotifications", mock.Anything, "alice").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get notifications","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewNotificationsGetter(t)
+			tc.mockSetup(mockGetter)
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/notifications"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			New(logger, mockGetter).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
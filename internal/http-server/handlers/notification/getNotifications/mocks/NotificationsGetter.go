@@ -0,0 +1,60 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// NotificationsGetter is an autogenerated mock type for the NotificationsGetter type
+type NotificationsGetter struct {
+	mock.Mock
+}
+
+// GetNotifications provides a mock function with given fields: ctx, userID
+func (_m *NotificationsGetter) GetNotifications(ctx context.Context, userID string) ([]models.Notification, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifications")
+	}
+
+	var r0 []models.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Notification, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Notification); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewNotificationsGetter creates a new instance of NotificationsGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationsGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationsGetter {
+	mock := &NotificationsGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,125 @@
+package createPayment
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/models"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type PaymentRequest struct {
+	UserId string `json:"user_id" validate:"required"`
+}
+
+type PaymentResponse struct {
+	PaymentID int `json:"payment_id"`
+	// CheckoutURL is the provider's page the user pays on.
+	CheckoutURL string `json:"checkout_url"`
+	// Amount is in the minor units of Currency.
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=PaymentCreator
+type PaymentCreator interface {
+	CreatePayment(ctx context.Context, eventID int, userID, provider string) (*models.Payment, error)
+	SetPaymentProviderID(ctx context.Context, id int, providerID string) error
+}
+
+// New starts the payment of a pending booking of a priced ticket type. The
+// user pays on the returned checkout page, and the booking is confirmed when
+// the provider reports the payment to the webhook.
+func New(log *slog.Logger, v *validator.Validate, payments PaymentCreator, provider payment.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.payment.createPayment.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		var req PaymentRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		p, err := payments.CreatePayment(r.Context(), eventID, req.UserId, provider.Name())
+		if err != nil {
+			log.Error("failed to create payment", sl.Err(err))
+
+			switch err.Error() {
+			case "no pending booking found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "no pending booking found for this user")
+			case "payment not required":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "booking is free, confirm it instead")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to create payment")
+			}
+			return
+		}
+
+		checkout, err := provider.CreateIntent(r.Context(), payment.Intent{
+			PaymentID:   p.ID,
+			Amount:      p.Amount,
+			Currency:    p.Currency,
+			Description: fmt.Sprintf("Booking for event %d", eventID),
+		})
+		if err != nil {
+			log.Error("failed to create payment at provider", sl.Err(err))
+			response.Error(w, r, http.StatusBadGateway, response.CodeUnavailable, "payment provider is unavailable")
+			return
+		}
+
+		if err = payments.SetPaymentProviderID(r.Context(), p.ID, checkout.ID); err != nil {
+			log.Error("failed to save provider payment id", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to create payment")
+			return
+		}
+
+		log.Info("payment created", slog.Int("payment_id", p.ID), slog.String("provider_payment_id", checkout.ID))
+
+		response.OK(w, r, PaymentResponse{
+			PaymentID:   p.ID,
+			CheckoutURL: checkout.URL,
+			Amount:      p.Amount,
+			Currency:    p.Currency,
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package createPayment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/payment/createPayment/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+// unavailableProvider fails to create payments.
+type unavailableProvider struct {
+	*payment.Fake
+}
+
+func (unavailableProvider) CreateIntent(context.Context, payment.Intent) (payment.Checkout, error) {
+	return payment.Checkout{}, errors.New("connection refused")
+}
+
+func TestCreatePaymentHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	fake := payment.NewFake("secret", "/payments/fake", "http://localhost/api/v1/payments/webhook")
+
+	created := &models.Payment{
+		ID:       7,
+		EventID:  1,
+		UserID:   "user123",
+		Provider: "fake",
+		Amount:   150050,
+		Currency: "RUB",
+		Status:   models.PaymentPending,
+	}
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		requestBody    string
+		provider       payment.Provider
+		mockSetup      func(m *mocks.PaymentCreator)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.PaymentCreator) {
+				m.On("CreatePayment", mock.Anything, 1, "user123", "fake").Return(created, nil)
+				m.On("SetPaymentProviderID", mock.Anything, 7, mock.MatchedBy(func(id string) bool {
+					return strings.HasPrefix(id, "fake_")
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "abc",
+			requestBody:    `{"user_id": "user123"}`,
+			mockSetup:      func(m *mocks.PaymentCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			eventID:        "1",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.PaymentCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "Missing user ID",
+			eventID:        "1",
+			requestBody:    `{}`,
+			mockSetup:      func(m *mocks.PaymentCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field user_id is a required field","code":"validation_failed","errors":[{"field":"user_id","tag":"required","message":"field user_id is a required field"}]}`,
+		},
+		{
+			name:        "No pending booking",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.PaymentCreator) {
+				m.On("CreatePayment", mock.Anything, 1, "user123", "fake").Return(nil, errors.New("no pending booking found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"no pending booking found for this user","code":"not_found"}`,
+		},
+		{
+			name:        "Free booking",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.PaymentCreator) {
+				m.On("CreatePayment", mock.Anything, 1, "user123", "fake").Return(nil, errors.New("payment not required"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"booking is free, confirm it instead","code":"conflict"}`,
+		},
+		{
+			name:        "Storage error",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.PaymentCreator) {
+				m.On("CreatePayment", mock.Anything, 1, "user123", "fake").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to create payment","code":"internal_error"}`,
+		},
+		{
+			name:        "Provider unavailable",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			provider:    unavailableProvider{fake},
+			mockSetup: func(m *mocks.PaymentCreator) {
+				m.On("CreatePayment", mock.Anything, 1, "user123", "fake").Return(created, nil)
+			},
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `{"type":"about:blank","title":"Bad Gateway","status":502,"detail":"payment provider is unavailable","code":"unavailable"}`,
+		},
+		{
+			name:        "Provider id not saved",
+			eventID:     "1",
+			requestBody: `{"user_id": "user123"}`,
+			mockSetup: func(m *mocks.PaymentCreator) {
+				m.On("CreatePayment", mock.Anything, 1, "user123", "fake").Return(created, nil)
+				m.On("SetPaymentProviderID", mock.Anything, 7, mock.Anything).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to create payment","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCreator := mocks.NewPaymentCreator(t)
+			tc.mockSetup(mockCreator)
+
+			provider := tc.provider
+			if provider == nil {
+				provider = fake
+			}
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/events/{id}/pay", New(logger, testValidator, mockCreator, provider))
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/events/"+tc.eventID+"/pay", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+				return
+			}
+
+			var resp struct {
+				Data PaymentResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, 7, resp.Data.PaymentID)
+			assert.Equal(t, int64(150050), resp.Data.Amount)
+			assert.Equal(t, "RUB", resp.Data.Currency)
+			assert.True(t, strings.HasPrefix(resp.Data.CheckoutURL, "/payments/fake/fake_"), resp.Data.CheckoutURL)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// PaymentCreator is an autogenerated mock type for the PaymentCreator type
+type PaymentCreator struct {
+	mock.Mock
+}
+
+// CreatePayment provides a mock function with given fields: ctx, eventID, userID, provider
+func (_m *PaymentCreator) CreatePayment(ctx context.Context, eventID int, userID string, provider string) (*models.Payment, error) {
+	ret := _m.Called(ctx, eventID, userID, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePayment")
+	}
+
+	var r0 *models.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (*models.Payment, error)); ok {
+		return rf(ctx, eventID, userID, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) *models.Payment); ok {
+		r0 = rf(ctx, eventID, userID, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, eventID, userID, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPaymentProviderID provides a mock function with given fields: ctx, id, providerID
+func (_m *PaymentCreator) SetPaymentProviderID(ctx context.Context, id int, providerID string) error {
+	ret := _m.Called(ctx, id, providerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPaymentProviderID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, providerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentCreator creates a new instance of PaymentCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentCreator {
+	mock := &PaymentCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+package getRefunds
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=RefundsGetter
+type RefundsGetter interface {
+	GetRefunds(ctx context.Context, eventID int) ([]models.Refund, error)
+}
+
+// New lists the refunds of the event's payments, oldest first, with their
+// bookings and audit notes.
+func New(log *slog.Logger, refunds RefundsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.payment.getRefunds.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		list, err := refunds.GetRefunds(r.Context(), eventID)
+		if err != nil {
+			log.Error("failed to get refunds", slog.Int("event_id", eventID), sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get refunds")
+			return
+		}
+
+		response.OK(w, r, list)
+	}
+}
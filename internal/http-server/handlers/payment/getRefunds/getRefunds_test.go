@@ -0,0 +1,126 @@
+package getRefunds
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/payment/getRefunds/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRefundsHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
+	bookingID := 3
+	testRefunds := []models.Refund{
+		{
+			ID:         1,
+			PaymentID:  2,
+			BookingID:  &bookingID,
+			EventID:    1,
+			UserID:     "user1",
+			Amount:     750,
+			Currency:   "EUR",
+			Reason:     models.RefundCancellation,
+			Status:     models.RefundSucceeded,
+			CreatedAt:  testTime,
+			UpdatedAt:  testTime,
+			ProviderID: "fake_1",
+		},
+		{
+			ID:        2,
+			PaymentID: 2,
+			EventID:   1,
+			UserID:    "user1",
+			Amount:    750,
+			Currency:  "EUR",
+			Reason:    models.RefundOverride,
+			Note:      "event moved",
+			CreatedBy: "admin",
+			Status:    models.RefundFailed,
+			CreatedAt: testTime,
+			UpdatedAt: testTime,
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		eventID        string
+		mockSetup      func(m *mocks.RefundsGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:    "Success",
+			eventID: "1",
+			mockSetup: func(m *mocks.RefundsGetter) {
+				m.On("GetRefunds", mock.Anything, 1).Return(testRefunds, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[` +
+				`{"id":1,"payment_id":2,"booking_id":3,"event_id":1,"user_id":"user1","amount":750,"currency":"EUR","reason":"cancellation","status":"succeeded","created_at":"2024-12-25T18:00:00Z","updated_at":"2024-12-25T18:00:00Z"},` +
+				`{"id":2,"payment_id":2,"event_id":1,"user_id":"user1","amount":750,"currency":"EUR","reason":"override","note":"event moved","created_by":"admin","status":"failed","created_at":"2024-12-25T18:00:00Z","updated_at":"2024-12-25T18:00:00Z"}` +
+				`],"meta":{}}`,
+		},
+		{
+			name:    "No refunds",
+			eventID: "1",
+			mockSetup: func(m *mocks.RefundsGetter) {
+				m.On("GetRefunds", mock.Anything, 1).Return([]models.Refund{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[],"meta":{}}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			eventID:        "abc",
+			mockSetup:      func(m *mocks.RefundsGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:    "Storage error",
+			eventID: "1",
+			mockSetup: func(m *mocks.RefundsGetter) {
+				m.On("GetRefunds", mock.Anything, 1).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get refunds","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewRefundsGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/events/{id}/refunds", New(logger, mockGetter))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/events/"+tc.eventID+"/refunds", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
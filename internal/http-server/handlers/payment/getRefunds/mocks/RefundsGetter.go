@@ -0,0 +1,60 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// RefundsGetter is an autogenerated mock type for the RefundsGetter type
+type RefundsGetter struct {
+	mock.Mock
+}
+
+// GetRefunds provides a mock function with given fields: ctx, eventID
+func (_m *RefundsGetter) GetRefunds(ctx context.Context, eventID int) ([]models.Refund, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefunds")
+	}
+
+	var r0 []models.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]models.Refund, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.Refund); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRefundsGetter creates a new instance of RefundsGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRefundsGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RefundsGetter {
+	mock := &RefundsGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,104 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentCompleter is an autogenerated mock type for the PaymentCompleter type
+type PaymentCompleter struct {
+	mock.Mock
+}
+
+// ClaimRefund provides a mock function with given fields: ctx, id
+func (_m *PaymentCompleter) ClaimRefund(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompletePayment provides a mock function with given fields: ctx, provider, providerID, succeeded
+func (_m *PaymentCompleter) CompletePayment(ctx context.Context, provider string, providerID string, succeeded bool) (*models.Payment, *models.Refund, error) {
+	ret := _m.Called(ctx, provider, providerID, succeeded)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompletePayment")
+	}
+
+	var r0 *models.Payment
+	var r1 *models.Refund
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (*models.Payment, *models.Refund, error)); ok {
+		return rf(ctx, provider, providerID, succeeded)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *models.Payment); ok {
+		r0 = rf(ctx, provider, providerID, succeeded)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) *models.Refund); ok {
+		r1 = rf(ctx, provider, providerID, succeeded)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, bool) error); ok {
+		r2 = rf(ctx, provider, providerID, succeeded)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CompleteRefund provides a mock function with given fields: ctx, id, succeeded
+func (_m *PaymentCompleter) CompleteRefund(ctx context.Context, id int, succeeded bool) error {
+	ret := _m.Called(ctx, id, succeeded)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) error); ok {
+		r0 = rf(ctx, id, succeeded)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentCompleter creates a new instance of PaymentCompleter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentCompleter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentCompleter {
+	mock := &PaymentCompleter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
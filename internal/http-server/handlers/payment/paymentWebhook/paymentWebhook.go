@@ -0,0 +1,77 @@
+package paymentWebhook
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+)
+
+type WebhookResponse struct {
+	PaymentID int    `json:"payment_id"`
+	Status    string `json:"status"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=PaymentCompleter
+type PaymentCompleter interface {
+	CompletePayment(ctx context.Context, provider, providerID string, succeeded bool) (*models.Payment, *models.Refund, error)
+	payment.RefundCompleter
+}
+
+// New receives the provider's callbacks. Only a callback with a valid
+// signature completes a payment, and only a succeeded payment confirms its
+// booking. A payment whose booking can no longer be confirmed is refunded:
+// the refund is recorded with the payment and sent here.
+func New(log *slog.Logger, payments PaymentCompleter, provider payment.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.payment.paymentWebhook.New"
+
+		log = log.With(slog.String("op", op))
+
+		cb, err := provider.VerifyCallback(r)
+		if err != nil {
+			log.Error("failed to verify callback", sl.Err(err))
+			if errors.Is(err, payment.ErrInvalidCallback) {
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid payment callback")
+				return
+			}
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to verify payment callback")
+			return
+		}
+
+		log = log.With(slog.String("provider_payment_id", cb.ID), slog.Bool("succeeded", cb.Succeeded))
+
+		p, refund, err := payments.CompletePayment(r.Context(), provider.Name(), cb.ID, cb.Succeeded)
+		if err != nil && refund == nil {
+			log.Error("failed to complete payment", sl.Err(err))
+
+			switch err.Error() {
+			case "payment not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "payment not found")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to complete payment")
+			}
+			return
+		}
+
+		if refund != nil {
+			log.Warn("paid booking cannot be confirmed, refunding", slog.Int("payment_id", p.ID), sl.Err(err))
+
+			if err = payment.ExecuteRefund(r.Context(), provider, payments, *refund); err != nil {
+				log.Error("failed to refund payment", slog.Int("payment_id", p.ID), sl.Err(err))
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to refund payment")
+				return
+			}
+
+			p.Status = models.PaymentRefunded
+		}
+
+		log.Info("payment completed", slog.Int("payment_id", p.ID), slog.String("status", p.Status))
+
+		response.OK(w, r, WebhookResponse{PaymentID: p.ID, Status: p.Status})
+	}
+}
@@ -0,0 +1,179 @@
+package paymentWebhook
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/http-server/handlers/payment/paymentWebhook/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// refundProvider refunds with the given result.
+type refundProvider struct {
+	*payment.Fake
+	err error
+}
+
+func (p refundProvider) Refund(context.Context, string, int64, string) error {
+	return p.err
+}
+
+func TestPaymentWebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	fake := payment.NewFake("secret", "/payments/fake", "/api/v1/payments/webhook")
+
+	refund := &models.Refund{ID: 3, PaymentID: 7, Amount: 5000, Currency: "EUR", Reason: models.RefundUnconfirmable, Status: models.RefundPending, ProviderID: "fake_1"}
+
+	paid := func(status string) *models.Payment {
+		return &models.Payment{ID: 7, EventID: 1, UserID: "user123", Provider: "fake", ProviderID: "fake_1", Amount: 5000, Currency: "EUR", Status: status}
+	}
+
+	testCases := []struct {
+		name           string
+		signer         *payment.Fake
+		succeeded      bool
+		refundErr      error
+		mockSetup      func(m *mocks.PaymentCompleter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "Succeeded",
+			succeeded: true,
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", true).Return(paid(models.PaymentSucceeded), nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"payment_id":7,"status":"succeeded"},"meta":{}}`,
+		},
+		{
+			name:      "Replayed after the refund was recorded",
+			succeeded: true,
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", true).Return(paid(models.PaymentRefunded), nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"payment_id":7,"status":"refunded"},"meta":{}}`,
+		},
+		{
+			name:      "Failed",
+			succeeded: false,
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", false).Return(paid(models.PaymentFailed), nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"payment_id":7,"status":"failed"},"meta":{}}`,
+		},
+		{
+			name:           "Invalid signature",
+			signer:         payment.NewFake("other", "/payments/fake", "/api/v1/payments/webhook"),
+			succeeded:      true,
+			mockSetup:      func(m *mocks.PaymentCompleter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid payment callback","code":"bad_request"}`,
+		},
+		{
+			name:      "Payment not found",
+			succeeded: true,
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", true).Return(nil, nil, errors.New("payment not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"payment not found","code":"not_found"}`,
+		},
+		{
+			name:      "Storage error",
+			succeeded: true,
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", true).Return(nil, nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to complete payment","code":"internal_error"}`,
+		},
+		{
+			name:      "Refunded when seats ran out",
+			succeeded: true,
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", true).Return(paid(models.PaymentSucceeded), refund, errors.New("no available seats"))
+				m.On("ClaimRefund", mock.Anything, 3).Return(nil)
+				m.On("CompleteRefund", mock.Anything, 3, true).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"payment_id":7,"status":"refunded"},"meta":{}}`,
+		},
+		{
+			name:      "Refunded when booking already paid",
+			succeeded: true,
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", true).Return(paid(models.PaymentSucceeded), refund, errors.New("booking already paid"))
+				m.On("ClaimRefund", mock.Anything, 3).Return(nil)
+				m.On("CompleteRefund", mock.Anything, 3, true).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"payment_id":7,"status":"refunded"},"meta":{}}`,
+		},
+		{
+			name:      "Refund failed",
+			succeeded: true,
+			refundErr: errors.New("provider unavailable"),
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", true).Return(paid(models.PaymentSucceeded), refund, errors.New("no pending booking found"))
+				m.On("ClaimRefund", mock.Anything, 3).Return(nil)
+				m.On("CompleteRefund", mock.Anything, 3, false).Return(nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to refund payment","code":"internal_error"}`,
+		},
+		{
+			name:      "Refund not claimed",
+			succeeded: true,
+			mockSetup: func(m *mocks.PaymentCompleter) {
+				m.On("CompletePayment", mock.Anything, "fake", "fake_1", true).Return(paid(models.PaymentSucceeded), refund, errors.New("no available seats"))
+				m.On("ClaimRefund", mock.Anything, 3).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to refund payment","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCompleter := mocks.NewPaymentCompleter(t)
+			tc.mockSetup(mockCompleter)
+
+			signer := tc.signer
+			if signer == nil {
+				signer = fake
+			}
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/payments/webhook", New(logger, mockCompleter, refundProvider{Fake: fake, err: tc.refundErr}))
+
+			req, err := signer.NewCallback(context.Background(), "fake_1", tc.succeeded)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RefundIssuer is an autogenerated mock type for the RefundIssuer type
+type RefundIssuer struct {
+	mock.Mock
+}
+
+// ClaimRefund provides a mock function with given fields: ctx, id
+func (_m *RefundIssuer) ClaimRefund(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteRefund provides a mock function with given fields: ctx, id, succeeded
+func (_m *RefundIssuer) CompleteRefund(ctx context.Context, id int, succeeded bool) error {
+	ret := _m.Called(ctx, id, succeeded)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) error); ok {
+		r0 = rf(ctx, id, succeeded)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRefund provides a mock function with given fields: ctx, r
+func (_m *RefundIssuer) CreateRefund(ctx context.Context, r models.Refund) (*models.Refund, error) {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefund")
+	}
+
+	var r0 *models.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Refund) (*models.Refund, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Refund) *models.Refund); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Refund) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRefundIssuer creates a new instance of RefundIssuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRefundIssuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RefundIssuer {
+	mock := &RefundIssuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
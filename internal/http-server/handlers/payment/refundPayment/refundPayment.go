@@ -0,0 +1,125 @@
+package refundPayment
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type RefundRequest struct {
+	// Amount is in the minor units of the payment's currency. Zero refunds
+	// the rest of the payment not refunded yet.
+	Amount int64 `json:"amount,omitempty" validate:"gte=0"`
+	// Note explains the refund for the audit trail.
+	Note string `json:"note" validate:"required,max=500"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=RefundIssuer
+type RefundIssuer interface {
+	CreateRefund(ctx context.Context, r models.Refund) (*models.Refund, error)
+	payment.RefundCompleter
+}
+
+// New lets an admin refund a succeeded payment regardless of the event's
+// cancellation policy, e.g. when the event is moved or a refund failed.
+// The admin authenticated by mwauth and the note are recorded with the
+// refund. It must be routed behind mwauth.RequireRole for the admin role.
+func New(log *slog.Logger, v *validator.Validate, refunds RefundIssuer, provider payment.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.payment.refundPayment.New"
+
+		log = log.With(slog.String("op", op))
+
+		paymentIdStr := chi.URLParam(r, "id")
+		if paymentIdStr == "" {
+			log.Error("payment id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "payment id is required")
+			return
+		}
+
+		paymentID, err := strconv.Atoi(paymentIdStr)
+		if err != nil {
+			log.Error("invalid payment id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid payment id format")
+			return
+		}
+
+		log = log.With(slog.Int("payment_id", paymentID))
+
+		var req RefundRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		req.Note = strings.TrimSpace(req.Note)
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		adminID := mwauth.UserID(r.Context())
+
+		refund, err := refunds.CreateRefund(r.Context(), models.Refund{
+			PaymentID: paymentID,
+			Amount:    req.Amount,
+			Reason:    models.RefundOverride,
+			Note:      req.Note,
+			CreatedBy: adminID,
+		})
+		if err != nil {
+			log.Error("failed to create refund", sl.Err(err))
+
+			switch err.Error() {
+			case "payment not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "payment not found")
+			case "payment not refundable":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "only succeeded payments can be refunded")
+			case "refund exceeds payment":
+				response.Error(w, r, http.StatusUnprocessableEntity, response.CodeUnprocessable, "amount exceeds the part of the payment not refunded yet")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to refund payment")
+			}
+			return
+		}
+
+		if err = payment.ExecuteRefund(r.Context(), provider, refunds, *refund); err != nil {
+			log.Error("failed to refund payment", slog.Int("refund_id", refund.ID), sl.Err(err))
+
+			if errors.Is(err, payment.ErrRefundFailed) {
+				response.Error(w, r, http.StatusBadGateway, response.CodeUnavailable, "payment provider failed to refund")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to refund payment")
+			return
+		}
+
+		refund.Status = models.RefundSucceeded
+
+		log.Info("payment refunded", slog.Int("refund_id", refund.ID), slog.Int64("amount", refund.Amount))
+
+		response.OK(w, r, refund)
+	}
+}
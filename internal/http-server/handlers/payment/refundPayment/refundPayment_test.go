@@ -0,0 +1,191 @@
+package refundPayment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/payment/refundPayment/mocks"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+// refundProvider refunds with the given result.
+type refundProvider struct {
+	*payment.Fake
+	err error
+}
+
+func (p refundProvider) Refund(context.Context, string, int64, string) error {
+	return p.err
+}
+
+func TestRefundPaymentHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	fake := payment.NewFake("secret", "/payments/fake", "/api/v1/payments/webhook")
+
+	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
+
+	override := models.Refund{
+		PaymentID: 7,
+		Amount:    2500,
+		Reason:    models.RefundOverride,
+		Note:      "event moved",
+		CreatedBy: "admin",
+	}
+
+	created := func() *models.Refund {
+		r := override
+		r.ID = 3
+		r.EventID = 1
+		r.UserID = "user123"
+		r.Currency = "EUR"
+		r.Status = models.RefundPending
+		r.CreatedAt = testTime
+		r.UpdatedAt = testTime
+		r.ProviderID = "fake_1"
+		return &r
+	}
+
+	testCases := []struct {
+		name           string
+		paymentID      string
+		requestBody    string
+		refundErr      error
+		mockSetup      func(m *mocks.RefundIssuer)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			paymentID:   "7",
+			requestBody: `{"amount": 2500, "note": "  event moved "}`,
+			mockSetup: func(m *mocks.RefundIssuer) {
+				m.On("CreateRefund", mock.Anything, override).Return(created(), nil)
+				m.On("ClaimRefund", mock.Anything, 3).Return(nil)
+				m.On("CompleteRefund", mock.Anything, 3, true).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":3,"payment_id":7,"event_id":1,"user_id":"user123","amount":2500,"currency":"EUR","reason":"override","note":"event moved","created_by":"admin","status":"succeeded","created_at":"2024-12-25T18:00:00Z","updated_at":"2024-12-25T18:00:00Z"},"meta":{}}`,
+		},
+		{
+			name:           "Invalid payment ID format",
+			paymentID:      "abc",
+			requestBody:    `{"note": "event moved"}`,
+			mockSetup:      func(m *mocks.RefundIssuer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid payment id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			paymentID:      "7",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.RefundIssuer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "Blank note",
+			paymentID:      "7",
+			requestBody:    `{"note": "   "}`,
+			mockSetup:      func(m *mocks.RefundIssuer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field note is a required field","code":"validation_failed","errors":[{"field":"note","tag":"required","message":"field note is a required field"}]}`,
+		},
+		{
+			name:        "Payment not found",
+			paymentID:   "7",
+			requestBody: `{"amount": 2500, "note": "event moved"}`,
+			mockSetup: func(m *mocks.RefundIssuer) {
+				m.On("CreateRefund", mock.Anything, override).Return(nil, errors.New("payment not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"payment not found","code":"not_found"}`,
+		},
+		{
+			name:        "Payment not refundable",
+			paymentID:   "7",
+			requestBody: `{"amount": 2500, "note": "event moved"}`,
+			mockSetup: func(m *mocks.RefundIssuer) {
+				m.On("CreateRefund", mock.Anything, override).Return(nil, errors.New("payment not refundable"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"only succeeded payments can be refunded","code":"conflict"}`,
+		},
+		{
+			name:        "Refund exceeds payment",
+			paymentID:   "7",
+			requestBody: `{"amount": 2500, "note": "event moved"}`,
+			mockSetup: func(m *mocks.RefundIssuer) {
+				m.On("CreateRefund", mock.Anything, override).Return(nil, errors.New("refund exceeds payment"))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"amount exceeds the part of the payment not refunded yet","code":"unprocessable"}`,
+		},
+		{
+			name:        "Provider failed",
+			paymentID:   "7",
+			requestBody: `{"amount": 2500, "note": "event moved"}`,
+			refundErr:   errors.New("connection refused"),
+			mockSetup: func(m *mocks.RefundIssuer) {
+				m.On("CreateRefund", mock.Anything, override).Return(created(), nil)
+				m.On("ClaimRefund", mock.Anything, 3).Return(nil)
+				m.On("CompleteRefund", mock.Anything, 3, false).Return(nil)
+			},
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `{"type":"about:blank","title":"Bad Gateway","status":502,"detail":"payment provider failed to refund","code":"unavailable"}`,
+		},
+		{
+			name:        "Storage error",
+			paymentID:   "7",
+			requestBody: `{"amount": 2500, "note": "event moved"}`,
+			mockSetup: func(m *mocks.RefundIssuer) {
+				m.On("CreateRefund", mock.Anything, override).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to refund payment","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockIssuer := mocks.NewRefundIssuer(t)
+			tc.mockSetup(mockIssuer)
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/payments/{id}/refunds", New(logger, testValidator, mockIssuer, refundProvider{fake, tc.refundErr}))
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/payments/"+tc.paymentID+"/refunds", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+			req = req.WithContext(mwauth.WithUserID(req.Context(), "admin"))
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package createPromoCode
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type PromoCodeRequest struct {
+	Code string `json:"code" validate:"required,alphanum,max=50"`
+	Kind string `json:"kind" validate:"required,oneof=percent fixed"`
+	// Value is a percentage for percent codes and an amount in the minor
+	// units of Currency for fixed ones.
+	Value int64 `json:"value" validate:"required,gt=0"`
+	// Currency is required for fixed codes, which only apply to prices in it.
+	Currency       string     `json:"currency,omitempty" validate:"required_if=Kind fixed,omitempty,iso4217"`
+	MaxUses        int        `json:"max_uses" validate:"gte=0"`
+	MaxUsesPerUser int        `json:"max_uses_per_user" validate:"gte=0"`
+	ValidFrom      *time.Time `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time `json:"valid_until,omitempty"`
+	EventID        int        `json:"event_id,omitempty" validate:"omitempty,gt=0"`
+	TicketTypeID   int        `json:"ticket_type_id,omitempty" validate:"omitempty,gt=0"`
+}
+
+type PromoCodeResponse struct {
+	PromoCodeID int    `json:"promo_code_id"`
+	Code        string `json:"code"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=PromoCodeCreator
+type PromoCodeCreator interface {
+	CreatePromoCode(ctx context.Context, p models.PromoCode) (int, error)
+}
+
+// New creates a promo code, optionally restricted to an event or one of its
+// ticket types. Codes are stored upper-case and matched regardless of case.
+func New(log *slog.Logger, v *validator.Validate, promoCodes PromoCodeCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.promo.createPromoCode.New"
+
+		log = log.With(slog.String("op", op))
+
+		var req PromoCodeRequest
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+		req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		if req.Kind == models.PromoPercent {
+			if req.Value > 100 {
+				log.Error("percentage above 100")
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "value of a percent code must not exceed 100")
+				return
+			}
+			req.Currency = ""
+		}
+
+		if req.ValidFrom != nil && req.ValidUntil != nil && !req.ValidUntil.After(*req.ValidFrom) {
+			log.Error("validity window ends before it starts")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "valid_until must be after valid_from")
+			return
+		}
+
+		p := models.PromoCode{
+			Code:           req.Code,
+			Kind:           req.Kind,
+			Value:          req.Value,
+			Currency:       req.Currency,
+			MaxUses:        req.MaxUses,
+			MaxUsesPerUser: req.MaxUsesPerUser,
+			ValidFrom:      req.ValidFrom,
+			ValidUntil:     req.ValidUntil,
+		}
+		// Ids of 0 leave the code unrestricted.
+		if req.EventID != 0 {
+			p.EventID = &req.EventID
+		}
+		if req.TicketTypeID != 0 {
+			p.TicketTypeID = &req.TicketTypeID
+		}
+
+		id, err := promoCodes.CreatePromoCode(r.Context(), p)
+		if err != nil {
+			log.Error("failed to create promo code", sl.Err(err))
+
+			switch err.Error() {
+			case "event not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+			case "ticket type not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "ticket type not found")
+			case "promo code already exists":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "promo code already exists")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to create promo code")
+			}
+			return
+		}
+
+		log.Info("promo code created", slog.Int("id", id))
+
+		response.OK(w, r, PromoCodeResponse{PromoCodeID: id, Code: req.Code})
+	}
+}
@@ -0,0 +1,153 @@
+package createPromoCode
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/promo/createPromoCode/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestCreatePromoCodeHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	eventID, ticketTypeID := 1, 2
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mocks.PromoCodeCreator)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Percent",
+			requestBody: `{"code": " earlybird ", "kind": "percent", "value": 20, "currency": "EUR", "max_uses": 100, "max_uses_per_user": 1, "event_id": 1}`,
+			mockSetup: func(m *mocks.PromoCodeCreator) {
+				m.On("CreatePromoCode", mock.Anything, models.PromoCode{
+					Code:           "EARLYBIRD",
+					Kind:           models.PromoPercent,
+					Value:          20,
+					MaxUses:        100,
+					MaxUsesPerUser: 1,
+					EventID:        &eventID,
+				}).Return(5, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"promo_code_id":5,"code":"EARLYBIRD"},"meta":{}}`,
+		},
+		{
+			name:        "Fixed",
+			requestBody: `{"code": "PARTNER", "kind": "fixed", "value": 500, "currency": "eur", "ticket_type_id": 2}`,
+			mockSetup: func(m *mocks.PromoCodeCreator) {
+				m.On("CreatePromoCode", mock.Anything, models.PromoCode{
+					Code:         "PARTNER",
+					Kind:         models.PromoFixed,
+					Value:        500,
+					Currency:     "EUR",
+					TicketTypeID: &ticketTypeID,
+				}).Return(6, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"promo_code_id":6,"code":"PARTNER"},"meta":{}}`,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.PromoCodeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "Code with spaces",
+			requestBody:    `{"code": "EARLY BIRD", "kind": "percent", "value": 20}`,
+			mockSetup:      func(m *mocks.PromoCodeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field code must contain only letters and digits","code":"validation_failed","errors":[{"field":"code","tag":"alphanum","message":"field code must contain only letters and digits"}]}`,
+		},
+		{
+			name:           "Fixed without currency",
+			requestBody:    `{"code": "PARTNER", "kind": "fixed", "value": 500}`,
+			mockSetup:      func(m *mocks.PromoCodeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field currency is a required field","code":"validation_failed","errors":[{"field":"currency","tag":"required_if","param":"Kind fixed","message":"field currency is a required field"}]}`,
+		},
+		{
+			name:           "Percent above 100",
+			requestBody:    `{"code": "EARLYBIRD", "kind": "percent", "value": 120}`,
+			mockSetup:      func(m *mocks.PromoCodeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"value of a percent code must not exceed 100","code":"bad_request"}`,
+		},
+		{
+			name:           "Validity window ends before it starts",
+			requestBody:    `{"code": "EARLYBIRD", "kind": "percent", "value": 20, "valid_from": "2099-06-01T00:00:00Z", "valid_until": "2099-05-01T00:00:00Z"}`,
+			mockSetup:      func(m *mocks.PromoCodeCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"valid_until must be after valid_from","code":"bad_request"}`,
+		},
+		{
+			name:        "Ticket type not found",
+			requestBody: `{"code": "PARTNER", "kind": "percent", "value": 10, "ticket_type_id": 2}`,
+			mockSetup: func(m *mocks.PromoCodeCreator) {
+				m.On("CreatePromoCode", mock.Anything, mock.Anything).Return(0, errors.New("ticket type not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"ticket type not found","code":"not_found"}`,
+		},
+		{
+			name:        "Duplicate code",
+			requestBody: `{"code": "PARTNER", "kind": "percent", "value": 10}`,
+			mockSetup: func(m *mocks.PromoCodeCreator) {
+				m.On("CreatePromoCode", mock.Anything, mock.Anything).Return(0, errors.New("promo code already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"promo code already exists","code":"conflict"}`,
+		},
+		{
+			name:        "Storage error",
+			requestBody: `{"code": "PARTNER", "kind": "percent", "value": 10}`,
+			mockSetup: func(m *mocks.PromoCodeCreator) {
+				m.On("CreatePromoCode", mock.Anything, mock.Anything).Return(0, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to create promo code","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCreator := mocks.NewPromoCodeCreator(t)
+			tc.mockSetup(mockCreator)
+
+			handler := New(logger, testValidator, mockCreator)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/promo-codes", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// PromoCodeCreator is an autogenerated mock type for the PromoCodeCreator type
+type PromoCodeCreator struct {
+	mock.Mock
+}
+
+// CreatePromoCode provides a mock function with given fields: ctx, p
+func (_m *PromoCodeCreator) CreatePromoCode(ctx context.Context, p models.PromoCode) (int, error) {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePromoCode")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.PromoCode) (int, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.PromoCode) int); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.PromoCode) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPromoCodeCreator creates a new instance of PromoCodeCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPromoCodeCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PromoCodeCreator {
+	mock := &PromoCodeCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
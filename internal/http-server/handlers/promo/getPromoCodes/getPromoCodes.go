@@ -0,0 +1,33 @@
+package getPromoCodes
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=PromoCodesGetter
+type PromoCodesGetter interface {
+	GetPromoCodes(ctx context.Context) ([]models.PromoCode, error)
+}
+
+// New lists all promo codes, oldest first, with how many times each is in use.
+func New(log *slog.Logger, promoCodes PromoCodesGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.promo.getPromoCodes.New"
+
+		log = log.With(slog.String("op", op))
+
+		codes, err := promoCodes.GetPromoCodes(r.Context())
+		if err != nil {
+			log.Error("failed to get promo codes", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get promo codes")
+			return
+		}
+
+		response.OK(w, r, codes)
+	}
+}
@@ -0,0 +1,76 @@
+package getPromoCodes
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/promo/getPromoCodes/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPromoCodesHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testTime := time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC)
+	eventID := 1
+
+	testCases := []struct {
+		name           string
+		mockSetup      func(m *mocks.PromoCodesGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success",
+			mockSetup: func(m *mocks.PromoCodesGetter) {
+				m.On("GetPromoCodes", mock.Anything).Return([]models.PromoCode{
+					{ID: 1, Code: "EARLYBIRD", Kind: models.PromoPercent, Value: 20, MaxUses: 100, MaxUsesPerUser: 1, ValidUntil: &testTime, EventID: &eventID, Uses: 3, CreatedAt: testTime},
+					{ID: 2, Code: "PARTNER", Kind: models.PromoFixed, Value: 500, Currency: "EUR", CreatedAt: testTime},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[` +
+				`{"id":1,"code":"EARLYBIRD","kind":"percent","value":20,"max_uses":100,"max_uses_per_user":1,"valid_until":"2024-12-25T18:00:00Z","event_id":1,"uses":3,"created_at":"2024-12-25T18:00:00Z"},` +
+				`{"id":2,"code":"PARTNER","kind":"fixed","value":500,"currency":"EUR","max_uses":0,"max_uses_per_user":0,"uses":0,"created_at":"2024-12-25T18:00:00Z"}` +
+				`],"meta":{}}`,
+		},
+		{
+			name: "Storage error",
+			mockSetup: func(m *mocks.PromoCodesGetter) {
+				m.On("GetPromoCodes", mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get promo codes","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewPromoCodesGetter(t)
+			tc.mockSetup(mockGetter)
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/promo-codes", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			New(logger, mockGetter).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// PromoCodesGetter is an autogenerated mock type for the PromoCodesGetter type
+type PromoCodesGetter struct {
+	mock.Mock
+}
+
+// GetPromoCodes provides a mock function with given fields: ctx
+func (_m *PromoCodesGetter) GetPromoCodes(ctx context.Context) ([]models.PromoCode, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromoCodes")
+	}
+
+	var r0 []models.PromoCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.PromoCode, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.PromoCode); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PromoCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPromoCodesGetter creates a new instance of PromoCodesGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPromoCodesGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PromoCodesGetter {
+	mock := &PromoCodesGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+package addSeriesException
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type ExceptionRequest struct {
+	// Date is the start of the occurrence to skip, as the series' rule
+	// gives it, even if the occurrence alone was moved since.
+	Date time.Time `json:"date" validate:"required"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=ExceptionAdder
+type ExceptionAdder interface {
+	AddSeriesException(ctx context.Context, seriesID int, date time.Time) error
+}
+
+// New skips an occurrence of the series. Its event is cancelled like any
+// other: bookings are cancelled, their users notified and paid ones refunded
+// in full.
+func New(log *slog.Logger, v *validator.Validate, series ExceptionAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.series.addSeriesException.New"
+
+		log = log.With(slog.String("op", op))
+
+		seriesIdStr := chi.URLParam(r, "id")
+		if seriesIdStr == "" {
+			log.Error("series id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "series id is required")
+			return
+		}
+
+		seriesID, err := strconv.Atoi(seriesIdStr)
+		if err != nil {
+			log.Error("invalid series id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid series id format")
+			return
+		}
+
+		log = log.With(slog.Int("series_id", seriesID))
+
+		var req ExceptionRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		if err = series.AddSeriesException(r.Context(), seriesID, req.Date); err != nil {
+			log.Error("failed to add series exception", sl.Err(err))
+
+			switch err.Error() {
+			case "series not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "series not found")
+			case "date is not an occurrence of the series":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "date is not an occurrence of the series")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to skip occurrence")
+			}
+			return
+		}
+
+		log.Info("occurrence skipped", slog.Time("date", req.Date))
+
+		response.OK(w, r, nil)
+	}
+}
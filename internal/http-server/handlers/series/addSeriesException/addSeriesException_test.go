@@ -0,0 +1,111 @@
+package addSeriesException
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/series/addSeriesException/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestAddSeriesExceptionHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	date := time.Date(2030, time.January, 8, 19, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		seriesID       string
+		requestBody    string
+		mockSetup      func(m *mocks.ExceptionAdder)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			seriesID:    "3",
+			requestBody: `{"date": "2030-01-08T20:00:00+01:00"}`,
+			mockSetup: func(m *mocks.ExceptionAdder) {
+				m.On("AddSeriesException", mock.Anything, 3, mock.MatchedBy(date.Equal)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":null,"meta":{}}`,
+		},
+		{
+			name:           "Missing date",
+			seriesID:       "3",
+			requestBody:    `{}`,
+			mockSetup:      func(m *mocks.ExceptionAdder) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field date is a required field","code":"validation_failed","errors":[{"field":"date","tag":"required","message":"field date is a required field"}]}`,
+		},
+		{
+			name:        "Series not found",
+			seriesID:    "3",
+			requestBody: `{"date": "2030-01-08T19:00:00Z"}`,
+			mockSetup: func(m *mocks.ExceptionAdder) {
+				m.On("AddSeriesException", mock.Anything, 3, mock.Anything).Return(errors.New("series not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"series not found","code":"not_found"}`,
+		},
+		{
+			name:        "Not an occurrence",
+			seriesID:    "3",
+			requestBody: `{"date": "2030-01-09T19:00:00Z"}`,
+			mockSetup: func(m *mocks.ExceptionAdder) {
+				m.On("AddSeriesException", mock.Anything, 3, mock.Anything).Return(errors.New("date is not an occurrence of the series"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"date is not an occurrence of the series","code":"bad_request"}`,
+		},
+		{
+			name:        "Storage error",
+			seriesID:    "3",
+			requestBody: `{"date": "2030-01-08T19:00:00Z"}`,
+			mockSetup: func(m *mocks.ExceptionAdder) {
+				m.On("AddSeriesException", mock.Anything, 3, mock.Anything).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to skip occurrence","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockAdder := mocks.NewExceptionAdder(t)
+			tc.mockSetup(mockAdder)
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/series/{id}/exceptions", New(logger, testValidator, mockAdder))
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/series/"+tc.seriesID+"/exceptions", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExceptionAdder is an autogenerated mock type for the ExceptionAdder type
+type ExceptionAdder struct {
+	mock.Mock
+}
+
+// AddSeriesException provides a mock function with given fields: ctx, seriesID, date
+func (_m *ExceptionAdder) AddSeriesException(ctx context.Context, seriesID int, date time.Time) error {
+	ret := _m.Called(ctx, seriesID, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSeriesException")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, seriesID, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExceptionAdder creates a new instance of ExceptionAdder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExceptionAdder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExceptionAdder {
+	mock := &ExceptionAdder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,100 @@
+package bookSeries
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type BookingRequest struct {
+	UserId string `json:"user_id" validate:"required"`
+}
+
+type BookingResponse struct {
+	// EventIDs are the occurrences booked, in order of date. Each booking
+	// is confirmed on its own.
+	EventIDs []int `json:"event_ids"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=SeriesBooker
+type SeriesBooker interface {
+	BookSeries(ctx context.Context, seriesID int, userID string) ([]int, error)
+}
+
+// New books every upcoming occurrence of the series, or none if any of them
+// cannot be booked.
+func New(log *slog.Logger, v *validator.Validate, booking SeriesBooker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.series.bookSeries.New"
+
+		log = log.With(slog.String("op", op))
+
+		seriesIdStr := chi.URLParam(r, "id")
+		if seriesIdStr == "" {
+			log.Error("series id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "series id is required")
+			return
+		}
+
+		seriesID, err := strconv.Atoi(seriesIdStr)
+		if err != nil {
+			log.Error("invalid series id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid series id format")
+			return
+		}
+
+		log = log.With(slog.Int("series_id", seriesID))
+
+		var req BookingRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		eventIDs, err := booking.BookSeries(r.Context(), seriesID, req.UserId)
+		if err != nil {
+			log.Error("failed to book series", sl.Err(err))
+
+			switch err.Error() {
+			case "series not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "series not found")
+			case "series has no upcoming occurrences":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "series has no upcoming occurrences")
+			case "no available seats":
+				response.Error(w, r, http.StatusConflict, response.CodeNoAvailableSeats, "an occurrence of the series has no available seats")
+			case "user already has pending booking for this event":
+				response.Error(w, r, http.StatusConflict, response.CodeDuplicateBooking, "user already has pending booking for an occurrence of the series")
+			case "series must be booked by occurrence", "ticket type is required", "seat is required":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "an occurrence of the series has ticket types or reserved seating, book occurrences one by one")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to book series")
+			}
+			return
+		}
+
+		log.Info("series booked", slog.String("user_id", req.UserId), slog.Int("occurrences", len(eventIDs)))
+
+		response.OK(w, r, BookingResponse{EventIDs: eventIDs})
+	}
+}
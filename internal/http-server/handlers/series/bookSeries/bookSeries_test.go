@@ -0,0 +1,146 @@
+package bookSeries
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/series/bookSeries/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestBookSeriesHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		seriesID       string
+		requestBody    string
+		mockSetup      func(m *mocks.SeriesBooker)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			seriesID:    "3",
+			requestBody: `{"user_id": "u1"}`,
+			mockSetup: func(m *mocks.SeriesBooker) {
+				m.On("BookSeries", mock.Anything, 3, "u1").Return([]int{7, 8, 9}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_ids":[7,8,9]},"meta":{}}`,
+		},
+		{
+			name:           "Missing user ID",
+			seriesID:       "3",
+			requestBody:    `{}`,
+			mockSetup:      func(m *mocks.SeriesBooker) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field user_id is a required field","code":"validation_failed","errors":[{"field":"user_id","tag":"required","message":"field user_id is a required field"}]}`,
+		},
+		{
+			name:           "Invalid series ID format",
+			seriesID:       "abc",
+			requestBody:    `{"user_id": "u1"}`,
+			mockSetup:      func(m *mocks.SeriesBooker) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid series id format","code":"bad_request"}`,
+		},
+		{
+			name:        "Series not found",
+			seriesID:    "3",
+			requestBody: `{"user_id": "u1"}`,
+			mockSetup: func(m *mocks.SeriesBooker) {
+				m.On("BookSeries", mock.Anything, 3, "u1").Return(nil, errors.New("series not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"series not found","code":"not_found"}`,
+		},
+		{
+			name:        "No upcoming occurrences",
+			seriesID:    "3",
+			requestBody: `{"user_id": "u1"}`,
+			mockSetup: func(m *mocks.SeriesBooker) {
+				m.On("BookSeries", mock.Anything, 3, "u1").Return(nil, errors.New("series has no upcoming occurrences"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"series has no upcoming occurrences","code":"conflict"}`,
+		},
+		{
+			name:        "Occurrence full",
+			seriesID:    "3",
+			requestBody: `{"user_id": "u1"}`,
+			mockSetup: func(m *mocks.SeriesBooker) {
+				m.On("BookSeries", mock.Anything, 3, "u1").Return(nil, errors.New("no available seats"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"an occurrence of the series has no available seats","code":"no_available_seats"}`,
+		},
+		{
+			name:        "Occurrence already booked",
+			seriesID:    "3",
+			requestBody: `{"user_id": "u1"}`,
+			mockSetup: func(m *mocks.SeriesBooker) {
+				m.On("BookSeries", mock.Anything, 3, "u1").Return(nil, errors.New("user already has pending booking for this event"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"user already has pending booking for an occurrence of the series","code":"duplicate_booking"}`,
+		},
+		{
+			name:        "Occurrence with reserved seating",
+			seriesID:    "3",
+			requestBody: `{"user_id": "u1"}`,
+			mockSetup: func(m *mocks.SeriesBooker) {
+				m.On("BookSeries", mock.Anything, 3, "u1").Return(nil, errors.New("series must be booked by occurrence"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"an occurrence of the series has ticket types or reserved seating, book occurrences one by one","code":"conflict"}`,
+		},
+		{
+			name:        "Storage error",
+			seriesID:    "3",
+			requestBody: `{"user_id": "u1"}`,
+			mockSetup: func(m *mocks.SeriesBooker) {
+				m.On("BookSeries", mock.Anything, 3, "u1").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to book series","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockBooker := mocks.NewSeriesBooker(t)
+			tc.mockSetup(mockBooker)
+
+			r := chi.NewRouter()
+			r.Post("/api/v1/series/{id}/book", New(logger, testValidator, mockBooker))
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/series/"+tc.seriesID+"/book", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
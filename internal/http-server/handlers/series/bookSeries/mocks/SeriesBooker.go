@@ -0,0 +1,58 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SeriesBooker is an autogenerated mock type for the SeriesBooker type
+type SeriesBooker struct {
+	mock.Mock
+}
+
+// BookSeries provides a mock function with given fields: ctx, seriesID, userID
+func (_m *SeriesBooker) BookSeries(ctx context.Context, seriesID int, userID string) ([]int, error) {
+	ret := _m.Called(ctx, seriesID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BookSeries")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]int, error)); ok {
+		return rf(ctx, seriesID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []int); ok {
+		r0 = rf(ctx, seriesID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, seriesID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSeriesBooker creates a new instance of SeriesBooker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSeriesBooker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SeriesBooker {
+	mock := &SeriesBooker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,112 @@
+package createSeries
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SeriesRequest describes a new series. Start is its first occurrence and
+// Rule an RFC 5545 RRULE, e.g. "FREQ=WEEKLY;BYDAY=TU;COUNT=10". A series at
+// a venue may leave out total_seats and timezone like an event.
+type SeriesRequest struct {
+	Title      string    `json:"title" validate:"required,min=3,max=200"`
+	Start      time.Time `json:"start" validate:"required,future"`
+	Rule       string    `json:"rrule" validate:"required,rrule"`
+	TotalSeats int       `json:"total_seats" validate:"required_without=VenueID,omitempty,seats"`
+	Deadline   int       `json:"deadline" validate:"required,gt=0,ltuntil=Start"`
+	VenueID    int       `json:"venue_id,omitempty" validate:"omitempty,gt=0"`
+	Timezone   string    `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	// Exceptions are the starts of occurrences to skip.
+	Exceptions []time.Time `json:"exceptions,omitempty"`
+}
+
+// Series returns the series the request describes.
+func (req SeriesRequest) Series() models.Series {
+	s := models.Series{
+		Title:      req.Title,
+		Start:      req.Start,
+		Rule:       req.Rule,
+		TotalSeats: req.TotalSeats,
+		Deadline:   req.Deadline,
+		Timezone:   req.Timezone,
+		Exceptions: req.Exceptions,
+	}
+	if req.VenueID != 0 {
+		s.VenueID = &req.VenueID
+	}
+
+	return s
+}
+
+type SeriesResponse struct {
+	SeriesID int `json:"series_id"`
+	// EventIDs are the occurrences created up to the generation horizon.
+	EventIDs []int `json:"event_ids"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=SeriesCreator
+type SeriesCreator interface {
+	CreateSeries(ctx context.Context, s models.Series, horizon time.Time) (int, []int, error)
+}
+
+// New creates a series and its occurrences up to horizon from now. Later
+// ones are created as time goes by.
+func New(log *slog.Logger, v *validator.Validate, series SeriesCreator, horizon time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.series.createSeries.New"
+
+		log = log.With(slog.String("op", op))
+
+		var req SeriesRequest
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		req.Title = strings.TrimSpace(req.Title)
+		req.Rule = strings.TrimSpace(req.Rule)
+		req.Timezone = strings.TrimSpace(req.Timezone)
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		seriesID, eventIDs, err := series.CreateSeries(r.Context(), req.Series(), time.Now().Add(horizon))
+		if err != nil {
+			log.Error("failed to create series", sl.Err(err))
+
+			switch err.Error() {
+			case "venue not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "venue not found")
+			case "event exceeds venue capacity":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "total_seats exceeds the capacity of the venue")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to create series")
+			}
+			return
+		}
+
+		log.Info("series created", slog.Int("id", seriesID), slog.Int("occurrences", len(eventIDs)))
+
+		response.OK(w, r, SeriesResponse{SeriesID: seriesID, EventIDs: eventIDs})
+	}
+}
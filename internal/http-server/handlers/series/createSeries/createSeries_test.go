@@ -0,0 +1,139 @@
+package createSeries
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/series/createSeries/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestCreateSeriesHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	startStr := start.Format(time.RFC3339)
+	horizon := 30 * 24 * time.Hour
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mocks.SeriesCreator)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			requestBody: `{"title": " Workshop ", "start": "` + startStr + `", "rrule": "FREQ=WEEKLY;COUNT=3", "total_seats": 20, "deadline": 30, "exceptions": ["` + startStr + `"]}`,
+			mockSetup: func(m *mocks.SeriesCreator) {
+				m.On("CreateSeries", mock.Anything, models.Series{
+					Title:      "Workshop",
+					Start:      start,
+					Rule:       "FREQ=WEEKLY;COUNT=3",
+					TotalSeats: 20,
+					Deadline:   30,
+					Exceptions: []time.Time{start},
+				}, mock.MatchedBy(func(h time.Time) bool {
+					return h.After(time.Now().Add(horizon - time.Minute))
+				})).Return(3, []int{7, 8}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"series_id":3,"event_ids":[7,8]},"meta":{}}`,
+		},
+		{
+			name:        "At venue",
+			requestBody: `{"title": "Workshop", "start": "` + startStr + `", "rrule": "FREQ=DAILY", "deadline": 30, "venue_id": 2, "timezone": "Europe/Berlin"}`,
+			mockSetup: func(m *mocks.SeriesCreator) {
+				venueID := 2
+				m.On("CreateSeries", mock.Anything, models.Series{
+					Title:    "Workshop",
+					Start:    start,
+					Rule:     "FREQ=DAILY",
+					Deadline: 30,
+					VenueID:  &venueID,
+					Timezone: "Europe/Berlin",
+				}, mock.Anything).Return(1, []int{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"series_id":1,"event_ids":[]},"meta":{}}`,
+		},
+		{
+			name:           "Unsupported rule",
+			requestBody:    `{"title": "Workshop", "start": "` + startStr + `", "rrule": "FREQ=HOURLY", "total_seats": 20, "deadline": 30}`,
+			mockSetup:      func(m *mocks.SeriesCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field rrule is not a supported RFC 5545 recurrence rule","code":"validation_failed","errors":[{"field":"rrule","tag":"rrule","message":"field rrule is not a supported RFC 5545 recurrence rule"}]}`,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"title": "Workshop",`,
+			mockSetup:      func(m *mocks.SeriesCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:        "Venue not found",
+			requestBody: `{"title": "Workshop", "start": "` + startStr + `", "rrule": "FREQ=DAILY", "deadline": 30, "venue_id": 9}`,
+			mockSetup: func(m *mocks.SeriesCreator) {
+				m.On("CreateSeries", mock.Anything, mock.Anything, mock.Anything).Return(0, nil, errors.New("venue not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"venue not found","code":"not_found"}`,
+		},
+		{
+			name:        "Exceeds venue capacity",
+			requestBody: `{"title": "Workshop", "start": "` + startStr + `", "rrule": "FREQ=DAILY", "total_seats": 500, "deadline": 30, "venue_id": 2}`,
+			mockSetup: func(m *mocks.SeriesCreator) {
+				m.On("CreateSeries", mock.Anything, mock.Anything, mock.Anything).Return(0, nil, errors.New("event exceeds venue capacity"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"total_seats exceeds the capacity of the venue","code":"bad_request"}`,
+		},
+		{
+			name:        "Storage error",
+			requestBody: `{"title": "Workshop", "start": "` + startStr + `", "rrule": "FREQ=DAILY", "total_seats": 20, "deadline": 30}`,
+			mockSetup: func(m *mocks.SeriesCreator) {
+				m.On("CreateSeries", mock.Anything, mock.Anything, mock.Anything).Return(0, nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to create series","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCreator := mocks.NewSeriesCreator(t)
+			tc.mockSetup(mockCreator)
+
+			handler := New(logger, testValidator, mockCreator, horizon)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/series", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
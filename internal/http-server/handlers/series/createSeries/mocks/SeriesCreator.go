@@ -0,0 +1,69 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+
+	time "time"
+)
+
+// SeriesCreator is an autogenerated mock type for the SeriesCreator type
+type SeriesCreator struct {
+	mock.Mock
+}
+
+// CreateSeries provides a mock function with given fields: ctx, s, horizon
+func (_m *SeriesCreator) CreateSeries(ctx context.Context, s models.Series, horizon time.Time) (int, []int, error) {
+	ret := _m.Called(ctx, s, horizon)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeries")
+	}
+
+	var r0 int
+	var r1 []int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Series, time.Time) (int, []int, error)); ok {
+		return rf(ctx, s, horizon)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Series, time.Time) int); ok {
+		r0 = rf(ctx, s, horizon)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Series, time.Time) []int); ok {
+		r1 = rf(ctx, s, horizon)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.Series, time.Time) error); ok {
+		r2 = rf(ctx, s, horizon)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewSeriesCreator creates a new instance of SeriesCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSeriesCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SeriesCreator {
+	mock := &SeriesCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
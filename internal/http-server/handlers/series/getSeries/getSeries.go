@@ -0,0 +1,63 @@
+package getSeries
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type SeriesResponse struct {
+	Series *models.Series `json:"series"`
+	// Events are the occurrences created so far, ordered by date.
+	Events []models.Event `json:"events"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=SeriesGetter
+type SeriesGetter interface {
+	GetSeries(ctx context.Context, id int) (*models.Series, []models.Event, error)
+}
+
+// New returns the series with its occurrences.
+func New(log *slog.Logger, series SeriesGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.series.getSeries.New"
+
+		log = log.With(slog.String("op", op))
+
+		seriesIdStr := chi.URLParam(r, "id")
+		if seriesIdStr == "" {
+			log.Error("series id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "series id is required")
+			return
+		}
+
+		seriesID, err := strconv.Atoi(seriesIdStr)
+		if err != nil {
+			log.Error("invalid series id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid series id format")
+			return
+		}
+
+		log = log.With(slog.Int("series_id", seriesID))
+
+		s, events, err := series.GetSeries(r.Context(), seriesID)
+		if err != nil {
+			log.Error("failed to get series", sl.Err(err))
+
+			if err.Error() == "series not found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "series not found")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get series")
+			return
+		}
+
+		response.OK(w, r, SeriesResponse{Series: s, Events: events})
+	}
+}
@@ -0,0 +1,109 @@
+package getSeries
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/series/getSeries/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSeriesHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	start := time.Date(2030, time.January, 1, 19, 0, 0, 0, time.UTC)
+	seriesID := 3
+
+	testCases := []struct {
+		name           string
+		seriesID       string
+		mockSetup      func(m *mocks.SeriesGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:     "Success",
+			seriesID: "3",
+			mockSetup: func(m *mocks.SeriesGetter) {
+				m.On("GetSeries", mock.Anything, 3).Return(&models.Series{
+					ID:             3,
+					Title:          "Workshop",
+					Start:          start,
+					Rule:           "FREQ=WEEKLY;COUNT=2",
+					TotalSeats:     20,
+					Deadline:       30,
+					Timezone:       "UTC",
+					Exceptions:     []time.Time{start.AddDate(0, 0, 7)},
+					GeneratedUntil: start.AddDate(0, 3, 0),
+					CreatedAt:      start.AddDate(0, -1, 0),
+				}, []models.Event{
+					{ID: 7, Title: "Workshop", Date: start, TotalSeats: 20, BookedSeats: 2, Deadline: 30, SeriesID: &seriesID},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"series":{"id":3,"title":"Workshop","start":"2030-01-01T19:00:00Z","rrule":"FREQ=WEEKLY;COUNT=2",` +
+				`"total_seats":20,"deadline_minutes":30,"timezone":"UTC","exceptions":["2030-01-08T19:00:00Z"],` +
+				`"generated_until":"2030-04-01T19:00:00Z","created_at":"2029-12-01T19:00:00Z"},` +
+				`"events":[{"id":7,"title":"Workshop","date":"2030-01-01T19:00:00Z","total_seats":20,"booked_seats":2,"deadline_minutes":30,"series_id":3}]},"meta":{}}`,
+		},
+		{
+			name:           "Invalid series ID format",
+			seriesID:       "abc",
+			mockSetup:      func(m *mocks.SeriesGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid series id format","code":"bad_request"}`,
+		},
+		{
+			name:     "Series not found",
+			seriesID: "3",
+			mockSetup: func(m *mocks.SeriesGetter) {
+				m.On("GetSeries", mock.Anything, 3).Return(nil, nil, errors.New("series not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"series not found","code":"not_found"}`,
+		},
+		{
+			name:     "Storage error",
+			seriesID: "3",
+			mockSetup: func(m *mocks.SeriesGetter) {
+				m.On("GetSeries", mock.Anything, 3).Return(nil, nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get series","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewSeriesGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/series/{id}", New(logger, mockGetter))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/series/"+tc.seriesID, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// SeriesGetter is an autogenerated mock type for the SeriesGetter type
+type SeriesGetter struct {
+	mock.Mock
+}
+
+// GetSeries provides a mock function with given fields: ctx, id
+func (_m *SeriesGetter) GetSeries(ctx context.Context, id int) (*models.Series, []models.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeries")
+	}
+
+	var r0 *models.Series
+	var r1 []models.Event
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Series, []models.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Series); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Series)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) []models.Event); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewSeriesGetter creates a new instance of SeriesGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSeriesGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SeriesGetter {
+	mock := &SeriesGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
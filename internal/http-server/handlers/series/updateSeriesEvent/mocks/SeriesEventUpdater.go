@@ -0,0 +1,57 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SeriesEventUpdater is an autogenerated mock type for the SeriesEventUpdater type
+type SeriesEventUpdater struct {
+	mock.Mock
+}
+
+// UpdateSeriesEvent provides a mock function with given fields: ctx, seriesID, eventID, scope, change
+func (_m *SeriesEventUpdater) UpdateSeriesEvent(ctx context.Context, seriesID int, eventID int, scope string, change models.Event) (int, error) {
+	ret := _m.Called(ctx, seriesID, eventID, scope, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSeriesEvent")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, models.Event) (int, error)); ok {
+		return rf(ctx, seriesID, eventID, scope, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, models.Event) int); ok {
+		r0 = rf(ctx, seriesID, eventID, scope, change)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, models.Event) error); ok {
+		r1 = rf(ctx, seriesID, eventID, scope, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSeriesEventUpdater creates a new instance of SeriesEventUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSeriesEventUpdater(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SeriesEventUpdater {
+	mock := &SeriesEventUpdater{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,130 @@
+package updateSeriesEvent
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UpdateRequest edits an occurrence. Scope "this" edits the occurrence
+// alone; "future" edits it and all later ones, which then keep date's
+// time of day, so date must stay on the occurrence's day.
+type UpdateRequest struct {
+	Title      string    `json:"title" validate:"required,min=3,max=200"`
+	Date       time.Time `json:"date" validate:"required,future"`
+	TotalSeats int       `json:"total_seats" validate:"required,seats"`
+	Deadline   int       `json:"deadline" validate:"required,gt=0,ltuntil=Date"`
+	Scope      string    `json:"scope" validate:"required,oneof=this future"`
+}
+
+type UpdateResponse struct {
+	// SeriesID is the series the occurrence belongs to afterwards. Editing
+	// all future occurrences from other than the first splits them off into
+	// a new series.
+	SeriesID int `json:"series_id"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=SeriesEventUpdater
+type SeriesEventUpdater interface {
+	UpdateSeriesEvent(ctx context.Context, seriesID, eventID int, scope string, change models.Event) (int, error)
+}
+
+func New(log *slog.Logger, v *validator.Validate, series SeriesEventUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.series.updateSeriesEvent.New"
+
+		log = log.With(slog.String("op", op))
+
+		seriesIdStr := chi.URLParam(r, "id")
+		if seriesIdStr == "" {
+			log.Error("series id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "series id is required")
+			return
+		}
+
+		seriesID, err := strconv.Atoi(seriesIdStr)
+		if err != nil {
+			log.Error("invalid series id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid series id format")
+			return
+		}
+
+		eventIdStr := chi.URLParam(r, "event_id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("series_id", seriesID), slog.Int("event_id", eventID))
+
+		var req UpdateRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		req.Title = strings.TrimSpace(req.Title)
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		change := models.Event{
+			Title:      req.Title,
+			Date:       req.Date,
+			TotalSeats: req.TotalSeats,
+			Deadline:   req.Deadline,
+		}
+
+		newSeriesID, err := series.UpdateSeriesEvent(r.Context(), seriesID, eventID, req.Scope, change)
+		if err != nil {
+			log.Error("failed to update occurrence", sl.Err(err))
+
+			switch err.Error() {
+			case "series not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "series not found")
+			case "occurrence not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event is not an occurrence of the series")
+			case "occurrence moved to another day":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "date must stay on the occurrence's day when editing all future occurrences")
+			case "event exceeds venue capacity":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "total_seats exceeds the capacity of the venue")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to update occurrence")
+			}
+			return
+		}
+
+		log.Info("occurrence updated", slog.String("scope", req.Scope), slog.Int("new_series_id", newSeriesID))
+
+		response.OK(w, r, UpdateResponse{SeriesID: newSeriesID})
+	}
+}
@@ -0,0 +1,135 @@
+package updateSeriesEvent
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/series/updateSeriesEvent/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestUpdateSeriesEventHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	date := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	dateStr := date.Format(time.RFC3339)
+	body := func(scope string) string {
+		return `{"title": "Workshop", "date": "` + dateStr + `", "total_seats": 20, "deadline": 30, "scope": "` + scope + `"}`
+	}
+	change := models.Event{Title: "Workshop", Date: date, TotalSeats: 20, Deadline: 30}
+
+	testCases := []struct {
+		name           string
+		path           string
+		requestBody    string
+		mockSetup      func(m *mocks.SeriesEventUpdater)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "This occurrence",
+			path:        "/3/events/7",
+			requestBody: body("this"),
+			mockSetup: func(m *mocks.SeriesEventUpdater) {
+				m.On("UpdateSeriesEvent", mock.Anything, 3, 7, models.ScopeThis, change).Return(3, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"series_id":3},"meta":{}}`,
+		},
+		{
+			name:        "Future occurrences split the series",
+			path:        "/3/events/7",
+			requestBody: body("future"),
+			mockSetup: func(m *mocks.SeriesEventUpdater) {
+				m.On("UpdateSeriesEvent", mock.Anything, 3, 7, models.ScopeFuture, change).Return(4, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"series_id":4},"meta":{}}`,
+		},
+		{
+			name:           "Invalid event ID format",
+			path:           "/3/events/abc",
+			requestBody:    body("this"),
+			mockSetup:      func(m *mocks.SeriesEventUpdater) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid event id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Invalid scope",
+			path:           "/3/events/7",
+			requestBody:    body("all"),
+			mockSetup:      func(m *mocks.SeriesEventUpdater) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field scope must be one of: this, future","code":"validation_failed","errors":[{"field":"scope","tag":"oneof","param":"this future","message":"field scope must be one of: this, future"}]}`,
+		},
+		{
+			name:        "Not an occurrence",
+			path:        "/3/events/7",
+			requestBody: body("this"),
+			mockSetup: func(m *mocks.SeriesEventUpdater) {
+				m.On("UpdateSeriesEvent", mock.Anything, 3, 7, models.ScopeThis, change).Return(0, errors.New("occurrence not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"event is not an occurrence of the series","code":"not_found"}`,
+		},
+		{
+			name:        "Moved to another day",
+			path:        "/3/events/7",
+			requestBody: body("future"),
+			mockSetup: func(m *mocks.SeriesEventUpdater) {
+				m.On("UpdateSeriesEvent", mock.Anything, 3, 7, models.ScopeFuture, change).Return(0, errors.New("occurrence moved to another day"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"date must stay on the occurrence's day when editing all future occurrences","code":"bad_request"}`,
+		},
+		{
+			name:        "Storage error",
+			path:        "/3/events/7",
+			requestBody: body("this"),
+			mockSetup: func(m *mocks.SeriesEventUpdater) {
+				m.On("UpdateSeriesEvent", mock.Anything, 3, 7, models.ScopeThis, change).Return(0, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to update occurrence","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUpdater := mocks.NewSeriesEventUpdater(t)
+			tc.mockSetup(mockUpdater)
+
+			r := chi.NewRouter()
+			r.Put("/api/v1/series/{id}/events/{event_id}", New(logger, testValidator, mockUpdater))
+
+			req, err := http.NewRequest(http.MethodPut, "/api/v1/series"+tc.path, bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package checkIn
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type CheckInRequest struct {
+	Ticket string `json:"ticket" validate:"required"`
+	// EventID, when set, rejects tickets for other events, e.g. at the door of a parallel event.
+	EventID int `json:"event_id,omitempty"`
+}
+
+type CheckInResponse struct {
+	Booking *models.Booking `json:"booking"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=CheckInRecorder
+type CheckInRecorder interface {
+	CheckIn(ctx context.Context, bookingID, eventID int, userID string) (*models.Booking, error)
+}
+
+// New checks in the holder of a ticket. The signature is verified before
+// the database is touched, and each ticket is accepted once.
+func New(log *slog.Logger, v *validator.Validate, recorder CheckInRecorder, tickets *ticket.Signer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.ticket.checkIn.New"
+
+		log = log.With(slog.String("op", op))
+
+		var req CheckInRequest
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			if errors.As(err, &validateErr) {
+				log.Error("invalid request", sl.Err(err))
+				response.ValidationError(w, r, validateErr)
+				return
+			}
+		}
+
+		t, err := tickets.Verify(req.Ticket)
+		if err != nil {
+			log.Warn("invalid ticket", sl.Err(err))
+			response.Error(w, r, http.StatusUnprocessableEntity, response.CodeInvalidTicket, "invalid ticket")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", t.EventID), slog.Int("booking_id", t.BookingID))
+
+		if req.EventID != 0 && req.EventID != t.EventID {
+			log.Warn("ticket is for another event", slog.Int("expected_event_id", req.EventID))
+			response.Error(w, r, http.StatusUnprocessableEntity, response.CodeInvalidTicket, "ticket is for another event")
+			return
+		}
+
+		booking, err := recorder.CheckIn(r.Context(), t.BookingID, t.EventID, t.UserID)
+		if err != nil {
+			log.Error("failed to check in", sl.Err(err))
+
+			switch err.Error() {
+			case "ticket already used":
+				response.Error(w, r, http.StatusConflict, response.CodeTicketUsed, "ticket already used")
+				return
+			case "no confirmed booking found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "booking was cancelled")
+				return
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to check in")
+				return
+			}
+		}
+
+		log.Info("checked in", slog.String("user_id", t.UserID))
+
+		response.OK(w, r, CheckInResponse{Booking: booking})
+	}
+}
@@ -0,0 +1,143 @@
+package checkIn
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/ticket/checkIn/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+var testSigner, _ = ticket.ParseKey(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+
+func TestCheckInHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	validTicket := testSigner.Sign(ticket.Ticket{BookingID: 7, EventID: 1, UserID: "user123"})
+
+	otherSigner, err := ticket.ParseKey(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", 32))))
+	require.NoError(t, err)
+	forgedTicket := otherSigner.Sign(ticket.Ticket{BookingID: 7, EventID: 1, UserID: "user123"})
+
+	checkedInAt := time.Date(2099, 6, 1, 18, 5, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mocks.CheckInRecorder)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			requestBody: `{"ticket":"` + validTicket + `","event_id":1}`,
+			mockSetup: func(m *mocks.CheckInRecorder) {
+				m.On("CheckIn", mock.Anything, 7, 1, "user123").Return(&models.Booking{
+					ID:          7,
+					EventID:     1,
+					UserID:      "user123",
+					CreatedAt:   time.Date(2099, 6, 1, 10, 0, 0, 0, time.UTC),
+					Confirmed:   true,
+					CheckedInAt: &checkedInAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"booking":{"id":7,"event_id":1,"user_id":"user123","created_at":"2099-06-01T10:00:00Z",
+				"confirmed":true,"checked_in_at":"2099-06-01T18:05:00Z"}},"meta":{}}`,
+		},
+		{
+			name:        "Replayed ticket",
+			requestBody: `{"ticket":"` + validTicket + `"}`,
+			mockSetup: func(m *mocks.CheckInRecorder) {
+				m.On("CheckIn", mock.Anything, 7, 1, "user123").Return(nil, errors.New("ticket already used"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"ticket already used","code":"ticket_used"}`,
+		},
+		{
+			name:        "Cancelled booking",
+			requestBody: `{"ticket":"` + validTicket + `"}`,
+			mockSetup: func(m *mocks.CheckInRecorder) {
+				m.On("CheckIn", mock.Anything, 7, 1, "user123").Return(nil, errors.New("no confirmed booking found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"booking was cancelled","code":"not_found"}`,
+		},
+		{
+			name:           "Forged ticket",
+			requestBody:    `{"ticket":"` + forgedTicket + `"}`,
+			mockSetup:      func(m *mocks.CheckInRecorder) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"invalid ticket","code":"invalid_ticket"}`,
+		},
+		{
+			name:           "Ticket for another event",
+			requestBody:    `{"ticket":"` + validTicket + `","event_id":2}`,
+			mockSetup:      func(m *mocks.CheckInRecorder) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"ticket is for another event","code":"invalid_ticket"}`,
+		},
+		{
+			name:           "Missing ticket",
+			requestBody:    `{}`,
+			mockSetup:      func(m *mocks.CheckInRecorder) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field ticket is a required field","code":"validation_failed",
+				"errors":[{"field":"ticket","tag":"required","message":"field ticket is a required field"}]}`,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"ticket":`,
+			mockSetup:      func(m *mocks.CheckInRecorder) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:        "Storage error",
+			requestBody: `{"ticket":"` + validTicket + `"}`,
+			mockSetup: func(m *mocks.CheckInRecorder) {
+				m.On("CheckIn", mock.Anything, 7, 1, "user123").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to check in","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRecorder := mocks.NewCheckInRecorder(t)
+			tc.mockSetup(mockRecorder)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/checkin", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			New(logger, testValidator, mockRecorder, testSigner).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+		})
+	}
+}
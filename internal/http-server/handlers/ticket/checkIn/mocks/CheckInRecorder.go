@@ -0,0 +1,59 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CheckInRecorder is an autogenerated mock type for the CheckInRecorder type
+type CheckInRecorder struct {
+	mock.Mock
+}
+
+// CheckIn provides a mock function with given fields: ctx, bookingID, eventID, userID
+func (_m *CheckInRecorder) CheckIn(ctx context.Context, bookingID int, eventID int, userID string) (*models.Booking, error) {
+	ret := _m.Called(ctx, bookingID, eventID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckIn")
+	}
+
+	var r0 *models.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) (*models.Booking, error)); ok {
+		return rf(ctx, bookingID, eventID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) *models.Booking); ok {
+		r0 = rf(ctx, bookingID, eventID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string) error); ok {
+		r1 = rf(ctx, bookingID, eventID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCheckInRecorder creates a new instance of CheckInRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCheckInRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CheckInRecorder {
+	mock := &CheckInRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
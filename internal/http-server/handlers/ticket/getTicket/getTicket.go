@@ -0,0 +1,130 @@
+package getTicket
+
+import (
+	"context"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	formatJSON = "json"
+	formatPNG  = "png"
+
+	// qrSize is the width and height of the QR code image in pixels.
+	qrSize = 320
+)
+
+type TicketResponse struct {
+	Ticket      string     `json:"ticket"`
+	BookingID   int        `json:"booking_id"`
+	EventID     int        `json:"event_id"`
+	UserID      string     `json:"user_id"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=BookingGetter
+type BookingGetter interface {
+	GetConfirmedBooking(ctx context.Context, eventID int, userID string) (*models.Booking, error)
+}
+
+// New returns the ticket of the confirmed booking of the user authenticated
+// by the API key, as JSON or, for /ticket.png or format=png, as a QR code to
+// show at the door.
+func New(log *slog.Logger, bookings BookingGetter, tickets *ticket.Signer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.ticket.getTicket.New"
+
+		log = log.With(slog.String("op", op))
+
+		eventIdStr := chi.URLParam(r, "id")
+		if eventIdStr == "" {
+			log.Error("event id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "event id is required")
+			return
+		}
+
+		eventID, err := strconv.Atoi(eventIdStr)
+		if err != nil {
+			log.Error("invalid event id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid event id format")
+			return
+		}
+
+		log = log.With(slog.Int("event_id", eventID))
+
+		userID := mwauth.UserID(r.Context())
+		if userID == "" {
+			log.Error("anonymous request for a ticket")
+			response.Error(w, r, http.StatusUnauthorized, response.CodeUnauthorized, "api key required")
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format, _ = r.Context().Value(middleware.URLFormatCtxKey).(string)
+		}
+		if format == "" {
+			format = formatJSON
+		}
+		if format != formatJSON && format != formatPNG {
+			log.Error("unknown ticket format", slog.String("format", format))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "format must be one of: json, png")
+			return
+		}
+
+		booking, err := bookings.GetConfirmedBooking(r.Context(), eventID, userID)
+		if err != nil {
+			log.Error("failed to get booking", sl.Err(err))
+
+			if err.Error() == "no confirmed booking found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "no confirmed booking found for this user")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get ticket")
+			return
+		}
+
+		token := tickets.Sign(ticket.Ticket{
+			BookingID: booking.ID,
+			EventID:   booking.EventID,
+			UserID:    booking.UserID,
+		})
+
+		// Anyone holding the ticket can check in with it.
+		w.Header().Set("Cache-Control", "private, no-store")
+
+		if format == formatJSON {
+			response.OK(w, r, TicketResponse{
+				Ticket:      token,
+				BookingID:   booking.ID,
+				EventID:     booking.EventID,
+				UserID:      booking.UserID,
+				CheckedInAt: booking.CheckedInAt,
+			})
+			return
+		}
+
+		png, err := qrcode.Encode(token, qrcode.Medium, qrSize)
+		if err != nil {
+			log.Error("failed to encode qr code", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get ticket")
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Length", strconv.Itoa(len(png)))
+		_, _ = w.Write(png)
+	}
+}
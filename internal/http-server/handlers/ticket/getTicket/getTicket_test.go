@@ -0,0 +1,166 @@
+package getTicket
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"eventBooker/internal/http-server/handlers/ticket/getTicket/mocks"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/models"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSigner, _ = ticket.ParseKey(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+
+var testBooking = &models.Booking{
+	ID:        7,
+	EventID:   1,
+	UserID:    "user123",
+	CreatedAt: time.Date(2099, 6, 1, 10, 0, 0, 0, time.UTC),
+	Confirmed: true,
+}
+
+func TestGetTicketHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name                string
+		url                 string
+		anonymous           bool
+		mockSetup           func(m *mocks.BookingGetter)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name: "JSON",
+			url:  "/api/v1/events/1/ticket",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBooking", mock.Anything, 1, "user123").Return(testBooking, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+		},
+		{
+			name: "QR code by extension",
+			url:  "/api/v1/events/1/ticket.png",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBooking", mock.Anything, 1, "user123").Return(testBooking, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/png",
+		},
+		{
+			name: "QR code by format parameter",
+			url:  "/api/v1/events/1/ticket?format=png",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBooking", mock.Anything, 1, "user123").Return(testBooking, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/png",
+		},
+		{
+			name:                "Anonymous",
+			url:                 "/api/v1/events/1/ticket",
+			anonymous:           true,
+			mockSetup:           func(m *mocks.BookingGetter) {},
+			expectedStatus:      http.StatusUnauthorized,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"api key required","code":"unauthorized"}`,
+		},
+		{
+			name:                "Unknown format",
+			url:                 "/api/v1/events/1/ticket?format=pdf",
+			mockSetup:           func(m *mocks.BookingGetter) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Bad Request","status":400,"detail":"format must be one of: json, png","code":"bad_request"}`,
+		},
+		{
+			name: "No confirmed booking",
+			url:  "/api/v1/events/1/ticket",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBooking", mock.Anything, 1, "user123").Return(nil, errors.New("no confirmed booking found"))
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"no confirmed booking found for this user","code":"not_found"}`,
+		},
+		{
+			name: "Storage error",
+			url:  "/api/v1/events/1/ticket",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBooking", mock.Anything, 1, "user123").Return(nil, errors.New("database error"))
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get ticket","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewBookingGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Use(middleware.URLFormat)
+			r.Get("/api/v1/events/{id}/ticket", New(logger, mockGetter, testSigner))
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			if !tc.anonymous {
+				req = req.WithContext(mwauth.WithUserID(req.Context(), "user123"))
+			}
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.Equal(t, tc.expectedContentType, rr.Header().Get("Content-Type"))
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedStatus != http.StatusOK {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+				return
+			}
+
+			assert.Equal(t, "private, no-store", rr.Header().Get("Cache-Control"))
+
+			if tc.expectedContentType == "image/png" {
+				_, err = png.Decode(bytes.NewReader(rr.Body.Bytes()))
+				assert.NoError(t, err, "body is not a PNG image")
+				return
+			}
+
+			var resp TicketResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response.Envelope{Data: &resp}))
+			assert.Equal(t, 7, resp.BookingID)
+
+			got, err := ticket.Verify(testSigner.PublicKey(), resp.Ticket)
+			require.NoError(t, err)
+			assert.Equal(t, ticket.Ticket{BookingID: 7, EventID: 1, UserID: "user123"}, got)
+		})
+	}
+}
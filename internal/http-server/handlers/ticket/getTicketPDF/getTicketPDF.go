@@ -0,0 +1,134 @@
+package getTicketPDF
+
+import (
+	"bytes"
+	"context"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/lib/ticketpdf"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const formatPDF = "pdf"
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=BookingGetter
+type BookingGetter interface {
+	GetConfirmedBookingByID(ctx context.Context, id int) (*models.Booking, error)
+	GetEvent(ctx context.Context, id int) (*models.Event, error)
+	mwauth.RoleChecker
+}
+
+// New returns the printable ticket of a confirmed booking. The API key must
+// authenticate the booking's owner or an admin, so booking ids cannot be
+// enumerated into other users' tickets.
+func New(log *slog.Logger, bookings BookingGetter, tickets *ticket.Signer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.ticket.getTicketPDF.New"
+
+		log = log.With(slog.String("op", op))
+
+		bookingIdStr := chi.URLParam(r, "id")
+		if bookingIdStr == "" {
+			log.Error("booking id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "booking id is required")
+			return
+		}
+
+		bookingID, err := strconv.Atoi(bookingIdStr)
+		if err != nil {
+			log.Error("invalid booking id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid booking id format")
+			return
+		}
+
+		log = log.With(slog.Int("booking_id", bookingID))
+
+		userID := mwauth.UserID(r.Context())
+		if userID == "" {
+			log.Error("anonymous request for a ticket")
+			response.Error(w, r, http.StatusUnauthorized, response.CodeUnauthorized, "api key required")
+			return
+		}
+
+		if format, _ := r.Context().Value(middleware.URLFormatCtxKey).(string); format != "" && format != formatPDF {
+			log.Error("unknown ticket format", slog.String("format", format))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "format must be pdf")
+			return
+		}
+
+		booking, err := bookings.GetConfirmedBookingByID(r.Context(), bookingID)
+		if err != nil {
+			log.Error("failed to get booking", sl.Err(err))
+
+			if err.Error() == "no confirmed booking found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "no confirmed booking found for this user")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get ticket")
+			return
+		}
+
+		if booking.UserID != userID {
+			admin, err := bookings.HasRole(r.Context(), userID, models.RoleAdmin)
+			if err != nil {
+				log.Error("failed to get user roles", sl.Err(err))
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get ticket")
+				return
+			}
+
+			if !admin {
+				log.Error("booking belongs to another user", slog.String("user_id", userID))
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "no confirmed booking found for this user")
+				return
+			}
+		}
+
+		event, err := bookings.GetEvent(r.Context(), booking.EventID)
+		if err != nil {
+			log.Error("failed to get event", sl.Err(err))
+
+			if err.Error() == "event not found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get ticket")
+			return
+		}
+
+		// Render into a buffer, so a failure can still be reported as JSON.
+		var buf bytes.Buffer
+		err = ticketpdf.Render(&buf, ticketpdf.Ticket{
+			EventTitle: event.Title,
+			Date:       event.Date.In(event.Location()),
+			Attendee:   booking.UserID,
+			BookingID:  booking.ID,
+			Code: tickets.Sign(ticket.Ticket{
+				BookingID: booking.ID,
+				EventID:   booking.EventID,
+				UserID:    booking.UserID,
+			}),
+		})
+		if err != nil {
+			log.Error("failed to render ticket", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get ticket")
+			return
+		}
+
+		// Anyone holding the ticket can check in with it.
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.Header().Set("Content-Type", ticketpdf.ContentType)
+		w.Header().Set("Content-Disposition", `inline; filename="`+ticketpdf.Filename(booking.ID)+`"`)
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		_, _ = buf.WriteTo(w)
+	}
+}
@@ -0,0 +1,193 @@
+package getTicketPDF
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"eventBooker/internal/http-server/handlers/ticket/getTicketPDF/mocks"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSigner, _ = ticket.ParseKey(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+
+var testBooking = &models.Booking{
+	ID:        7,
+	EventID:   1,
+	UserID:    "user123",
+	CreatedAt: time.Date(2099, 6, 1, 10, 0, 0, 0, time.UTC),
+	Confirmed: true,
+}
+
+var testEvent = &models.Event{
+	ID:         1,
+	Title:      "Go Conference",
+	Date:       time.Date(2099, 6, 10, 19, 0, 0, 0, time.UTC),
+	TotalSeats: 100,
+	Deadline:   30,
+}
+
+func TestGetTicketPDFHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name                string
+		url                 string
+		userID              string
+		mockSetup           func(m *mocks.BookingGetter)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:   "Success",
+			url:    "/api/v1/bookings/7/ticket.pdf",
+			userID: "user123",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBookingByID", mock.Anything, 7).Return(testBooking, nil)
+				m.On("GetEvent", mock.Anything, 1).Return(testEvent, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/pdf",
+		},
+		{
+			name:   "Admin",
+			url:    "/api/v1/bookings/7/ticket.pdf",
+			userID: "admin1",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBookingByID", mock.Anything, 7).Return(testBooking, nil)
+				m.On("HasRole", mock.Anything, "admin1", models.RoleAdmin).Return(true, nil)
+				m.On("GetEvent", mock.Anything, 1).Return(testEvent, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/pdf",
+		},
+		{
+			name:                "Anonymous",
+			url:                 "/api/v1/bookings/7/ticket.pdf",
+			mockSetup:           func(m *mocks.BookingGetter) {},
+			expectedStatus:      http.StatusUnauthorized,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"api key required","code":"unauthorized"}`,
+		},
+		{
+			name:                "Invalid booking id",
+			url:                 "/api/v1/bookings/abc/ticket.pdf",
+			userID:              "user123",
+			mockSetup:           func(m *mocks.BookingGetter) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid booking id format","code":"bad_request"}`,
+		},
+		{
+			name:   "No confirmed booking",
+			url:    "/api/v1/bookings/7/ticket.pdf",
+			userID: "user123",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBookingByID", mock.Anything, 7).Return(nil, errors.New("no confirmed booking found"))
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"no confirmed booking found for this user","code":"not_found"}`,
+		},
+		{
+			name:   "Booking of another user",
+			url:    "/api/v1/bookings/7/ticket.pdf",
+			userID: "intruder",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBookingByID", mock.Anything, 7).Return(testBooking, nil)
+				m.On("HasRole", mock.Anything, "intruder", models.RoleAdmin).Return(false, nil)
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"no confirmed booking found for this user","code":"not_found"}`,
+		},
+		{
+			name:   "Roles storage error",
+			url:    "/api/v1/bookings/7/ticket.pdf",
+			userID: "intruder",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBookingByID", mock.Anything, 7).Return(testBooking, nil)
+				m.On("HasRole", mock.Anything, "intruder", models.RoleAdmin).Return(false, errors.New("database error"))
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get ticket","code":"internal_error"}`,
+		},
+		{
+			name:   "Event not found",
+			url:    "/api/v1/bookings/7/ticket.pdf",
+			userID: "user123",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBookingByID", mock.Anything, 7).Return(testBooking, nil)
+				m.On("GetEvent", mock.Anything, 1).Return(nil, errors.New("event not found"))
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"event not found","code":"not_found"}`,
+		},
+		{
+			name:   "Storage error",
+			url:    "/api/v1/bookings/7/ticket.pdf",
+			userID: "user123",
+			mockSetup: func(m *mocks.BookingGetter) {
+				m.On("GetConfirmedBookingByID", mock.Anything, 7).Return(nil, errors.New("database error"))
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get ticket","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewBookingGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Use(middleware.URLFormat)
+			r.Get("/api/v1/bookings/{id}/ticket", New(logger, mockGetter, testSigner))
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			if tc.userID != "" {
+				req = req.WithContext(mwauth.WithUserID(req.Context(), tc.userID))
+			}
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.Equal(t, tc.expectedContentType, rr.Header().Get("Content-Type"))
+			openapitest.ValidateResponse(t, req, rr)
+
+			if tc.expectedStatus != http.StatusOK {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+				return
+			}
+
+			assert.Equal(t, "private, no-store", rr.Header().Get("Cache-Control"))
+			assert.Equal(t, `inline; filename="ticket-7.pdf"`, rr.Header().Get("Content-Disposition"))
+			assert.True(t, bytes.HasPrefix(rr.Body.Bytes(), []byte("%PDF-")), "body is not a PDF")
+		})
+	}
+}
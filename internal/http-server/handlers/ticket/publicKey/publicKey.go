@@ -0,0 +1,28 @@
+package publicKey
+
+import (
+	"encoding/base64"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/ticket"
+	"net/http"
+)
+
+const algorithm = "Ed25519"
+
+type PublicKeyResponse struct {
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+}
+
+// New returns the key that verifies ticket signatures, for scanners that
+// check tickets while offline and sync check-ins later.
+func New(tickets *ticket.Signer) http.HandlerFunc {
+	body := PublicKeyResponse{
+		Algorithm: algorithm,
+		PublicKey: base64.StdEncoding.EncodeToString(tickets.PublicKey()),
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.OK(w, r, body)
+	}
+}
@@ -0,0 +1,43 @@
+package publicKey
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/ticket"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKeyHandler(t *testing.T) {
+	t.Parallel()
+
+	signer, err := ticket.ParseKey(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tickets/public-key", nil)
+	rr := httptest.NewRecorder()
+
+	New(signer).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	openapitest.ValidateResponse(t, req, rr)
+
+	var resp PublicKeyResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response.Envelope{Data: &resp}))
+	assert.Equal(t, "Ed25519", resp.Algorithm)
+
+	key, err := base64.StdEncoding.DecodeString(resp.PublicKey)
+	require.NoError(t, err)
+
+	// A scanner holding only the published key accepts the signer's tickets.
+	_, err = ticket.Verify(ed25519.PublicKey(key), signer.Sign(ticket.Ticket{BookingID: 1, EventID: 1, UserID: "user123"}))
+	assert.NoError(t, err)
+}
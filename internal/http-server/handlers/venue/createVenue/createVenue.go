@@ -0,0 +1,110 @@
+package createVenue
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type VenueRequest struct {
+	Name    string `json:"name" validate:"required,max=200"`
+	Address string `json:"address,omitempty" validate:"max=500"`
+	// Timezone is an IANA time zone name and defaults to UTC.
+	Timezone string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	Capacity int    `json:"capacity" validate:"required,seats"`
+	// LayoutID is the seat layout new events at the venue get.
+	LayoutID int `json:"layout_id,omitempty" validate:"omitempty,gt=0"`
+}
+
+// Normalize trims the text fields and fills in the default time zone.
+func (req *VenueRequest) Normalize() {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Address = strings.TrimSpace(req.Address)
+	req.Timezone = strings.TrimSpace(req.Timezone)
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+}
+
+// Venue returns the venue the request describes.
+func (req *VenueRequest) Venue() models.Venue {
+	v := models.Venue{
+		Name:     req.Name,
+		Address:  req.Address,
+		Timezone: req.Timezone,
+		Capacity: req.Capacity,
+	}
+	// A layout id of 0 leaves the venue without a layout.
+	if req.LayoutID != 0 {
+		v.LayoutID = &req.LayoutID
+	}
+
+	return v
+}
+
+type VenueResponse struct {
+	VenueID int `json:"venue_id"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=VenueCreator
+type VenueCreator interface {
+	CreateVenue(ctx context.Context, v models.Venue) (int, error)
+}
+
+// New creates a venue. Events created at it default to its capacity and
+// get its seat layout, if it has one.
+func New(log *slog.Logger, v *validator.Validate, venues VenueCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.venue.createVenue.New"
+
+		log = log.With(slog.String("op", op))
+
+		var req VenueRequest
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		req.Normalize()
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		id, err := venues.CreateVenue(r.Context(), req.Venue())
+		if err != nil {
+			log.Error("failed to create venue", sl.Err(err))
+
+			switch err.Error() {
+			case "seat layout not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "seat layout not found")
+			case "seat layout exceeds venue capacity":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "the seat layout has more seats than the venue's capacity")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to create venue")
+			}
+			return
+		}
+
+		log.Info("venue created", slog.Int("id", id))
+
+		response.OK(w, r, VenueResponse{VenueID: id})
+	}
+}
@@ -0,0 +1,132 @@
+package createVenue
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/venue/createVenue/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestCreateVenueHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	layoutID := 3
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mocks.VenueCreator)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			requestBody: `{"name": " Main hall ", "address": "1 Theatre Sq", "timezone": "Europe/Moscow", "capacity": 300, "layout_id": 3}`,
+			mockSetup: func(m *mocks.VenueCreator) {
+				m.On("CreateVenue", mock.Anything, models.Venue{
+					Name:     "Main hall",
+					Address:  "1 Theatre Sq",
+					Timezone: "Europe/Moscow",
+					Capacity: 300,
+					LayoutID: &layoutID,
+				}).Return(4, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"venue_id":4},"meta":{}}`,
+		},
+		{
+			name:        "Timezone defaults to UTC",
+			requestBody: `{"name": "Club", "capacity": 80}`,
+			mockSetup: func(m *mocks.VenueCreator) {
+				m.On("CreateVenue", mock.Anything, models.Venue{Name: "Club", Timezone: "UTC", Capacity: 80}).Return(5, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"venue_id":5},"meta":{}}`,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{`,
+			mockSetup:      func(m *mocks.VenueCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"failed to decode request","code":"bad_request"}`,
+		},
+		{
+			name:           "Missing capacity",
+			requestBody:    `{"name": "Club"}`,
+			mockSetup:      func(m *mocks.VenueCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field capacity is a required field","code":"validation_failed","errors":[{"field":"capacity","tag":"required","message":"field capacity is a required field"}]}`,
+		},
+		{
+			name:           "Unknown timezone",
+			requestBody:    `{"name": "Club", "timezone": "Mars/Olympus", "capacity": 80}`,
+			mockSetup:      func(m *mocks.VenueCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field timezone is not an IANA time zone","code":"validation_failed","errors":[{"field":"timezone","tag":"timezone","message":"field timezone is not an IANA time zone"}]}`,
+		},
+		{
+			name:        "Layout not found",
+			requestBody: `{"name": "Club", "capacity": 80, "layout_id": 3}`,
+			mockSetup: func(m *mocks.VenueCreator) {
+				m.On("CreateVenue", mock.Anything, mock.Anything).Return(0, errors.New("seat layout not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"seat layout not found","code":"not_found"}`,
+		},
+		{
+			name:        "Layout too big",
+			requestBody: `{"name": "Club", "capacity": 80, "layout_id": 3}`,
+			mockSetup: func(m *mocks.VenueCreator) {
+				m.On("CreateVenue", mock.Anything, mock.Anything).Return(0, errors.New("seat layout exceeds venue capacity"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"the seat layout has more seats than the venue's capacity","code":"bad_request"}`,
+		},
+		{
+			name:        "Storage error",
+			requestBody: `{"name": "Club", "capacity": 80}`,
+			mockSetup: func(m *mocks.VenueCreator) {
+				m.On("CreateVenue", mock.Anything, mock.Anything).Return(0, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to create venue","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCreator := mocks.NewVenueCreator(t)
+			tc.mockSetup(mockCreator)
+
+			handler := New(logger, testValidator, mockCreator)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/venues", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
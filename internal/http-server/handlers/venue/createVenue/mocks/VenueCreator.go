@@ -0,0 +1,58 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// VenueCreator is an autogenerated mock type for the VenueCreator type
+type VenueCreator struct {
+	mock.Mock
+}
+
+// CreateVenue provides a mock function with given fields: ctx, v
+func (_m *VenueCreator) CreateVenue(ctx context.Context, v models.Venue) (int, error) {
+	ret := _m.Called(ctx, v)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVenue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Venue) (int, error)); ok {
+		return rf(ctx, v)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Venue) int); ok {
+		r0 = rf(ctx, v)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Venue) error); ok {
+		r1 = rf(ctx, v)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewVenueCreator creates a new instance of VenueCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVenueCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VenueCreator {
+	mock := &VenueCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
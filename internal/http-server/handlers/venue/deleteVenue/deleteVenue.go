@@ -0,0 +1,60 @@
+package deleteVenue
+
+import (
+	"context"
+	"eventBooker/internal/http-server/handlers/venue/createVenue"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=VenueDeleter
+type VenueDeleter interface {
+	DeleteVenue(ctx context.Context, id int) error
+}
+
+// New deletes the venue. Venues events are held at cannot be deleted.
+func New(log *slog.Logger, venues VenueDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.venue.deleteVenue.New"
+
+		log = log.With(slog.String("op", op))
+
+		venueIdStr := chi.URLParam(r, "id")
+		if venueIdStr == "" {
+			log.Error("venue id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "venue id is required")
+			return
+		}
+
+		venueID, err := strconv.Atoi(venueIdStr)
+		if err != nil {
+			log.Error("invalid venue id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid venue id format")
+			return
+		}
+
+		log = log.With(slog.Int("venue_id", venueID))
+
+		if err = venues.DeleteVenue(r.Context(), venueID); err != nil {
+			log.Error("failed to delete venue", sl.Err(err))
+
+			switch err.Error() {
+			case "venue not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "venue not found")
+			case "venue has events":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "a venue with events cannot be deleted")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to delete venue")
+			}
+			return
+		}
+
+		log.Info("venue deleted")
+
+		response.OK(w, r, createVenue.VenueResponse{VenueID: venueID})
+	}
+}
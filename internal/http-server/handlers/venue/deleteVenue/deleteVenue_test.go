@@ -0,0 +1,97 @@
+package deleteVenue
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/venue/deleteVenue/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteVenueHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		venueID        string
+		mockSetup      func(m *mocks.VenueDeleter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:    "Success",
+			venueID: "4",
+			mockSetup: func(m *mocks.VenueDeleter) {
+				m.On("DeleteVenue", mock.Anything, 4).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"venue_id":4},"meta":{}}`,
+		},
+		{
+			name:           "Invalid venue ID format",
+			venueID:        "abc",
+			mockSetup:      func(m *mocks.VenueDeleter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid venue id format","code":"bad_request"}`,
+		},
+		{
+			name:    "Venue not found",
+			venueID: "4",
+			mockSetup: func(m *mocks.VenueDeleter) {
+				m.On("DeleteVenue", mock.Anything, 4).Return(errors.New("venue not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"venue not found","code":"not_found"}`,
+		},
+		{
+			name:    "Venue has events",
+			venueID: "4",
+			mockSetup: func(m *mocks.VenueDeleter) {
+				m.On("DeleteVenue", mock.Anything, 4).Return(errors.New("venue has events"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"a venue with events cannot be deleted","code":"conflict"}`,
+		},
+		{
+			name:    "Storage error",
+			venueID: "4",
+			mockSetup: func(m *mocks.VenueDeleter) {
+				m.On("DeleteVenue", mock.Anything, 4).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to delete venue","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockDeleter := mocks.NewVenueDeleter(t)
+			tc.mockSetup(mockDeleter)
+
+			r := chi.NewRouter()
+			r.Delete("/api/v1/venues/{id}", New(logger, mockDeleter))
+
+			req, err := http.NewRequest(http.MethodDelete, "/api/v1/venues/"+tc.venueID, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VenueDeleter is an autogenerated mock type for the VenueDeleter type
+type VenueDeleter struct {
+	mock.Mock
+}
+
+// DeleteVenue provides a mock function with given fields: ctx, id
+func (_m *VenueDeleter) DeleteVenue(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVenue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewVenueDeleter creates a new instance of VenueDeleter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVenueDeleter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VenueDeleter {
+	mock := &VenueDeleter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
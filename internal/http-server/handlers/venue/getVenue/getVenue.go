@@ -0,0 +1,57 @@
+package getVenue
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=VenueGetter
+type VenueGetter interface {
+	GetVenue(ctx context.Context, id int) (*models.Venue, error)
+}
+
+// New returns the venue.
+func New(log *slog.Logger, venues VenueGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.venue.getVenue.New"
+
+		log = log.With(slog.String("op", op))
+
+		venueIdStr := chi.URLParam(r, "id")
+		if venueIdStr == "" {
+			log.Error("venue id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "venue id is required")
+			return
+		}
+
+		venueID, err := strconv.Atoi(venueIdStr)
+		if err != nil {
+			log.Error("invalid venue id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid venue id format")
+			return
+		}
+
+		log = log.With(slog.Int("venue_id", venueID))
+
+		venue, err := venues.GetVenue(r.Context(), venueID)
+		if err != nil {
+			log.Error("failed to get venue", sl.Err(err))
+
+			if err.Error() == "venue not found" {
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "venue not found")
+				return
+			}
+
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get venue")
+			return
+		}
+
+		response.OK(w, r, venue)
+	}
+}
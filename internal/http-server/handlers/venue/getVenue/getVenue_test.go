@@ -0,0 +1,94 @@
+package getVenue
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/venue/getVenue/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVenueHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		venueID        string
+		mockSetup      func(m *mocks.VenueGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:    "Success",
+			venueID: "4",
+			mockSetup: func(m *mocks.VenueGetter) {
+				m.On("GetVenue", mock.Anything, 4).Return(&models.Venue{
+					ID: 4, Name: "Club", Address: "2 Side St", Timezone: "Europe/Berlin", Capacity: 80, CreatedAt: createdAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":4,"name":"Club","address":"2 Side St","timezone":"Europe/Berlin","capacity":80,"created_at":"2026-05-01T12:00:00Z"},"meta":{}}`,
+		},
+		{
+			name:           "Invalid venue ID format",
+			venueID:        "abc",
+			mockSetup:      func(m *mocks.VenueGetter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid venue id format","code":"bad_request"}`,
+		},
+		{
+			name:    "Venue not found",
+			venueID: "4",
+			mockSetup: func(m *mocks.VenueGetter) {
+				m.On("GetVenue", mock.Anything, 4).Return(nil, errors.New("venue not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"venue not found","code":"not_found"}`,
+		},
+		{
+			name:    "Storage error",
+			venueID: "4",
+			mockSetup: func(m *mocks.VenueGetter) {
+				m.On("GetVenue", mock.Anything, 4).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get venue","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewVenueGetter(t)
+			tc.mockSetup(mockGetter)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/venues/{id}", New(logger, mockGetter))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/venues/"+tc.venueID, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
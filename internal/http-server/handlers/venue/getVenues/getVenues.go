@@ -0,0 +1,33 @@
+package getVenues
+
+import (
+	"context"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"log/slog"
+	"net/http"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=VenuesGetter
+type VenuesGetter interface {
+	GetVenues(ctx context.Context) ([]models.Venue, error)
+}
+
+// New lists all venues ordered by name.
+func New(log *slog.Logger, venues VenuesGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.venue.getVenues.New"
+
+		log = log.With(slog.String("op", op))
+
+		list, err := venues.GetVenues(r.Context())
+		if err != nil {
+			log.Error("failed to get venues", sl.Err(err))
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to get venues")
+			return
+		}
+
+		response.OK(w, r, list)
+	}
+}
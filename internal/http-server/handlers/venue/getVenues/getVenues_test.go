@@ -0,0 +1,78 @@
+package getVenues
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/handlers/venue/getVenues/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVenuesHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	layoutID := 3
+
+	testCases := []struct {
+		name           string
+		mockSetup      func(m *mocks.VenuesGetter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success",
+			mockSetup: func(m *mocks.VenuesGetter) {
+				m.On("GetVenues", mock.Anything).Return([]models.Venue{
+					{ID: 2, Name: "Club", Address: "", Timezone: "UTC", Capacity: 80, CreatedAt: createdAt},
+					{ID: 1, Name: "Main hall", Address: "1 Theatre Sq", Timezone: "Europe/Moscow", Capacity: 300, LayoutID: &layoutID, CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[` +
+				`{"id":2,"name":"Club","address":"","timezone":"UTC","capacity":80,"created_at":"2026-05-01T12:00:00Z"},` +
+				`{"id":1,"name":"Main hall","address":"1 Theatre Sq","timezone":"Europe/Moscow","capacity":300,"layout_id":3,"created_at":"2026-05-01T12:00:00Z"}` +
+				`],"meta":{}}`,
+		},
+		{
+			name: "Storage error",
+			mockSetup: func(m *mocks.VenuesGetter) {
+				m.On("GetVenues", mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get venues","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockGetter := mocks.NewVenuesGetter(t)
+			tc.mockSetup(mockGetter)
+
+			handler := New(logger, mockGetter)
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/venues", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "eventBooker/internal/models"
+)
+
+// VenuesGetter is an autogenerated mock type for the VenuesGetter type
+type VenuesGetter struct {
+	mock.Mock
+}
+
+// GetVenues provides a mock function with given fields: ctx
+func (_m *VenuesGetter) GetVenues(ctx context.Context) ([]models.Venue, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVenues")
+	}
+
+	var r0 []models.Venue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Venue, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Venue); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Venue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewVenuesGetter creates a new instance of VenuesGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVenuesGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VenuesGetter {
+	mock := &VenuesGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VenueUpdater is an autogenerated mock type for the VenueUpdater type
+type VenueUpdater struct {
+	mock.Mock
+}
+
+// UpdateVenue provides a mock function with given fields: ctx, v
+func (_m *VenueUpdater) UpdateVenue(ctx context.Context, v models.Venue) error {
+	ret := _m.Called(ctx, v)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVenue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Venue) error); ok {
+		r0 = rf(ctx, v)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewVenueUpdater creates a new instance of VenueUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVenueUpdater(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VenueUpdater {
+	mock := &VenueUpdater{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
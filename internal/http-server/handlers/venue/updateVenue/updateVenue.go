@@ -0,0 +1,95 @@
+package updateVenue
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/http-server/handlers/venue/createVenue"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=VenueUpdater
+type VenueUpdater interface {
+	UpdateVenue(ctx context.Context, v models.Venue) error
+}
+
+// New replaces the venue's details. The capacity cannot drop below the seats
+// of an event held at the venue, and a new layout only applies to events
+// created afterwards.
+func New(log *slog.Logger, v *validator.Validate, venues VenueUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.venue.updateVenue.New"
+
+		log = log.With(slog.String("op", op))
+
+		venueIdStr := chi.URLParam(r, "id")
+		if venueIdStr == "" {
+			log.Error("venue id is required")
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "venue id is required")
+			return
+		}
+
+		venueID, err := strconv.Atoi(venueIdStr)
+		if err != nil {
+			log.Error("invalid venue id format", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "invalid venue id format")
+			return
+		}
+
+		log = log.With(slog.Int("venue_id", venueID))
+
+		var req createVenue.VenueRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to decode request")
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		req.Normalize()
+
+		if err = v.Struct(req); err != nil {
+			var validateErr validator.ValidationErrors
+			errors.As(err, &validateErr)
+
+			log.Error("invalid request", sl.Err(err))
+			response.ValidationError(w, r, validateErr)
+			return
+		}
+
+		venue := req.Venue()
+		venue.ID = venueID
+
+		if err = venues.UpdateVenue(r.Context(), venue); err != nil {
+			log.Error("failed to update venue", sl.Err(err))
+
+			switch err.Error() {
+			case "venue not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "venue not found")
+			case "seat layout not found":
+				response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "seat layout not found")
+			case "seat layout exceeds venue capacity":
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "the seat layout has more seats than the venue's capacity")
+			case "venue capacity below event seats":
+				response.Error(w, r, http.StatusConflict, response.CodeConflict, "an event at the venue has more seats than the new capacity")
+			default:
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to update venue")
+			}
+			return
+		}
+
+		log.Info("venue updated")
+
+		response.OK(w, r, createVenue.VenueResponse{VenueID: venueID})
+	}
+}
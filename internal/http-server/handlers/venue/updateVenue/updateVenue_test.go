@@ -0,0 +1,119 @@
+package updateVenue
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/handlers/venue/updateVenue/mocks"
+	"eventBooker/internal/lib/api/openapitest"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidator = validate.New(config.Validation{MaxSeats: 1000})
+
+func TestUpdateVenueHandler(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+
+	testCases := []struct {
+		name           string
+		venueID        string
+		requestBody    string
+		mockSetup      func(m *mocks.VenueUpdater)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			venueID:     "4",
+			requestBody: `{"name": "Club", "address": "2 Side St", "capacity": 120}`,
+			mockSetup: func(m *mocks.VenueUpdater) {
+				m.On("UpdateVenue", mock.Anything, models.Venue{
+					ID: 4, Name: "Club", Address: "2 Side St", Timezone: "UTC", Capacity: 120,
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"venue_id":4},"meta":{}}`,
+		},
+		{
+			name:           "Invalid venue ID format",
+			venueID:        "abc",
+			requestBody:    `{"name": "Club", "capacity": 120}`,
+			mockSetup:      func(m *mocks.VenueUpdater) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid venue id format","code":"bad_request"}`,
+		},
+		{
+			name:           "Missing name",
+			venueID:        "4",
+			requestBody:    `{"name": "  ", "capacity": 120}`,
+			mockSetup:      func(m *mocks.VenueUpdater) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"field name is a required field","code":"validation_failed","errors":[{"field":"name","tag":"required","message":"field name is a required field"}]}`,
+		},
+		{
+			name:        "Venue not found",
+			venueID:     "4",
+			requestBody: `{"name": "Club", "capacity": 120}`,
+			mockSetup: func(m *mocks.VenueUpdater) {
+				m.On("UpdateVenue", mock.Anything, mock.Anything).Return(errors.New("venue not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"venue not found","code":"not_found"}`,
+		},
+		{
+			name:        "Capacity below event seats",
+			venueID:     "4",
+			requestBody: `{"name": "Club", "capacity": 50}`,
+			mockSetup: func(m *mocks.VenueUpdater) {
+				m.On("UpdateVenue", mock.Anything, mock.Anything).Return(errors.New("venue capacity below event seats"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"an event at the venue has more seats than the new capacity","code":"conflict"}`,
+		},
+		{
+			name:        "Storage error",
+			venueID:     "4",
+			requestBody: `{"name": "Club", "capacity": 120}`,
+			mockSetup: func(m *mocks.VenueUpdater) {
+				m.On("UpdateVenue", mock.Anything, mock.Anything).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to update venue","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUpdater := mocks.NewVenueUpdater(t)
+			tc.mockSetup(mockUpdater)
+
+			r := chi.NewRouter()
+			r.Put("/api/v1/venues/{id}", New(logger, testValidator, mockUpdater))
+
+			req, err := http.NewRequest(http.MethodPut, "/api/v1/venues/"+tc.venueID, bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code, "Status code mismatch")
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String(), "Response body mismatch")
+			openapitest.ValidateResponse(t, req, rr)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package mwauth
+
+import (
+	"errors"
+	"eventBooker/internal/http-server/middleware/mwauth/mocks"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	keys := map[string]string{"admin-key": "admin1", "user-key": "user123"}
+
+	testCases := []struct {
+		name           string
+		key            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Anonymous",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "",
+		},
+		{
+			name:           "Known key",
+			key:            "user-key",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "user123",
+		},
+		{
+			name:           "Unknown key",
+			key:            "other-key",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"invalid api key","code":"unauthorized"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := New(slogdiscard.NewDiscardLogger(), keys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(UserID(r.Context())))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			if tc.key != "" {
+				req.Header.Set(APIKeyHeader, tc.key)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedBody, rr.Body.String())
+			} else {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		userID         string
+		mockSetup      func(m *mocks.RoleChecker)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "Admin",
+			userID: "admin1",
+			mockSetup: func(m *mocks.RoleChecker) {
+				m.On("HasRole", mock.Anything, "admin1", models.RoleAdmin).Return(true, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		{
+			name:           "Anonymous",
+			mockSetup:      func(m *mocks.RoleChecker) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"api key required","code":"unauthorized"}`,
+		},
+		{
+			name:   "Not an admin",
+			userID: "user123",
+			mockSetup: func(m *mocks.RoleChecker) {
+				m.On("HasRole", mock.Anything, "user123", models.RoleAdmin).Return(false, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"type":"about:blank","title":"Forbidden","status":403,"detail":"admin role required","code":"forbidden"}`,
+		},
+		{
+			name:   "Storage error",
+			userID: "admin1",
+			mockSetup: func(m *mocks.RoleChecker) {
+				m.On("HasRole", mock.Anything, "admin1", models.RoleAdmin).Return(false, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to get user roles","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			roles := mocks.NewRoleChecker(t)
+			tc.mockSetup(roles)
+
+			handler := RequireRole(slogdiscard.NewDiscardLogger(), roles, models.RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			}))
+
+			req := httptest.NewRequest(http.MethodPut, "/events/1/status", nil)
+			if tc.userID != "" {
+				req = req.WithContext(WithUserID(req.Context(), tc.userID))
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedBody, rr.Body.String())
+			} else {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package mwdeprecation
+
+import (
+	"eventBooker/internal/lib/api/response"
+	"net/http"
+)
+
+// New marks responses of unversioned routes as deprecated and points clients to
+// the same path under successorPrefix. Handlers keep answering in the legacy
+// response format on these routes so existing clients do not break.
+func New(successorPrefix string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", "<"+successorPrefix+r.URL.Path+`>; rel="successor-version"`)
+
+			next.ServeHTTP(w, r.WithContext(response.WithLegacyFormat(r.Context())))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,27 @@
+package mwdeprecation
+
+import (
+	"eventBooker/internal/lib/api/response"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecation(t *testing.T) {
+	handler := New("/api/v1")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.Error(w, r, http.StatusNotFound, response.CodeNotFound, "event not found")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/events/42", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "true", rr.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v1/events/42>; rel="successor-version"`, rr.Header().Get("Link"))
+	assert.JSONEq(t, `{"status":"Error","error":"event not found"}`, rr.Body.String())
+}
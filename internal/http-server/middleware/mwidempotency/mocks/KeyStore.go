@@ -0,0 +1,97 @@
+// Code generated by mockery v2.51.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "eventBooker/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// KeyStore is an autogenerated mock type for the KeyStore type
+type KeyStore struct {
+	mock.Mock
+}
+
+// CompleteIdempotencyKey provides a mock function with given fields: ctx, key, statusCode, response
+func (_m *KeyStore) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, response []byte) error {
+	ret := _m.Called(ctx, key, statusCode, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, []byte) error); ok {
+		r0 = rf(ctx, key, statusCode, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *KeyStore) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveIdempotencyKey provides a mock function with given fields: ctx, key, requestHash, ttl, lockTimeout
+func (_m *KeyStore) SaveIdempotencyKey(ctx context.Context, key string, requestHash string, ttl time.Duration, lockTimeout time.Duration) (*models.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key, requestHash, ttl, lockTimeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveIdempotencyKey")
+	}
+
+	var r0 *models.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, time.Duration) (*models.IdempotencyKey, error)); ok {
+		return rf(ctx, key, requestHash, ttl, lockTimeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, time.Duration) *models.IdempotencyKey); ok {
+		r0 = rf(ctx, key, requestHash, ttl, lockTimeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, time.Duration) error); ok {
+		r1 = rf(ctx, key, requestHash, ttl, lockTimeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewKeyStore creates a new instance of KeyStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKeyStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KeyStore {
+	mock := &KeyStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
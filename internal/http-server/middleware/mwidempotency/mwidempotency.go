@@ -0,0 +1,176 @@
+package mwidempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/models"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.51.1 --name=KeyStore
+type KeyStore interface {
+	SaveIdempotencyKey(ctx context.Context, key, requestHash string, ttl, lockTimeout time.Duration) (*models.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, response []byte) error
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+}
+
+// New returns a middleware that honors the Idempotency-Key header on mutating requests.
+// Keys are scoped to the method, path and the user authenticated by
+// mwauth.New, so clients of different endpoints or different users cannot
+// collide or replay each other's responses. The first response for a key is
+// stored for ttl and returned as is to every replay with the same body.
+// Server errors, 429s, 401s and 403s are not stored so the client can retry,
+// e.g. once it has the right API key. A request that has not finished within
+// lockTimeout, e.g. because the server died while serving it, gives its key
+// up to the next retry.
+func New(log *slog.Logger, store KeyStore, ttl, lockTimeout time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log = log.With(slog.String("component", "middleware/idempotency"))
+
+		log.Info("idempotency middleware enabled",
+			slog.String("ttl", ttl.String()),
+			slog.String("lock_timeout", lockTimeout.String()),
+		)
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(Header)
+			if key == "" || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			entry := log.With(
+				slog.String("idempotency_key", key),
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+			)
+
+			if len(key) > maxKeyLength {
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "idempotency key is too long")
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				entry.Error("failed to read request body", sl.Err(err))
+				response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, "failed to read request")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			hash := requestHash(r, body)
+			key = scopedKey(r, key)
+
+			existing, err := store.SaveIdempotencyKey(r.Context(), key, hash, ttl, lockTimeout)
+			if err != nil {
+				entry.Error("failed to save idempotency key", sl.Err(err))
+				response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to process idempotency key")
+				return
+			}
+
+			if existing != nil {
+				switch {
+				case existing.RequestHash != hash:
+					entry.Warn("idempotency key reused with a different request")
+					response.Error(w, r, http.StatusUnprocessableEntity, response.CodeUnprocessable, "idempotency key was already used with a different request")
+				case !existing.Completed:
+					response.Error(w, r, http.StatusConflict, response.CodeConflict, "request with this idempotency key is still in progress")
+				default:
+					entry.Info("replaying stored response", slog.Int("status", existing.StatusCode))
+					w.Header().Set("Content-Type", response.ContentType(r, existing.StatusCode))
+					w.Header().Set(ReplayedHeader, strconv.FormatBool(true))
+					w.WriteHeader(existing.StatusCode)
+					_, _ = w.Write(existing.Response)
+				}
+				return
+			}
+
+			var buf bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+
+			defer func() {
+				rec := recover()
+
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				if rec != nil || !storable(status) {
+					if err := store.DeleteIdempotencyKey(context.WithoutCancel(r.Context()), key); err != nil {
+						entry.Error("failed to release idempotency key", sl.Err(err))
+					}
+					if rec != nil {
+						panic(rec)
+					}
+					return
+				}
+
+				if err := store.CompleteIdempotencyKey(context.WithoutCancel(r.Context()), key, status, buf.Bytes()); err != nil {
+					entry.Error("failed to store idempotent response", sl.Err(err))
+				}
+			}()
+
+			next.ServeHTTP(ww, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// storable reports whether a response with the status is final for its
+// key. Failures the client may retry without changing the request are not.
+func storable(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return false
+	default:
+		return status < http.StatusInternalServerError
+	}
+}
+
+// scopedKey returns the key the store knows the client's key under. The
+// user is quoted so that it cannot run into the key.
+func scopedKey(r *http.Request, key string) string {
+	return r.Method + " " + r.URL.Path + " " + strconv.Quote(mwauth.UserID(r.Context())) + " " + key
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write([]byte(mwauth.UserID(r.Context())))
+	h.Write([]byte{0})
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
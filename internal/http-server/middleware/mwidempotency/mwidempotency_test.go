@@ -0,0 +1,225 @@
+package mwidempotency
+
+import (
+	"bytes"
+	"errors"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/http-server/middleware/mwidempotency/mocks"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Parallel()
+
+	logger := slogdiscard.NewDiscardLogger()
+	ttl := time.Hour
+	lockTimeout := 4 * time.Second
+	body := `{"user_id":"user123"}`
+	hash := requestHash(httptest.NewRequest(http.MethodPost, "/events/1/book", nil), []byte(body))
+	userReq := httptest.NewRequest(http.MethodPost, "/events/1/book", nil)
+	userHash := requestHash(userReq.WithContext(mwauth.WithUserID(userReq.Context(), "user123")), []byte(body))
+
+	testCases := []struct {
+		name           string
+		method         string
+		key            string
+		userID         string
+		handlerStatus  int
+		mockSetup      func(store *mocks.KeyStore)
+		expectedStatus int
+		expectedBody   string
+		expectedCalls  int
+		expectedReplay bool
+	}{
+		{
+			name:           "No key passes through",
+			method:         http.MethodPost,
+			handlerStatus:  http.StatusOK,
+			mockSetup:      func(store *mocks.KeyStore) {},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK"}`,
+			expectedCalls:  1,
+		},
+		{
+			name:           "Safe method ignores key",
+			method:         http.MethodGet,
+			key:            "key-1",
+			handlerStatus:  http.StatusOK,
+			mockSetup:      func(store *mocks.KeyStore) {},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK"}`,
+			expectedCalls:  1,
+		},
+		{
+			name:          "First request stores response",
+			method:        http.MethodPost,
+			key:           "key-1",
+			handlerStatus: http.StatusOK,
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`, hash, ttl, lockTimeout).Return(nil, nil)
+				store.On("CompleteIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`, http.StatusOK, mock.MatchedBy(func(b []byte) bool {
+					return bytes.Contains(b, []byte(`"status":"OK"`))
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK"}`,
+			expectedCalls:  1,
+		},
+		{
+			name:          "Server error releases key",
+			method:        http.MethodPost,
+			key:           "key-1",
+			handlerStatus: http.StatusInternalServerError,
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`, hash, ttl, lockTimeout).Return(nil, nil)
+				store.On("DeleteIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`).Return(nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"status":"OK"}`,
+			expectedCalls:  1,
+		},
+		{
+			name:          "Key is scoped to the user",
+			method:        http.MethodPost,
+			key:           "key-1",
+			userID:        "user123",
+			handlerStatus: http.StatusOK,
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "user123" key-1`, userHash, ttl, lockTimeout).Return(nil, nil)
+				store.On("CompleteIdempotencyKey", mock.Anything, `POST /events/1/book "user123" key-1`, http.StatusOK, mock.Anything).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK"}`,
+			expectedCalls:  1,
+		},
+		{
+			name:          "Unauthorized releases key",
+			method:        http.MethodPost,
+			key:           "key-1",
+			handlerStatus: http.StatusUnauthorized,
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`, hash, ttl, lockTimeout).Return(nil, nil)
+				store.On("DeleteIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`).Return(nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"OK"}`,
+			expectedCalls:  1,
+		},
+		{
+			name:          "Forbidden releases key",
+			method:        http.MethodPost,
+			key:           "key-1",
+			userID:        "user123",
+			handlerStatus: http.StatusForbidden,
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "user123" key-1`, userHash, ttl, lockTimeout).Return(nil, nil)
+				store.On("DeleteIdempotencyKey", mock.Anything, `POST /events/1/book "user123" key-1`).Return(nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"status":"OK"}`,
+			expectedCalls:  1,
+		},
+		{
+			name:   "Replay returns stored response",
+			method: http.MethodPost,
+			key:    "key-1",
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`, hash, ttl, lockTimeout).Return(&models.IdempotencyKey{
+					Key:         "key-1",
+					RequestHash: hash,
+					StatusCode:  http.StatusConflict,
+					Response:    []byte(`{"type":"about:blank","title":"Conflict","status":409,"detail":"no available seats","code":"no_available_seats"}`),
+					Completed:   true,
+				}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"no available seats","code":"no_available_seats"}`,
+			expectedReplay: true,
+		},
+		{
+			name:   "Key reused with different body",
+			method: http.MethodPost,
+			key:    "key-1",
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`, hash, ttl, lockTimeout).Return(&models.IdempotencyKey{
+					Key:         "key-1",
+					RequestHash: "other",
+					Completed:   true,
+				}, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"idempotency key was already used with a different request","code":"unprocessable"}`,
+		},
+		{
+			name:   "Request still in progress",
+			method: http.MethodPost,
+			key:    "key-1",
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`, hash, ttl, lockTimeout).Return(&models.IdempotencyKey{
+					Key:         "key-1",
+					RequestHash: hash,
+				}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"request with this idempotency key is still in progress","code":"conflict"}`,
+		},
+		{
+			name:   "Store failure",
+			method: http.MethodPost,
+			key:    "key-1",
+			mockSetup: func(store *mocks.KeyStore) {
+				store.On("SaveIdempotencyKey", mock.Anything, `POST /events/1/book "" key-1`, hash, ttl, lockTimeout).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"failed to process idempotency key","code":"internal_error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := mocks.NewKeyStore(t)
+			tc.mockSetup(store)
+
+			calls := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				render.Status(r, tc.handlerStatus)
+				render.JSON(w, r, map[string]string{"status": "OK"})
+			})
+
+			handler := New(logger, store, ttl, lockTimeout)(next)
+
+			req := httptest.NewRequest(tc.method, "/events/1/book", bytes.NewBufferString(body))
+			if tc.key != "" {
+				req.Header.Set(Header, tc.key)
+			}
+			if tc.userID != "" {
+				req = req.WithContext(mwauth.WithUserID(req.Context(), tc.userID))
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+			assert.Equal(t, tc.expectedCalls, calls, "handler call count mismatch")
+			if tc.expectedReplay {
+				assert.Equal(t, "true", rr.Header().Get(ReplayedHeader))
+				assert.Equal(t, response.ContentTypeProblem, rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
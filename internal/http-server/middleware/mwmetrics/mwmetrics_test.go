@@ -0,0 +1,51 @@
+package mwmetrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type observation struct {
+	method string
+	route  string
+	status int
+}
+
+type recorder struct {
+	observed []observation
+}
+
+func (r *recorder) ObserveRequest(method, route string, status int, _ time.Duration) {
+	r.observed = append(r.observed, observation{method: method, route: route, status: status})
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+
+	router := chi.NewRouter()
+	router.Use(New(rec))
+	router.Get("/events/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	router.Post("/events", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, path := range []string{"/events/1", "/events/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/events", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/random/path", nil))
+
+	assert.Equal(t, []observation{
+		{method: http.MethodGet, route: "/events/{id}", status: http.StatusNotFound},
+		{method: http.MethodGet, route: "/events/{id}", status: http.StatusNotFound},
+		{method: http.MethodPost, route: "/events", status: http.StatusOK},
+		{method: http.MethodGet, route: unmatchedRoute, status: http.StatusNotFound},
+	}, rec.observed)
+}
@@ -0,0 +1,192 @@
+package mwratelimit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/ratelimit"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	KeyByIP     = "ip"
+	KeyByUser   = "user"
+	KeyByAPIKey = "api_key"
+
+	APIKeyHeader = mwauth.APIKeyHeader
+
+	// maxPeekBody bounds how much of the request body of an anonymous
+	// request is read to find user_id.
+	maxPeekBody = 1 << 20
+)
+
+// New returns a factory that builds a rate limiting middleware for a named route
+// from cfg.Routes. Routes without a configured limit, or a disabled cfg, pass through.
+func New(log *slog.Logger, limiter ratelimit.Limiter, cfg config.RateLimit) func(route string) func(next http.Handler) http.Handler {
+	log = log.With(slog.String("component", "middleware/ratelimit"))
+
+	return func(route string) func(next http.Handler) http.Handler {
+		rule, ok := cfg.Routes[route]
+		if !cfg.Enabled || !ok || rule.Requests <= 0 || rule.Period <= 0 {
+			return func(next http.Handler) http.Handler { return next }
+		}
+
+		limit := ratelimit.Every(rule.Requests, rule.Period, rule.Burst)
+
+		log.Info("rate limit enabled",
+			slog.String("route", route),
+			slog.Int("requests", rule.Requests),
+			slog.String("period", rule.Period.String()),
+			slog.Int("burst", limit.Burst),
+			slog.Any("key_by", rule.KeyBy),
+		)
+
+		return func(next http.Handler) http.Handler {
+			fn := func(w http.ResponseWriter, r *http.Request) {
+				var (
+					worst   ratelimit.Result
+					limited bool
+				)
+
+				for _, dim := range rule.KeyBy {
+					value := clientKey(r, dim)
+					if value == "" {
+						continue
+					}
+
+					res, err := limiter.Allow(r.Context(), route+":"+dim+":"+value, limit)
+					if err != nil {
+						// Fail open: an unavailable limiter must not take bookings down with it.
+						log.Error("failed to check rate limit", sl.Err(err), slog.String("route", route))
+						continue
+					}
+
+					if !limited || moreRestrictive(res, worst) {
+						worst = res
+					}
+					limited = true
+				}
+
+				if limited {
+					setHeaders(w, worst)
+
+					if !worst.Allowed {
+						log.Warn("rate limit exceeded",
+							slog.String("route", route),
+							slog.String("remote_addr", r.RemoteAddr),
+						)
+
+						w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(worst.RetryAfter)))
+						response.Error(w, r, http.StatusTooManyRequests, response.CodeRateLimited, "too many requests")
+						return
+					}
+				}
+
+				next.ServeHTTP(w, r)
+			}
+
+			return http.HandlerFunc(fn)
+		}
+	}
+}
+
+// IdleTimeout returns how long the buckets of cfg may stay unused before the
+// limiter may drop them: the longest refill time of the configured routes,
+// so dropping a bucket never hands out tokens early, and no less than floor.
+func IdleTimeout(cfg config.RateLimit, floor time.Duration) time.Duration {
+	idle := floor
+	for _, rule := range cfg.Routes {
+		if rule.Requests <= 0 || rule.Period <= 0 {
+			continue
+		}
+
+		idle = max(idle, ratelimit.Every(rule.Requests, rule.Period, rule.Burst).RefillTime())
+	}
+
+	return idle
+}
+
+func setHeaders(w http.ResponseWriter, res ratelimit.Result) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+}
+
+func clientKey(r *http.Request, dim string) string {
+	switch dim {
+	case KeyByIP:
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			return r.RemoteAddr
+		}
+		return host
+	case KeyByAPIKey:
+		return hashAPIKey(r.Header.Get(APIKeyHeader))
+	case KeyByUser:
+		if userID := mwauth.UserID(r.Context()); userID != "" {
+			return userID
+		}
+		return peekUserID(r)
+	default:
+		return ""
+	}
+}
+
+// hashAPIKey returns the SHA-256 of key, so that the key itself never gets
+// into the limiter's storage, or "" if there is no key.
+func hashAPIKey(key string) string {
+	if key == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// peekUserID reads user_id from a JSON body and restores the body for the handler.
+func peekUserID(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPeekBody))
+	if err != nil {
+		return ""
+	}
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+	var req struct {
+		UserID string `json:"user_id"`
+	}
+	if err = json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+
+	return req.UserID
+}
+
+// moreRestrictive reports whether a should be reported to the client instead of b.
+func moreRestrictive(a, b ratelimit.Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,191 @@
+package mwratelimit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/ratelimit"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.RateLimit{
+		Enabled: true,
+		Routes: map[string]config.RouteLimit{
+			"book": {Requests: 2, Period: time.Minute, KeyBy: []string{KeyByIP, KeyByUser}},
+		},
+	}
+
+	var gotBody string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+	})
+
+	handler := New(slogdiscard.NewDiscardLogger(), ratelimit.NewMemory(), cfg)("book")(next)
+
+	send := func(remoteAddr, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/events/1/book", bytes.NewBufferString(body))
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := send("10.0.0.1:1000", `{"user_id":"u1"}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"user_id":"u1"}`, gotBody, "body must be restored for the handler")
+	assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Remaining"))
+
+	rr = send("10.0.0.1:1001", `{"user_id":"u1"}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get("X-RateLimit-Remaining"))
+
+	rr = send("10.0.0.1:1002", `{"user_id":"u1"}`)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "30", rr.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"type":"about:blank","title":"Too Many Requests","status":429,"detail":"too many requests","code":"rate_limited"}`, rr.Body.String())
+
+	// Same user from another address is still limited by the user bucket.
+	rr = send("10.0.0.2:1000", `{"user_id":"u1"}`)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+
+	rr = send("10.0.0.2:1000", `{"user_id":"u2"}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+// keyRecorder is a limiter that lets everything through and records the bucket keys.
+type keyRecorder struct {
+	keys []string
+}
+
+func (k *keyRecorder) Allow(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	k.keys = append(k.keys, key)
+	return ratelimit.Result{Allowed: true, Limit: limit.Burst, Remaining: limit.Burst}, nil
+}
+
+func (k *keyRecorder) Cleanup(context.Context, time.Duration) error { return nil }
+
+func TestRateLimitKeys(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.RateLimit{
+		Enabled: true,
+		Routes: map[string]config.RouteLimit{
+			"book": {Requests: 2, Period: time.Minute, KeyBy: []string{KeyByAPIKey, KeyByUser}},
+		},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	sum := sha256.Sum256([]byte("secret-key"))
+
+	testCases := []struct {
+		name         string
+		apiKey       string
+		userID       string
+		body         string
+		expectedKeys []string
+	}{
+		{
+			name:         "Authenticated user",
+			apiKey:       "secret-key",
+			userID:       "u1",
+			body:         `{"user_id":"u2"}`,
+			expectedKeys: []string{"book:api_key:" + hex.EncodeToString(sum[:]), "book:user:u1"},
+		},
+		{
+			name:         "Anonymous user",
+			body:         `{"user_id":"u2"}`,
+			expectedKeys: []string{"book:user:u2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := &keyRecorder{}
+			handler := New(slogdiscard.NewDiscardLogger(), limiter, cfg)("book")(next)
+
+			req := httptest.NewRequest(http.MethodPost, "/events/1/book", bytes.NewBufferString(tc.body))
+			if tc.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tc.apiKey)
+			}
+			if tc.userID != "" {
+				req = req.WithContext(mwauth.WithUserID(req.Context(), tc.userID))
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tc.expectedKeys, limiter.keys)
+		})
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.RateLimit{Routes: map[string]config.RouteLimit{
+		"book":   {Requests: 5, Period: time.Minute},
+		"import": {Requests: 10, Period: 24 * time.Hour, Burst: 20},
+		"broken": {Requests: 0, Period: 48 * time.Hour},
+	}}
+
+	assert.Equal(t, 48*time.Hour, IdleTimeout(cfg, time.Hour), "the bucket of import takes two days to refill")
+	assert.Equal(t, time.Hour, IdleTimeout(config.RateLimit{}, time.Hour))
+}
+
+func TestRateLimitPassThrough(t *testing.T) {
+	t.Parallel()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	testCases := []struct {
+		name  string
+		cfg   config.RateLimit
+		route string
+	}{
+		{
+			name:  "Disabled",
+			cfg:   config.RateLimit{Routes: map[string]config.RouteLimit{"book": {Requests: 1, Period: time.Minute, KeyBy: []string{KeyByIP}}}},
+			route: "book",
+		},
+		{
+			name:  "Route not configured",
+			cfg:   config.RateLimit{Enabled: true},
+			route: "book",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := New(slogdiscard.NewDiscardLogger(), ratelimit.NewMemory(), tc.cfg)(tc.route)(next)
+
+			for i := 0; i < 3; i++ {
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/", nil))
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+			}
+		})
+	}
+}
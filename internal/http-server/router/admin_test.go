@@ -0,0 +1,67 @@
+package router_test
+
+import (
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/http-server/router"
+	"eventBooker/internal/http-server/router/routertest"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminRoutes(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{method: http.MethodPut, path: "/events/1/status"},
+		{method: http.MethodPost, path: "/events/import"},
+		{method: http.MethodPost, path: "/events/1/ticket-types"},
+		{method: http.MethodGet, path: "/events/1/attendees"},
+		{method: http.MethodGet, path: "/events/1/refunds"},
+		{method: http.MethodPost, path: "/promo-codes"},
+		{method: http.MethodGet, path: "/promo-codes"},
+		{method: http.MethodPost, path: "/layouts"},
+		{method: http.MethodPost, path: "/series"},
+		{method: http.MethodPut, path: "/series/1/events/1"},
+		{method: http.MethodPost, path: "/series/1/exceptions"},
+		{method: http.MethodPost, path: "/venues"},
+		{method: http.MethodPut, path: "/venues/1"},
+		{method: http.MethodDelete, path: "/venues/1"},
+		{method: http.MethodGet, path: "/notifications"},
+		{method: http.MethodPost, path: "/payments/1/refunds"},
+	}
+
+	testCases := []struct {
+		name           string
+		key            string
+		expectedStatus int
+	}{
+		{name: "Anonymous", expectedStatus: http.StatusUnauthorized},
+		{name: "Not an admin", key: routertest.UserKey, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		for _, route := range routes {
+			req, err := http.NewRequest(route.method, srv.URL+router.Prefix+route.path, strings.NewReader(`{}`))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			if tc.key != "" {
+				req.Header.Set(mwauth.APIKeyHeader, tc.key)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode, tc.name+": "+route.method+" "+route.path)
+		}
+	}
+}
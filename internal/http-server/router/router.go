@@ -0,0 +1,366 @@
+package router
+
+import (
+	"context"
+	"eventBooker/internal/http-server/handlers/calendar/eventFeed"
+	"eventBooker/internal/http-server/handlers/calendar/scheduleFeed"
+	"eventBooker/internal/http-server/handlers/calendar/userFeed"
+	"eventBooker/internal/http-server/handlers/event/cancelBooking"
+	"eventBooker/internal/http-server/handlers/event/confirmBooking"
+	"eventBooker/internal/http-server/handlers/event/createBooking"
+	"eventBooker/internal/http-server/handlers/event/createEvent"
+	"eventBooker/internal/http-server/handlers/event/createTicketType"
+	"eventBooker/internal/http-server/handlers/event/getAllEvents"
+	"eventBooker/internal/http-server/handlers/event/getAttendees"
+	"eventBooker/internal/http-server/handlers/event/getCancellationPolicy"
+	"eventBooker/internal/http-server/handlers/event/getEventInfo"
+	"eventBooker/internal/http-server/handlers/event/getSeatMap"
+	"eventBooker/internal/http-server/handlers/event/importEvents"
+	"eventBooker/internal/http-server/handlers/event/setCancellationPolicy"
+	"eventBooker/internal/http-server/handlers/event/setEventLayout"
+	"eventBooker/internal/http-server/handlers/event/setEventStatus"
+	"eventBooker/internal/http-server/handlers/layout/createLayout"
+	"eventBooker/internal/http-server/handlers/layout/getLayout"
+	"eventBooker/internal/http-server/handlers/notification/getNotifications"
+	"eventBooker/internal/http-server/handlers/payment/createPayment"
+	"eventBooker/internal/http-server/handlers/payment/getRefunds"
+	"eventBooker/internal/http-server/handlers/payment/paymentWebhook"
+	"eventBooker/internal/http-server/handlers/payment/refundPayment"
+	"eventBooker/internal/http-server/handlers/promo/createPromoCode"
+	"eventBooker/internal/http-server/handlers/promo/getPromoCodes"
+	"eventBooker/internal/http-server/handlers/series/addSeriesException"
+	"eventBooker/internal/http-server/handlers/series/bookSeries"
+	"eventBooker/internal/http-server/handlers/series/createSeries"
+	"eventBooker/internal/http-server/handlers/series/getSeries"
+	"eventBooker/internal/http-server/handlers/series/updateSeriesEvent"
+	"eventBooker/internal/http-server/handlers/ticket/checkIn"
+	"eventBooker/internal/http-server/handlers/ticket/getTicket"
+	"eventBooker/internal/http-server/handlers/ticket/getTicketPDF"
+	"eventBooker/internal/http-server/handlers/ticket/publicKey"
+	"eventBooker/internal/http-server/handlers/venue/createVenue"
+	"eventBooker/internal/http-server/handlers/venue/deleteVenue"
+	"eventBooker/internal/http-server/handlers/venue/getVenue"
+	"eventBooker/internal/http-server/handlers/venue/getVenues"
+	"eventBooker/internal/http-server/handlers/venue/updateVenue"
+	"eventBooker/internal/http-server/middleware/mwauth"
+	"eventBooker/internal/http-server/middleware/mwidempotency"
+	"eventBooker/internal/lib/feedtoken"
+	"eventBooker/internal/lib/logger/sl"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/models"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+)
+
+// Prefix is where the current version of the API is mounted.
+const Prefix = "/api/v1"
+
+type Storage interface {
+	createEvent.EventCreator
+	setEventStatus.EventStatusSetter
+	createTicketType.TicketTypeCreator
+	getAllEvents.EventsGetter
+	getEventInfo.EventGetter
+	getAttendees.AttendeesStreamer
+	setCancellationPolicy.PolicySetter
+	getCancellationPolicy.PolicyGetter
+	createPromoCode.PromoCodeCreator
+	getPromoCodes.PromoCodesGetter
+	createLayout.LayoutCreator
+	getLayout.LayoutGetter
+	setEventLayout.EventLayoutSetter
+	getSeatMap.SeatMapGetter
+	createVenue.VenueCreator
+	getVenues.VenuesGetter
+	getVenue.VenueGetter
+	updateVenue.VenueUpdater
+	deleteVenue.VenueDeleter
+	importEvents.EventImporter
+	createSeries.SeriesCreator
+	getSeries.SeriesGetter
+	updateSeriesEvent.SeriesEventUpdater
+	addSeriesException.ExceptionAdder
+	getNotifications.NotificationsGetter
+	eventFeed.EventGetter
+	scheduleFeed.EventsGetter
+	userFeed.UserEventsGetter
+	getTicket.BookingGetter
+	getTicketPDF.BookingGetter
+	checkIn.CheckInRecorder
+	createPayment.PaymentCreator
+	paymentWebhook.PaymentCompleter
+	refundPayment.RefundIssuer
+	getRefunds.RefundsGetter
+	mwidempotency.KeyStore
+	// PendingBookingPrice returns the price of the user's pending booking, 0 if it is free.
+	PendingBookingPrice(ctx context.Context, eventID int, userID string) (int64, error)
+	// PendingRefunds returns the refunds of the user's cancelled bookings not yet sent to the provider.
+	PendingRefunds(ctx context.Context, eventID int, userID string) ([]models.Refund, error)
+}
+
+type Bookings interface {
+	createBooking.BookingCreator
+	confirmBooking.BookingConfirmer
+	cancelBooking.BookingCanceller
+	bookSeries.SeriesBooker
+}
+
+type Deps struct {
+	Validator *validator.Validate
+	Storage   Storage
+	Bookings  Bookings
+	RateLimit func(route string) func(next http.Handler) http.Handler
+	// APIKeys maps API keys to the users they authenticate, see mwauth.New.
+	APIKeys        map[string]string
+	IdempotencyTTL time.Duration
+	// IdempotencyLockTimeout is how long a request holds its idempotency key
+	// before a retry may take it over. No response is written after the
+	// server's write timeout, so that is what it should be.
+	IdempotencyLockTimeout time.Duration
+	// SeriesHorizon is how far ahead the occurrences of a new series are created.
+	SeriesHorizon time.Duration
+	// Calendars signs the URLs of users' calendar feeds. The feeds are disabled when it is nil.
+	Calendars *feedtoken.Signer
+	// CalendarDomain makes the UIDs of calendar events globally unique.
+	CalendarDomain string
+	// Tickets signs the tickets of confirmed bookings. Tickets and check-in are disabled when it is nil.
+	Tickets *ticket.Signer
+	// Payments takes payments for priced ticket types. When it is set, such
+	// bookings are confirmed only by paying and refunded when cancelled.
+	// Payments are disabled when it is nil.
+	Payments payment.Provider
+}
+
+// legacyRoutes are the routes served at the root before the API was versioned.
+var legacyRoutes = map[string]bool{
+	http.MethodPost + " /events":              true,
+	http.MethodPost + " /events/{id}/book":    true,
+	http.MethodPost + " /events/{id}/confirm": true,
+	http.MethodGet + " /events/{id}":          true,
+	http.MethodGet + " /events":               true,
+}
+
+// LegacyOnly lets through only the routes that existed before the API was
+// versioned, in their JSON format. It is for the deprecated aliases of API
+// at the root: routes added since are only served under Prefix.
+func LegacyOnly(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		format, _ := r.Context().Value(middleware.URLFormatCtxKey).(string)
+		if format != "" || !legacyRoutes[r.Method+" "+chi.RouteContext(r.Context()).RoutePattern()] {
+			http.NotFound(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// API returns the JSON API routes. They are mounted under Prefix and,
+// for existing clients, the ones in legacyRoutes as deprecated aliases at
+// the root, see LegacyOnly.
+func API(log *slog.Logger, deps Deps) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Use(mwauth.New(log, deps.APIKeys))
+		r.Use(mwidempotency.New(log, deps.Storage, deps.IdempotencyTTL, deps.IdempotencyLockTimeout))
+		admin := mwauth.RequireRole(log, deps.Storage, models.RoleAdmin)
+
+		bookings := deps.Bookings
+		var statuses setEventStatus.EventStatusSetter = deps.Storage
+		if deps.Payments != nil {
+			bookings = paidBookings{Bookings: deps.Bookings, store: deps.Storage, provider: deps.Payments, log: log}
+			statuses = paidStatuses{EventStatusSetter: deps.Storage, store: deps.Storage, provider: deps.Payments, log: log}
+		}
+
+		r.With(deps.RateLimit("create_event")).Post("/events", createEvent.New(log, deps.Validator, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Put("/events/{id}/status", setEventStatus.New(log, deps.Validator, statuses))
+		r.With(deps.RateLimit("import"), admin).Post("/events/import", importEvents.New(log, deps.Validator, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Post("/events/{id}/ticket-types", createTicketType.New(log, deps.Validator, deps.Storage))
+		r.With(deps.RateLimit("book")).Post("/events/{id}/book", createBooking.New(log, deps.Validator, bookings))
+		r.With(deps.RateLimit("confirm")).Post("/events/{id}/confirm", confirmBooking.New(log, deps.Validator, bookings, confirmLinks(deps)))
+		r.With(deps.RateLimit("cancel")).Post("/events/{id}/cancel", cancelBooking.New(log, deps.Validator, bookings))
+		r.With(deps.RateLimit("create_event")).Put("/events/{id}/cancellation-policy", setCancellationPolicy.New(log, deps.Validator, deps.Storage))
+		r.Get("/events/{id}/cancellation-policy", getCancellationPolicy.New(log, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Post("/promo-codes", createPromoCode.New(log, deps.Validator, deps.Storage))
+		r.With(admin).Get("/promo-codes", getPromoCodes.New(log, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Post("/layouts", createLayout.New(log, deps.Validator, deps.Storage))
+		r.Get("/layouts/{id}", getLayout.New(log, deps.Storage))
+		r.With(deps.RateLimit("create_event")).Put("/events/{id}/layout", setEventLayout.New(log, deps.Validator, deps.Storage))
+		r.Get("/events/{id}/seats", getSeatMap.New(log, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Post("/series", createSeries.New(log, deps.Validator, deps.Storage, deps.SeriesHorizon))
+		r.Get("/series/{id}", getSeries.New(log, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Put("/series/{id}/events/{event_id}", updateSeriesEvent.New(log, deps.Validator, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Post("/series/{id}/exceptions", addSeriesException.New(log, deps.Validator, deps.Storage))
+		r.With(deps.RateLimit("book")).Post("/series/{id}/book", bookSeries.New(log, deps.Validator, bookings))
+		r.With(deps.RateLimit("create_event"), admin).Post("/venues", createVenue.New(log, deps.Validator, deps.Storage))
+		r.Get("/venues", getVenues.New(log, deps.Storage))
+		r.Get("/venues/{id}", getVenue.New(log, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Put("/venues/{id}", updateVenue.New(log, deps.Validator, deps.Storage))
+		r.With(deps.RateLimit("create_event"), admin).Delete("/venues/{id}", deleteVenue.New(log, deps.Storage))
+		r.Get("/events/{id}", byFormat("ics",
+			eventFeed.New(log, deps.Storage, deps.CalendarDomain),
+			getEventInfo.New(log, deps.Storage)))
+		r.With(admin).Get("/events/{id}/attendees", getAttendees.New(log, deps.Storage))
+		r.With(admin).Get("/notifications", getNotifications.New(log, deps.Storage))
+		r.Get("/events", byFormat("ics",
+			scheduleFeed.New(log, deps.Storage, deps.CalendarDomain),
+			getAllEvents.New(log, deps.Storage)))
+
+		if deps.Calendars != nil {
+			r.Get("/calendars/{token}", userFeed.New(log, deps.Storage, deps.Calendars, deps.CalendarDomain))
+		}
+
+		if deps.Tickets != nil {
+			r.Get("/events/{id}/ticket", getTicket.New(log, deps.Storage, deps.Tickets))
+			r.Get("/bookings/{id}/ticket", getTicketPDF.New(log, deps.Storage, deps.Tickets))
+			r.With(deps.RateLimit("checkin")).Post("/checkin", checkIn.New(log, deps.Validator, deps.Storage, deps.Tickets))
+			r.Get("/tickets/public-key", publicKey.New(deps.Tickets))
+		}
+
+		if deps.Payments != nil {
+			r.With(deps.RateLimit("pay")).Post("/events/{id}/pay", createPayment.New(log, deps.Validator, deps.Storage, deps.Payments))
+			r.Post("/payments/webhook", paymentWebhook.New(log, deps.Storage, deps.Payments))
+			r.With(deps.RateLimit("refund"), admin).Post("/payments/{id}/refunds", refundPayment.New(log, deps.Validator, deps.Storage, deps.Payments))
+			r.With(admin).Get("/events/{id}/refunds", getRefunds.New(log, deps.Storage))
+		}
+	}
+}
+
+// byFormat serves requests with the format extension, e.g. /events.ics,
+// with feed and all others with def. It relies on middleware.URLFormat.
+func byFormat(format string, feed, def http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if f, _ := r.Context().Value(middleware.URLFormatCtxKey).(string); f == format {
+			feed(w, r)
+			return
+		}
+
+		def(w, r)
+	}
+}
+
+// paidBookings confirms bookings of priced ticket types only through the
+// payment webhook, and refunds paid bookings when they are cancelled.
+type paidBookings struct {
+	Bookings
+	store interface {
+		PendingBookingPrice(ctx context.Context, eventID int, userID string) (int64, error)
+		PendingRefunds(ctx context.Context, eventID int, userID string) ([]models.Refund, error)
+		payment.RefundCompleter
+	}
+	provider payment.Provider
+	log      *slog.Logger
+}
+
+func (b paidBookings) ConfirmBooking(ctx context.Context, eventID int, userID string) error {
+	price, err := b.store.PendingBookingPrice(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	if price > 0 {
+		return fmt.Errorf("payment required")
+	}
+
+	return b.Bookings.ConfirmBooking(ctx, eventID, userID)
+}
+
+// CancelBooking cancels the booking and sends the refund the storage
+// recorded for it, if any. A failed refund does not undo the cancellation:
+// it stays recorded as failed for an admin to issue again. Refunds left
+// pending, e.g. by a crash, are resent by the sweeper.
+func (b paidBookings) CancelBooking(ctx context.Context, eventID int, userID string) error {
+	if err := b.Bookings.CancelBooking(ctx, eventID, userID); err != nil {
+		return err
+	}
+
+	refunds, err := b.store.PendingRefunds(ctx, eventID, userID)
+	if err != nil {
+		b.log.Error("failed to get pending refunds", slog.Int("event_id", eventID), sl.Err(err))
+		return nil
+	}
+
+	for _, refund := range refunds {
+		if err = payment.ExecuteRefund(ctx, b.provider, b.store, refund); err != nil {
+			b.log.Error("failed to refund cancelled booking", slog.Int("refund_id", refund.ID), sl.Err(err))
+		}
+	}
+
+	return nil
+}
+
+// paidStatuses sends the refunds the storage recorded for the paid bookings
+// of an event when it is cancelled. Failed refunds do not undo the
+// cancellation: they stay recorded as failed for an admin to issue again.
+// Refunds left pending, e.g. by a crash, are resent by the sweeper.
+type paidStatuses struct {
+	setEventStatus.EventStatusSetter
+	store interface {
+		getRefunds.RefundsGetter
+		payment.RefundCompleter
+	}
+	provider payment.Provider
+	log      *slog.Logger
+}
+
+func (s paidStatuses) SetEventStatus(ctx context.Context, eventID int, status string) (int, error) {
+	cancelled, err := s.EventStatusSetter.SetEventStatus(ctx, eventID, status)
+	if err != nil || status != models.EventCancelled {
+		return cancelled, err
+	}
+
+	refunds, err := s.store.GetRefunds(ctx, eventID)
+	if err != nil {
+		s.log.Error("failed to get refunds", slog.Int("event_id", eventID), sl.Err(err))
+		return cancelled, nil
+	}
+
+	for _, refund := range refunds {
+		if refund.Reason != models.RefundEventCancelled || refund.Status != models.RefundPending {
+			continue
+		}
+
+		if err = payment.ExecuteRefund(ctx, s.provider, s.store, refund); err != nil {
+			s.log.Error("failed to refund booking of cancelled event", slog.Int("refund_id", refund.ID), sl.Err(err))
+		}
+	}
+
+	return cancelled, nil
+}
+
+// confirmLinks returns the links of the features enabled in deps.
+func confirmLinks(deps Deps) confirmBooking.Links {
+	var links confirmBooking.Links
+
+	if deps.Calendars != nil {
+		links.Calendar = func(userID string) string {
+			return CalendarPath(deps.Calendars, userID)
+		}
+	}
+
+	if deps.Tickets != nil {
+		links.Ticket = ticketPath
+	}
+
+	return links
+}
+
+// CalendarPath returns the path of the user's private calendar feed.
+func CalendarPath(calendars *feedtoken.Signer, userID string) string {
+	return Prefix + "/calendars/" + calendars.Sign(userID) + ".ics"
+}
+
+// ticketPath returns the path of the QR code of the ticket for the event. It
+// is served to the user authenticated by the API key.
+func ticketPath(eventID int) string {
+	return Prefix + "/events/" + strconv.Itoa(eventID) + "/ticket.png"
+}
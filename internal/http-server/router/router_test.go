@@ -0,0 +1,47 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacyOnly(t *testing.T) {
+	t.Parallel()
+
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	mux := chi.NewRouter()
+	mux.Use(middleware.URLFormat)
+	mux.Group(func(r chi.Router) {
+		r.Use(LegacyOnly)
+		r.Get("/events", ok)
+		r.Get("/events/{id}", ok)
+		r.Post("/events/{id}/book", ok)
+		r.Post("/events/{id}/cancel", ok)
+		r.Get("/venues", ok)
+	})
+
+	testCases := []struct {
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{method: http.MethodGet, path: "/events", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/events/1", expectedStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/events/1/book", expectedStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/events/1/cancel", expectedStatus: http.StatusNotFound},
+		{method: http.MethodGet, path: "/venues", expectedStatus: http.StatusNotFound},
+		{method: http.MethodGet, path: "/events.ics", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.expectedStatus, rr.Code, tc.method+" "+tc.path)
+	}
+}
@@ -0,0 +1,1743 @@
+// Package routertest runs the real API router against an in-memory store,
+// for end-to-end tests of API clients.
+package routertest
+
+import (
+	"context"
+	"encoding/base64"
+	"eventBooker/internal/config"
+	"eventBooker/internal/http-server/router"
+	"eventBooker/internal/lib/feedtoken"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"eventBooker/internal/lib/payment"
+	"eventBooker/internal/lib/ticket"
+	"eventBooker/internal/lib/validate"
+	"eventBooker/internal/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ticketKey is the signing key of the tickets issued by NewServer.
+var ticketKey = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("t", 32)))
+
+// NewServer starts a server with the API mounted under router.Prefix and
+// the checkout pages of the fake payment provider under /payments/fake.
+// It is closed when the test ends.
+func NewServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	log := slogdiscard.NewDiscardLogger()
+	store := NewStore()
+
+	tickets, err := ticket.ParseKey(ticketKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := chi.NewRouter()
+	srv := httptest.NewUnstartedServer(mux)
+
+	payments := payment.NewFake("test-secret", "/payments/fake",
+		"http://"+srv.Listener.Addr().String()+router.Prefix+"/payments/webhook")
+
+	mux.Use(middleware.RequestID)
+	mux.Use(middleware.URLFormat)
+	mux.HandleFunc("/payments/fake/{id}", payments.CheckoutHandler(log))
+	mux.Route(router.Prefix, router.API(log, router.Deps{
+		Validator:      validate.New(config.Validation{MaxSeats: 1000}),
+		Storage:        store,
+		Bookings:       store,
+		RateLimit:      func(string) func(http.Handler) http.Handler { return passThrough },
+		APIKeys:        map[string]string{AdminKey: AdminID, UserKey: UserID},
+		IdempotencyTTL: time.Hour,
+		// Matches the server's write timeout.
+		IdempotencyLockTimeout: 4 * time.Second,
+		SeriesHorizon:          90 * 24 * time.Hour,
+		Calendars:              feedtoken.New("test-secret"),
+		CalendarDomain:         "test.example",
+		Tickets:                tickets,
+		Payments:               payments,
+	}))
+
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func passThrough(next http.Handler) http.Handler { return next }
+
+// Store is an in-memory router.Storage and router.Bookings. It reports
+// the same errors as the postgres storage.
+type Store struct {
+	mu          sync.Mutex
+	events      []models.Event
+	ticketTypes []models.TicketType
+	bookings    []models.Booking
+	payments    []models.Payment
+	refunds     []models.Refund
+	policies    map[int]models.CancellationPolicy
+	promoCodes  []models.PromoCode
+	redemptions map[int]redemption
+	layouts     []models.SeatLayout
+	venues      map[int]models.Venue
+	lastID      int
+	lastSeatID  int
+	lastVenueID int
+	keys        map[string]models.IdempotencyKey
+	series      []models.Series
+	// occurrences are the occurrence dates of series events, by event id.
+	occurrences   map[int]time.Time
+	notifications []models.Notification
+}
+
+// redemption is the use of a promo code by a booking, keyed by the booking's id.
+type redemption struct {
+	promoCodeID int
+	userID      string
+	discount    int64
+}
+
+// AdminID is the user NewStore grants the admin role.
+const AdminID = "admin"
+
+// AdminKey and UserKey are the API keys NewServer accepts for AdminID and
+// UserID, a user without roles.
+const (
+	AdminKey = "admin-key"
+	UserKey  = "user-key"
+	UserID   = "user"
+)
+
+func NewStore() *Store {
+	return &Store{
+		policies:    map[int]models.CancellationPolicy{},
+		redemptions: map[int]redemption{},
+		venues:      map[int]models.Venue{},
+		keys:        map[string]models.IdempotencyKey{},
+		occurrences: map[int]time.Time{},
+	}
+}
+
+func (s *Store) CreateEvent(_ context.Context, title string, date time.Time, totalSeats, deadline, venueID int, timezone string, schedule models.Schedule) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if schedule.Status == "" {
+		schedule.Status = models.EventPublished
+	}
+
+	// An empty Timezone is resolved by event, like the NULL zone in postgres.
+	event := models.Event{
+		ID:         len(s.events) + 1,
+		Title:      title,
+		Date:       date,
+		TotalSeats: totalSeats,
+		Deadline:   deadline,
+		Timezone:   timezone,
+		Schedule:   schedule,
+	}
+
+	if venueID != 0 {
+		venue, ok := s.venues[venueID]
+		if !ok {
+			return 0, fmt.Errorf("venue not found")
+		}
+		if event.TotalSeats == 0 {
+			event.TotalSeats = venue.Capacity
+		}
+		if event.TotalSeats > venue.Capacity {
+			return 0, fmt.Errorf("event exceeds venue capacity")
+		}
+		event.VenueID = &venueID
+
+		if venue.LayoutID != nil {
+			layout, err := s.layout(*venue.LayoutID)
+			if err != nil {
+				return 0, err
+			}
+			event.LayoutID = venue.LayoutID
+			event.TotalSeats = layout.SeatCount()
+		}
+	}
+
+	s.events = append(s.events, event)
+
+	return event.ID, nil
+}
+
+func (s *Store) ImportEvents(_ context.Context, events []models.Event) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]int, 0, len(events))
+	for _, e := range events {
+		e.ID = len(s.events) + 1
+		e.BookedSeats = 0
+		e.Schedule = models.Schedule{Status: models.EventPublished}
+		s.events = append(s.events, e)
+		ids = append(ids, e.ID)
+	}
+
+	return ids, nil
+}
+
+func (s *Store) CreateTicketType(_ context.Context, t models.TicketType) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.event(t.EventID); err != nil {
+		return 0, err
+	}
+	for _, existing := range s.ticketTypes {
+		if existing.EventID == t.EventID && existing.Name == t.Name {
+			return 0, fmt.Errorf("ticket type already exists")
+		}
+	}
+
+	t.ID = len(s.ticketTypes) + 1
+	s.ticketTypes = append(s.ticketTypes, t)
+
+	return t.ID, nil
+}
+
+func (s *Store) CreatePromoCode(_ context.Context, p models.PromoCode) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p.TicketTypeID != nil {
+		id := *p.TicketTypeID
+		if id < 1 || id > len(s.ticketTypes) {
+			return 0, fmt.Errorf("ticket type not found")
+		}
+		eventID := s.ticketTypes[id-1].EventID
+		if p.EventID != nil && *p.EventID != eventID {
+			return 0, fmt.Errorf("ticket type not found")
+		}
+		p.EventID = &eventID
+	} else if p.EventID != nil {
+		if _, err := s.event(*p.EventID); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, existing := range s.promoCodes {
+		if existing.Code == p.Code {
+			return 0, fmt.Errorf("promo code already exists")
+		}
+	}
+
+	p.ID = len(s.promoCodes) + 1
+	p.CreatedAt = time.Now()
+	s.promoCodes = append(s.promoCodes, p)
+
+	return p.ID, nil
+}
+
+func (s *Store) GetPromoCodes(_ context.Context) ([]models.PromoCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	codes := make([]models.PromoCode, 0, len(s.promoCodes))
+	for _, p := range s.promoCodes {
+		p.Uses, _ = s.promoUses(p.ID, "")
+		codes = append(codes, p)
+	}
+
+	return codes, nil
+}
+
+func (s *Store) GetTicketTypes(_ context.Context, eventID int) ([]models.TicketType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := s.event(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketTypes := make([]models.TicketType, 0)
+	for _, t := range s.ticketTypes {
+		if t.EventID != eventID {
+			continue
+		}
+
+		t = s.ticketType(t)
+		t.RemainingSeats = t.Remaining(event.TotalSeats - event.BookedSeats)
+		ticketTypes = append(ticketTypes, t)
+	}
+
+	sort.SliceStable(ticketTypes, func(i, j int) bool {
+		return ticketTypes[i].Price < ticketTypes[j].Price
+	})
+
+	return ticketTypes, nil
+}
+
+func (s *Store) ListEvents(_ context.Context, limit, offset, venueID int, day string, includeDrafts bool) ([]models.Event, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := s.allEvents()
+	matching := make([]models.Event, 0, len(events))
+	for _, e := range events {
+		if venueID != 0 && (e.VenueID == nil || *e.VenueID != venueID) {
+			continue
+		}
+		if day != "" && !onDay(e, day) {
+			continue
+		}
+		if !includeDrafts && e.Status == models.EventDraft {
+			continue
+		}
+		matching = append(matching, e)
+	}
+	events = matching
+	total := len(events)
+
+	if offset > total {
+		offset = total
+	}
+	events = events[offset:]
+	// A zero limit returns all events, like LIMIT NULL in postgres.
+	if limit > 0 && limit < len(events) {
+		events = events[:limit]
+	}
+
+	return events, total, nil
+}
+
+func (s *Store) GetAllEvents(_ context.Context) ([]models.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.DeleteFunc(s.allEvents(), func(e models.Event) bool {
+		return e.Status == models.EventDraft
+	}), nil
+}
+
+func (s *Store) GetEvent(_ context.Context, id int) (*models.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := s.event(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (s *Store) GetUserConfirmedEvents(_ context.Context, userID string) ([]models.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]models.Event, 0)
+	for _, e := range s.allEvents() {
+		for _, b := range s.bookings {
+			if b.EventID == e.ID && b.UserID == userID && b.Confirmed {
+				events = append(events, e)
+				break
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func (s *Store) GetEventWithBookings(_ context.Context, eventID int) (*models.Event, []models.Booking, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := s.event(eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var bookings []models.Booking
+	for i := len(s.bookings) - 1; i >= 0; i-- {
+		if s.bookings[i].EventID == eventID {
+			bookings = append(bookings, s.bookings[i])
+		}
+	}
+
+	return &event, bookings, nil
+}
+
+func (s *Store) StreamBookings(_ context.Context, eventID int, status string, fn func(models.Booking) error) error {
+	s.mu.Lock()
+	if _, err := s.event(eventID); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	var bookings []models.Booking
+	for _, b := range s.bookings {
+		if b.EventID == eventID && (status == "" || b.Status() == status) {
+			bookings = append(bookings, b)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, b := range bookings {
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) BookEvent(_ context.Context, eventID int, userID string, ticketTypeID int, promoCode string, seatID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	booking, err := s.newBooking(eventID, userID, ticketTypeID, seatID)
+	if err != nil {
+		return err
+	}
+
+	if promoCode != "" {
+		if err = s.redeem(booking, promoCode); err != nil {
+			return err
+		}
+	}
+
+	s.lastID++
+	s.bookings = append(s.bookings, booking)
+
+	return nil
+}
+
+func (s *Store) CreateSeries(_ context.Context, sr models.Series, horizon time.Time) (int, []int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sr.VenueID != nil {
+		venue, ok := s.venues[*sr.VenueID]
+		if !ok {
+			return 0, nil, fmt.Errorf("venue not found")
+		}
+		if sr.TotalSeats == 0 {
+			sr.TotalSeats = venue.Capacity
+		}
+		if sr.TotalSeats > venue.Capacity {
+			return 0, nil, fmt.Errorf("event exceeds venue capacity")
+		}
+		if sr.Timezone == "" {
+			sr.Timezone = venue.Timezone
+		}
+	}
+	if sr.Timezone == "" {
+		sr.Timezone = "UTC"
+	}
+
+	sr.ID = len(s.series) + 1
+	sr.Exceptions = append([]time.Time{}, sr.Exceptions...)
+	sr.GeneratedUntil = horizon
+	sr.CreatedAt = time.Now()
+	s.series = append(s.series, sr)
+
+	ids, err := s.createOccurrences(sr, sr.Start, horizon)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return sr.ID, ids, nil
+}
+
+func (s *Store) GetSeries(_ context.Context, id int) (*models.Series, []models.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sr, err := s.seriesByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make([]models.Event, 0)
+	for _, e := range s.allEvents() {
+		if e.SeriesID != nil && *e.SeriesID == id {
+			events = append(events, e)
+		}
+	}
+
+	return &sr, events, nil
+}
+
+func (s *Store) UpdateSeriesEvent(_ context.Context, seriesID, eventID int, scope string, change models.Event) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sr, err := s.seriesByID(seriesID)
+	if err != nil {
+		return 0, err
+	}
+
+	event, err := s.event(eventID)
+	if err != nil || event.SeriesID == nil || *event.SeriesID != seriesID {
+		return 0, fmt.Errorf("occurrence not found")
+	}
+	occurrence := s.occurrences[eventID]
+
+	if scope == models.ScopeThis {
+		if event.LayoutID == nil && event.VenueID != nil && change.TotalSeats > s.venues[*event.VenueID].Capacity {
+			return 0, fmt.Errorf("event exceeds venue capacity")
+		}
+
+		e := &s.events[eventID-1]
+		e.Title, e.Date, e.Deadline = change.Title, change.Date, change.Deadline
+		if e.LayoutID == nil {
+			e.TotalSeats = change.TotalSeats
+		}
+
+		return seriesID, nil
+	}
+
+	if !sr.SameDay(change.Date, occurrence) {
+		return 0, fmt.Errorf("occurrence moved to another day")
+	}
+	if sr.VenueID != nil && change.TotalSeats > s.venues[*sr.VenueID].Capacity {
+		return 0, fmt.Errorf("event exceeds venue capacity")
+	}
+
+	target := &s.series[seriesID-1]
+	if !occurrence.Equal(sr.Start) {
+		rule, err := sr.RuleFrom(occurrence)
+		if err != nil {
+			return 0, err
+		}
+
+		split := sr
+		split.ID = len(s.series) + 1
+		split.Rule = rule
+		split.Exceptions = []time.Time{}
+		split.CreatedAt = time.Now()
+		s.series[seriesID-1].EndsAt = &occurrence
+		s.series = append(s.series, split)
+		target = &s.series[split.ID-1]
+	}
+
+	target.Title, target.Start, target.TotalSeats, target.Deadline = change.Title, change.Date, change.TotalSeats, change.Deadline
+
+	// Later exceptions skip the same days of the edited occurrences.
+	old := &s.series[seriesID-1]
+	old.Exceptions = slices.DeleteFunc(old.Exceptions, func(d time.Time) bool {
+		return !d.Before(occurrence)
+	})
+	for _, d := range sr.Exceptions {
+		if !d.Before(occurrence) {
+			target.Exceptions = append(target.Exceptions, sr.Reclock(d, change.Date).UTC())
+		}
+	}
+
+	targetID := target.ID
+	for i, e := range s.events {
+		if e.SeriesID == nil || *e.SeriesID != seriesID || s.occurrences[e.ID].Before(occurrence) {
+			continue
+		}
+
+		date := sr.Reclock(s.occurrences[e.ID], change.Date)
+		s.occurrences[e.ID] = date
+		e.SeriesID = &targetID
+		e.Title, e.Date, e.Deadline = change.Title, date, change.Deadline
+		if e.LayoutID == nil {
+			e.TotalSeats = change.TotalSeats
+		}
+		s.events[i] = e
+	}
+
+	return targetID, nil
+}
+
+func (s *Store) AddSeriesException(_ context.Context, seriesID int, date time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sr, err := s.seriesByID(seriesID)
+	if err != nil {
+		return err
+	}
+
+	dates, err := sr.Occurrences(date, date.Add(time.Nanosecond))
+	if err != nil {
+		return err
+	}
+	if len(dates) == 0 {
+		if slices.ContainsFunc(sr.Exceptions, date.Equal) {
+			return nil
+		}
+		return fmt.Errorf("date is not an occurrence of the series")
+	}
+
+	s.series[seriesID-1].Exceptions = append(s.series[seriesID-1].Exceptions, date)
+
+	for _, e := range s.events {
+		if e.SeriesID != nil && *e.SeriesID == seriesID && s.occurrences[e.ID].Equal(date) && models.CanTransition(e.Status, models.EventCancelled) {
+			s.events[e.ID-1].Status = models.EventCancelled
+			s.cancelEventBookings(e)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) BookSeries(_ context.Context, seriesID int, userID string) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.seriesByID(seriesID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ids := make([]int, 0)
+	for _, e := range s.allEvents() {
+		if e.SeriesID != nil && *e.SeriesID == seriesID && e.Date.After(now) && e.Status == models.EventPublished {
+			ids = append(ids, e.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("series has no upcoming occurrences")
+	}
+	for _, id := range ids {
+		event, _ := s.event(id)
+		hasTypes := slices.ContainsFunc(s.ticketTypes, func(t models.TicketType) bool { return t.EventID == id })
+		if event.LayoutID != nil || hasTypes {
+			return nil, fmt.Errorf("series must be booked by occurrence")
+		}
+	}
+
+	// Either every occurrence is booked or none is, like the postgres transaction.
+	bookings, lastID := len(s.bookings), s.lastID
+	for _, id := range ids {
+		booking, err := s.newBooking(id, userID, 0, 0)
+		if err != nil {
+			s.bookings, s.lastID = s.bookings[:bookings], lastID
+			return nil, err
+		}
+		s.lastID++
+		s.bookings = append(s.bookings, booking)
+	}
+
+	return ids, nil
+}
+
+func (s *Store) CreateSeatLayout(_ context.Context, layout models.SeatLayout) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	layout.ID = len(s.layouts) + 1
+	layout.CreatedAt = time.Now()
+	layout.Sections = copySections(layout.Sections)
+	for _, section := range layout.Sections {
+		for _, row := range section.Rows {
+			for i := range row.Seats {
+				s.lastSeatID++
+				row.Seats[i].ID = s.lastSeatID
+			}
+		}
+	}
+
+	s.layouts = append(s.layouts, layout)
+
+	return layout.ID, nil
+}
+
+func (s *Store) GetSeatLayout(_ context.Context, id int) (*models.SeatLayout, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	layout, err := s.layout(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &layout, nil
+}
+
+func (s *Store) SetEventLayout(_ context.Context, eventID, layoutID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.event(eventID); err != nil {
+		return err
+	}
+	for _, b := range s.bookings {
+		if b.EventID == eventID {
+			return fmt.Errorf("event already has bookings")
+		}
+	}
+
+	layout, err := s.layout(layoutID)
+	if err != nil {
+		return err
+	}
+
+	s.events[eventID-1].LayoutID = &layoutID
+	s.events[eventID-1].TotalSeats = layout.SeatCount()
+
+	return nil
+}
+
+func (s *Store) GetSeatMap(_ context.Context, eventID int) (*models.SeatLayout, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := s.event(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.LayoutID == nil {
+		return nil, fmt.Errorf("event has no seat layout")
+	}
+
+	layout, err := s.layout(*event.LayoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range layout.Sections {
+		for _, row := range section.Rows {
+			for i, seat := range row.Seats {
+				row.Seats[i].Status = models.SeatAvailable
+				if b := s.seatBooking(eventID, seat.ID); b != nil {
+					row.Seats[i].Status = models.SeatHeld
+					if b.Confirmed {
+						row.Seats[i].Status = models.SeatBooked
+					}
+				}
+			}
+		}
+	}
+
+	return &layout, nil
+}
+
+func (s *Store) CreateVenue(_ context.Context, v models.Venue) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkVenueLayout(v); err != nil {
+		return 0, err
+	}
+
+	s.lastVenueID++
+	v.ID = s.lastVenueID
+	v.CreatedAt = time.Now()
+	s.venues[v.ID] = v
+
+	return v.ID, nil
+}
+
+func (s *Store) GetVenue(_ context.Context, id int) (*models.Venue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.venues[id]
+	if !ok {
+		return nil, fmt.Errorf("venue not found")
+	}
+
+	return &v, nil
+}
+
+func (s *Store) GetVenues(_ context.Context) ([]models.Venue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	venues := make([]models.Venue, 0, len(s.venues))
+	for _, v := range s.venues {
+		venues = append(venues, v)
+	}
+
+	sort.Slice(venues, func(i, j int) bool {
+		if venues[i].Name != venues[j].Name {
+			return venues[i].Name < venues[j].Name
+		}
+		return venues[i].ID < venues[j].ID
+	})
+
+	return venues, nil
+}
+
+func (s *Store) UpdateVenue(_ context.Context, v models.Venue) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.venues[v.ID]
+	if !ok {
+		return fmt.Errorf("venue not found")
+	}
+
+	for _, e := range s.events {
+		if e.VenueID != nil && *e.VenueID == v.ID && e.TotalSeats > v.Capacity {
+			return fmt.Errorf("venue capacity below event seats")
+		}
+	}
+	for _, sr := range s.series {
+		if sr.VenueID != nil && *sr.VenueID == v.ID && sr.TotalSeats > v.Capacity {
+			return fmt.Errorf("venue capacity below event seats")
+		}
+	}
+
+	if err := s.checkVenueLayout(v); err != nil {
+		return err
+	}
+
+	v.CreatedAt = old.CreatedAt
+	s.venues[v.ID] = v
+
+	return nil
+}
+
+func (s *Store) DeleteVenue(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.venues[id]; !ok {
+		return fmt.Errorf("venue not found")
+	}
+
+	for _, e := range s.events {
+		if e.VenueID != nil && *e.VenueID == id {
+			return fmt.Errorf("venue has events")
+		}
+	}
+	for _, sr := range s.series {
+		if sr.VenueID != nil && *sr.VenueID == id {
+			return fmt.Errorf("venue has events")
+		}
+	}
+
+	delete(s.venues, id)
+
+	return nil
+}
+
+func (s *Store) ConfirmBooking(_ context.Context, eventID int, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(eventID, userID, false)
+	if i < 0 {
+		return fmt.Errorf("no pending booking found")
+	}
+
+	event, err := s.event(eventID)
+	if err != nil {
+		return err
+	}
+	if event.BookedSeats >= event.TotalSeats {
+		return fmt.Errorf("no available seats")
+	}
+	if id := s.bookings[i].TicketTypeID; id != nil {
+		if err = s.checkTicketType(eventID, *id, false); err != nil {
+			return err
+		}
+	}
+
+	s.bookings[i].Confirmed = true
+
+	return nil
+}
+
+func (s *Store) CancelBooking(_ context.Context, eventID int, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(eventID, userID, false)
+	if i < 0 {
+		i = s.find(eventID, userID, true)
+	}
+	if i < 0 {
+		return fmt.Errorf("no booking found")
+	}
+
+	s.recordCancellationRefund(s.bookings[i])
+	delete(s.redemptions, s.bookings[i].ID)
+	s.bookings = append(s.bookings[:i], s.bookings[i+1:]...)
+
+	return nil
+}
+
+func (s *Store) SetEventStatus(_ context.Context, eventID int, status string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := s.event(eventID)
+	if err != nil {
+		return 0, err
+	}
+	if !models.CanTransition(event.Status, status) {
+		return 0, fmt.Errorf("invalid status transition")
+	}
+
+	s.events[eventID-1].Status = status
+	if status != models.EventCancelled {
+		return 0, nil
+	}
+
+	return s.cancelEventBookings(event), nil
+}
+
+// cancelEventBookings deletes the bookings of the cancelled event. Like the
+// postgres cancellation, paid bookings are refunded in full and each user is
+// notified once. s.mu must be held.
+func (s *Store) cancelEventBookings(event models.Event) int {
+	eventID := event.ID
+	message := models.EventCancelledMessage(event.Title, event.Date)
+	notified := map[string]bool{}
+	bookings := len(s.bookings)
+	for _, b := range s.bookings {
+		if b.EventID != eventID {
+			continue
+		}
+
+		for _, p := range s.payments {
+			if p.BookingID == nil || *p.BookingID != b.ID || p.Status != models.PaymentSucceeded {
+				continue
+			}
+			if amount := p.Amount - s.refunded(p.ID); amount > 0 {
+				s.addRefund(p, models.Refund{Amount: amount, Reason: models.RefundEventCancelled})
+			}
+		}
+
+		if !notified[b.UserID] {
+			notified[b.UserID] = true
+			s.notifications = append(s.notifications, models.Notification{
+				ID:        len(s.notifications) + 1,
+				UserID:    b.UserID,
+				EventID:   eventID,
+				Kind:      models.NotificationEventCancelled,
+				Message:   message,
+				CreatedAt: time.Now(),
+			})
+		}
+
+		delete(s.redemptions, b.ID)
+	}
+	s.bookings = slices.DeleteFunc(s.bookings, func(b models.Booking) bool {
+		return b.EventID == eventID
+	})
+
+	return bookings - len(s.bookings)
+}
+
+func (s *Store) GetNotifications(_ context.Context, userID string) ([]models.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notifications := make([]models.Notification, 0)
+	for i := len(s.notifications) - 1; i >= 0; i-- {
+		if s.notifications[i].UserID == userID {
+			notifications = append(notifications, s.notifications[i])
+		}
+	}
+
+	return notifications, nil
+}
+
+func (s *Store) GetConfirmedBooking(_ context.Context, eventID int, userID string) (*models.Booking, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(eventID, userID, true)
+	if i < 0 {
+		return nil, fmt.Errorf("no confirmed booking found")
+	}
+
+	booking := s.bookings[i]
+
+	return &booking, nil
+}
+
+func (s *Store) GetConfirmedBookingByID(_ context.Context, id int) (*models.Booking, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, b := range s.bookings {
+		if b.ID == id && b.Confirmed {
+			return &b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no confirmed booking found")
+}
+
+func (s *Store) CheckIn(_ context.Context, bookingID, eventID int, userID string) (*models.Booking, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, b := range s.bookings {
+		if b.ID != bookingID || b.EventID != eventID || b.UserID != userID || !b.Confirmed {
+			continue
+		}
+		if b.CheckedInAt != nil {
+			return nil, fmt.Errorf("ticket already used")
+		}
+
+		now := time.Now()
+		s.bookings[i].CheckedInAt = &now
+		booking := s.bookings[i]
+
+		return &booking, nil
+	}
+
+	return nil, fmt.Errorf("no confirmed booking found")
+}
+
+func (s *Store) PendingBookingPrice(_ context.Context, eventID int, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(eventID, userID, false)
+	if i < 0 {
+		return 0, fmt.Errorf("no pending booking found")
+	}
+
+	price, _ := s.price(s.bookings[i])
+
+	return price, nil
+}
+
+func (s *Store) CreatePayment(_ context.Context, eventID int, userID, provider string) (*models.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(eventID, userID, false)
+	if i < 0 {
+		return nil, fmt.Errorf("no pending booking found")
+	}
+
+	price, currency := s.price(s.bookings[i])
+	if price == 0 {
+		return nil, fmt.Errorf("payment not required")
+	}
+
+	bookingID := s.bookings[i].ID
+	now := time.Now()
+	p := models.Payment{
+		ID:        len(s.payments) + 1,
+		BookingID: &bookingID,
+		EventID:   eventID,
+		UserID:    userID,
+		Provider:  provider,
+		Amount:    price,
+		Currency:  currency,
+		Status:    models.PaymentPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.payments = append(s.payments, p)
+
+	return &p, nil
+}
+
+func (s *Store) SetPaymentProviderID(_ context.Context, id int, providerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id >= 1 && id <= len(s.payments) {
+		s.payments[id-1].ProviderID = providerID
+	}
+
+	return nil
+}
+
+func (s *Store) CompletePayment(_ context.Context, provider, providerID string, succeeded bool) (*models.Payment, *models.Refund, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := -1
+	for j, p := range s.payments {
+		if p.Provider == provider && p.ProviderID == providerID {
+			i = j
+		}
+	}
+	if i < 0 {
+		return nil, nil, fmt.Errorf("payment not found")
+	}
+
+	p := &s.payments[i]
+	if p.Status != models.PaymentPending {
+		payment := *p
+		return &payment, nil, nil
+	}
+
+	p.Status = models.PaymentFailed
+	if succeeded {
+		p.Status = models.PaymentSucceeded
+	}
+	p.UpdatedAt = time.Now()
+
+	var err error
+	if succeeded {
+		err = s.confirmPaid(p.BookingID)
+	}
+
+	payment := *p
+	if err != nil {
+		refund := s.addRefund(payment, models.Refund{Amount: payment.Amount, Reason: models.RefundUnconfirmable})
+		return &payment, &refund, err
+	}
+
+	return &payment, nil, nil
+}
+
+func (s *Store) SetCancellationPolicy(_ context.Context, p models.CancellationPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.event(p.EventID); err != nil {
+		return err
+	}
+
+	s.policies[p.EventID] = p
+
+	return nil
+}
+
+func (s *Store) GetCancellationPolicy(_ context.Context, eventID int) (*models.CancellationPolicy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.event(eventID); err != nil {
+		return nil, err
+	}
+
+	p := s.policies[eventID]
+	p.EventID = eventID
+
+	return &p, nil
+}
+
+func (s *Store) HasRole(_ context.Context, userID, role string) (bool, error) {
+	return userID == AdminID && role == models.RoleAdmin, nil
+}
+
+func (s *Store) CreateRefund(_ context.Context, r models.Refund) (*models.Refund, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.PaymentID < 1 || r.PaymentID > len(s.payments) {
+		return nil, fmt.Errorf("payment not found")
+	}
+
+	p := s.payments[r.PaymentID-1]
+	if p.Status != models.PaymentSucceeded {
+		return nil, fmt.Errorf("payment not refundable")
+	}
+
+	rest := p.Amount - s.refunded(p.ID)
+	if r.Amount == 0 {
+		r.Amount = rest
+	}
+	if r.Amount <= 0 || r.Amount > rest {
+		return nil, fmt.Errorf("refund exceeds payment")
+	}
+
+	refund := s.addRefund(p, r)
+
+	return &refund, nil
+}
+
+func (s *Store) ClaimRefund(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 1 || id > len(s.refunds) || s.refunds[id-1].Status != models.RefundPending {
+		return fmt.Errorf("refund not pending")
+	}
+
+	s.refunds[id-1].Status = models.RefundSending
+	s.refunds[id-1].UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (s *Store) ClaimStaleRefunds(_ context.Context, t time.Time) ([]models.Refund, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claimed := make([]models.Refund, 0)
+	for i, r := range s.refunds {
+		if (r.Status == models.RefundPending && r.CreatedAt.Before(t)) || (r.Status == models.RefundSending && r.UpdatedAt.Before(t)) {
+			s.refunds[i].Status = models.RefundSending
+			s.refunds[i].UpdatedAt = time.Now()
+			claimed = append(claimed, s.refunds[i])
+		}
+	}
+
+	return claimed, nil
+}
+
+func (s *Store) CompleteRefund(_ context.Context, id int, succeeded bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 1 || id > len(s.refunds) || s.refunds[id-1].Status != models.RefundSending {
+		return fmt.Errorf("refund not found")
+	}
+
+	r := &s.refunds[id-1]
+	r.Status = models.RefundFailed
+	if succeeded {
+		r.Status = models.RefundSucceeded
+	}
+	r.UpdatedAt = time.Now()
+
+	p := &s.payments[r.PaymentID-1]
+	if succeeded && s.refundedSucceeded(p.ID) >= p.Amount {
+		p.Status = models.PaymentRefunded
+		p.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+func (s *Store) PendingRefunds(_ context.Context, eventID int, userID string) ([]models.Refund, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refunds := make([]models.Refund, 0)
+	for _, r := range s.refunds {
+		if r.EventID == eventID && r.UserID == userID && r.Status == models.RefundPending && r.Reason == models.RefundCancellation {
+			refunds = append(refunds, r)
+		}
+	}
+
+	return refunds, nil
+}
+
+func (s *Store) GetRefunds(_ context.Context, eventID int) ([]models.Refund, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refunds := make([]models.Refund, 0)
+	for _, r := range s.refunds {
+		if r.EventID == eventID {
+			refunds = append(refunds, r)
+		}
+	}
+
+	return refunds, nil
+}
+
+func (s *Store) SaveIdempotencyKey(_ context.Context, key, requestHash string, ttl, lockTimeout time.Duration) (*models.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if existing, ok := s.keys[key]; ok && existing.ExpiresAt.After(now) &&
+		(existing.Completed || existing.CreatedAt.After(now.Add(-lockTimeout))) {
+		return &existing, nil
+	}
+
+	s.keys[key] = models.IdempotencyKey{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+
+	return nil, nil
+}
+
+func (s *Store) CompleteIdempotencyKey(_ context.Context, key string, statusCode int, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.keys[key]
+	record.StatusCode = statusCode
+	record.Response = response
+	record.Completed = true
+	s.keys[key] = record
+
+	return nil
+}
+
+func (s *Store) DeleteIdempotencyKey(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, key)
+
+	return nil
+}
+
+// event returns the event with its confirmed bookings counted and its time
+// zone resolved. s.mu must be held.
+func (s *Store) event(id int) (models.Event, error) {
+	if id < 1 || id > len(s.events) {
+		return models.Event{}, fmt.Errorf("event not found")
+	}
+
+	event := s.events[id-1]
+	for _, b := range s.bookings {
+		if b.EventID == id && b.Confirmed {
+			event.BookedSeats++
+		}
+	}
+
+	if event.Timezone == "" {
+		event.Timezone = "UTC"
+		if event.VenueID != nil {
+			event.Timezone = s.venues[*event.VenueID].Timezone
+		}
+	}
+	event.Localize()
+
+	return event, nil
+}
+
+// seriesByID returns the series with its exceptions in order. s.mu must be held.
+func (s *Store) seriesByID(id int) (models.Series, error) {
+	if id < 1 || id > len(s.series) {
+		return models.Series{}, fmt.Errorf("series not found")
+	}
+
+	sr := s.series[id-1]
+	sr.Exceptions = slices.Clone(sr.Exceptions)
+	slices.SortFunc(sr.Exceptions, time.Time.Compare)
+
+	return sr, nil
+}
+
+// createOccurrences is the in-memory twin of the postgres generation of the
+// series' events in [from, to). s.mu must be held.
+func (s *Store) createOccurrences(sr models.Series, from, to time.Time) ([]int, error) {
+	dates, err := sr.Occurrences(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(dates))
+	for _, date := range dates {
+		event := models.Event{
+			ID:         len(s.events) + 1,
+			Title:      sr.Title,
+			Date:       date,
+			TotalSeats: sr.TotalSeats,
+			Deadline:   sr.Deadline,
+			VenueID:    sr.VenueID,
+			SeriesID:   &sr.ID,
+			Timezone:   sr.Timezone,
+			Schedule:   models.Schedule{Status: models.EventPublished},
+		}
+		if sr.VenueID != nil {
+			if layoutID := s.venues[*sr.VenueID].LayoutID; layoutID != nil {
+				layout, err := s.layout(*layoutID)
+				if err != nil {
+					return nil, err
+				}
+				event.LayoutID = layoutID
+				event.TotalSeats = layout.SeatCount()
+			}
+		}
+
+		s.events = append(s.events, event)
+		s.occurrences[event.ID] = date
+		ids = append(ids, event.ID)
+	}
+
+	return ids, nil
+}
+
+// deleteEvent deletes the event together with all of its bookings. Its id
+// is not reused. s.mu must be held.
+// newBooking returns a pending booking of the event after the checks of
+// the postgres insertBooking. s.mu must be held.
+func (s *Store) newBooking(eventID int, userID string, ticketTypeID, seatID int) (models.Booking, error) {
+	event, err := s.event(eventID)
+	if err != nil {
+		return models.Booking{}, err
+	}
+	if !event.OnSale(time.Now()) {
+		return models.Booking{}, fmt.Errorf("event is not on sale")
+	}
+	if event.BookedSeats >= event.TotalSeats {
+		return models.Booking{}, fmt.Errorf("no available seats")
+	}
+	if err = s.checkTicketType(eventID, ticketTypeID, true); err != nil {
+		return models.Booking{}, err
+	}
+	if s.find(eventID, userID, false) >= 0 {
+		return models.Booking{}, fmt.Errorf("user already has pending booking for this event")
+	}
+
+	booking := models.Booking{
+		ID:        s.lastID + 1,
+		EventID:   eventID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+	if ticketTypeID != 0 {
+		booking.TicketTypeID = &ticketTypeID
+	}
+
+	if err = s.holdSeat(event, seatID); err != nil {
+		return models.Booking{}, err
+	}
+	if seatID != 0 {
+		booking.SeatID = &seatID
+	}
+
+	return booking, nil
+}
+
+// onDay reports whether the event starts on day, "today" or a date in the
+// format 2006-01-02, in its own time zone. e must be localized.
+func onDay(e models.Event, day string) bool {
+	if day == "today" {
+		day = time.Now().In(e.Location()).Format(time.DateOnly)
+	}
+
+	return e.LocalDate.Format(time.DateOnly) == day
+}
+
+// ticketType returns t with its confirmed bookings counted. s.mu must be held.
+func (s *Store) ticketType(t models.TicketType) models.TicketType {
+	t.BookedSeats = 0
+	for _, b := range s.bookings {
+		if b.TicketTypeID != nil && *b.TicketTypeID == t.ID && b.Confirmed {
+			t.BookedSeats++
+		}
+	}
+
+	return t
+}
+
+// checkTicketType is the in-memory twin of the postgres check of a booking's
+// ticket type. s.mu must be held.
+func (s *Store) checkTicketType(eventID, ticketTypeID int, checkSales bool) error {
+	var found *models.TicketType
+	for i, t := range s.ticketTypes {
+		if t.EventID != eventID {
+			continue
+		}
+		if ticketTypeID == 0 {
+			return fmt.Errorf("ticket type is required")
+		}
+		if t.ID == ticketTypeID {
+			found = &s.ticketTypes[i]
+		}
+	}
+
+	if ticketTypeID == 0 {
+		return nil
+	}
+	if found == nil {
+		return fmt.Errorf("ticket type not found")
+	}
+
+	t := s.ticketType(*found)
+	if checkSales && !t.OnSale(time.Now()) {
+		return fmt.Errorf("ticket type is not on sale")
+	}
+	if t.BookedSeats >= t.Capacity {
+		return fmt.Errorf("no available seats")
+	}
+
+	return nil
+}
+
+// layout returns a copy of the seat layout. s.mu must be held.
+func (s *Store) layout(id int) (models.SeatLayout, error) {
+	if id < 1 || id > len(s.layouts) {
+		return models.SeatLayout{}, fmt.Errorf("seat layout not found")
+	}
+
+	layout := s.layouts[id-1]
+	layout.Sections = copySections(layout.Sections)
+
+	return layout, nil
+}
+
+// checkVenueLayout fails unless the venue's layout, if any, exists and
+// fits into its capacity. s.mu must be held.
+func (s *Store) checkVenueLayout(v models.Venue) error {
+	if v.LayoutID == nil {
+		return nil
+	}
+
+	layout, err := s.layout(*v.LayoutID)
+	if err != nil {
+		return err
+	}
+	if layout.SeatCount() > v.Capacity {
+		return fmt.Errorf("seat layout exceeds venue capacity")
+	}
+
+	return nil
+}
+
+// copySections returns a deep copy of the sections.
+func copySections(sections []models.SeatSection) []models.SeatSection {
+	out := make([]models.SeatSection, len(sections))
+	for i, section := range sections {
+		out[i] = models.SeatSection{Name: section.Name, Rows: make([]models.SeatRow, len(section.Rows))}
+		for j, row := range section.Rows {
+			out[i].Rows[j] = models.SeatRow{Name: row.Name, Seats: append([]models.Seat(nil), row.Seats...)}
+		}
+	}
+
+	return out
+}
+
+// holdSeat is the in-memory twin of the postgres check of a booking's seat.
+// s.mu must be held.
+func (s *Store) holdSeat(event models.Event, seatID int) error {
+	if event.LayoutID == nil {
+		if seatID != 0 {
+			return fmt.Errorf("event has no seat layout")
+		}
+		return nil
+	}
+	if seatID == 0 {
+		return fmt.Errorf("seat is required")
+	}
+
+	layout, _ := s.layout(*event.LayoutID)
+	for _, section := range layout.Sections {
+		for _, row := range section.Rows {
+			for _, seat := range row.Seats {
+				if seat.ID != seatID {
+					continue
+				}
+				if s.seatBooking(event.ID, seatID) != nil {
+					return fmt.Errorf("seat already taken")
+				}
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("seat not found")
+}
+
+// seatBooking returns the booking holding the seat of the event, or nil.
+// s.mu must be held.
+func (s *Store) seatBooking(eventID, seatID int) *models.Booking {
+	for i, b := range s.bookings {
+		if b.EventID == eventID && b.SeatID != nil && *b.SeatID == seatID {
+			return &s.bookings[i]
+		}
+	}
+
+	return nil
+}
+
+// price returns the price and currency of the booking's ticket type less
+// its promo code discount, 0 for a booking without one. s.mu must be held.
+func (s *Store) price(b models.Booking) (int64, string) {
+	price, currency := s.ticketPrice(b)
+
+	return price - s.redemptions[b.ID].discount, currency
+}
+
+// ticketPrice returns the price and currency of the booking's ticket type,
+// 0 for a booking without one. s.mu must be held.
+func (s *Store) ticketPrice(b models.Booking) (int64, string) {
+	if b.TicketTypeID == nil {
+		return 0, ""
+	}
+
+	for _, t := range s.ticketTypes {
+		if t.ID == *b.TicketTypeID {
+			return t.Price, t.Currency
+		}
+	}
+
+	return 0, ""
+}
+
+// redeem applies the promo code to the new booking like the postgres
+// storage does. s.mu must be held.
+func (s *Store) redeem(b models.Booking, code string) error {
+	i := -1
+	for j, p := range s.promoCodes {
+		if p.Code == strings.ToUpper(code) {
+			i = j
+		}
+	}
+	if i < 0 {
+		return fmt.Errorf("promo code not found")
+	}
+
+	p := s.promoCodes[i]
+	if !p.Active(time.Now()) {
+		return fmt.Errorf("promo code is not active")
+	}
+
+	price, currency := s.ticketPrice(b)
+	ticketTypeID := 0
+	if b.TicketTypeID != nil {
+		ticketTypeID = *b.TicketTypeID
+	}
+	if !p.AppliesTo(b.EventID, ticketTypeID, currency) {
+		return fmt.Errorf("promo code does not apply")
+	}
+
+	uses, userUses := s.promoUses(p.ID, b.UserID)
+	if p.MaxUses > 0 && uses >= p.MaxUses {
+		return fmt.Errorf("promo code usage limit reached")
+	}
+	if p.MaxUsesPerUser > 0 && userUses >= p.MaxUsesPerUser {
+		return fmt.Errorf("promo code already used")
+	}
+
+	s.redemptions[b.ID] = redemption{promoCodeID: p.ID, userID: b.UserID, discount: p.Discount(price)}
+
+	return nil
+}
+
+// promoUses counts the uses of the promo code in total and by the user. s.mu must be held.
+func (s *Store) promoUses(promoCodeID int, userID string) (int, int) {
+	var uses, userUses int
+	for _, r := range s.redemptions {
+		if r.promoCodeID != promoCodeID {
+			continue
+		}
+		uses++
+		if r.userID == userID {
+			userUses++
+		}
+	}
+
+	return uses, userUses
+}
+
+// confirmPaid confirms the booking of a succeeded payment like the postgres
+// storage does. s.mu must be held.
+func (s *Store) confirmPaid(bookingID *int) error {
+	i := -1
+	for j, b := range s.bookings {
+		if bookingID != nil && b.ID == *bookingID {
+			i = j
+		}
+	}
+	if i < 0 {
+		return fmt.Errorf("no pending booking found")
+	}
+
+	b := s.bookings[i]
+	if b.Confirmed {
+		return fmt.Errorf("booking already paid")
+	}
+
+	event, err := s.event(b.EventID)
+	if err != nil {
+		return err
+	}
+	if event.BookedSeats >= event.TotalSeats {
+		return fmt.Errorf("no available seats")
+	}
+	if b.TicketTypeID != nil {
+		if err = s.checkTicketType(b.EventID, *b.TicketTypeID, false); err != nil {
+			return err
+		}
+	}
+
+	s.bookings[i].Confirmed = true
+
+	return nil
+}
+
+// recordCancellationRefund records a pending refund of the booking's
+// succeeded payment according to the event's policy. s.mu must be held.
+func (s *Store) recordCancellationRefund(b models.Booking) {
+	for _, p := range s.payments {
+		if p.BookingID == nil || *p.BookingID != b.ID || p.Status != models.PaymentSucceeded {
+			continue
+		}
+
+		event := s.events[b.EventID-1]
+		amount := min(s.policies[b.EventID].RefundAmount(p.Amount, event.Date, time.Now()), p.Amount-s.refunded(p.ID))
+		if amount > 0 {
+			s.addRefund(p, models.Refund{Amount: amount, Reason: models.RefundCancellation})
+		}
+	}
+}
+
+// addRefund records a pending refund of the payment. s.mu must be held.
+func (s *Store) addRefund(p models.Payment, r models.Refund) models.Refund {
+	now := time.Now()
+
+	r.ID = len(s.refunds) + 1
+	r.PaymentID = p.ID
+	r.BookingID = p.BookingID
+	r.EventID = p.EventID
+	r.UserID = p.UserID
+	r.Currency = p.Currency
+	r.Status = models.RefundPending
+	r.CreatedAt = now
+	r.UpdatedAt = now
+	r.ProviderID = p.ProviderID
+	s.refunds = append(s.refunds, r)
+
+	return r
+}
+
+// refunded returns the amount of the payment refunded or being refunded. s.mu must be held.
+func (s *Store) refunded(paymentID int) int64 {
+	var sum int64
+	for _, r := range s.refunds {
+		if r.PaymentID == paymentID && r.Status != models.RefundFailed {
+			sum += r.Amount
+		}
+	}
+
+	return sum
+}
+
+// refundedSucceeded returns the amount of the payment refunded. s.mu must be held.
+func (s *Store) refundedSucceeded(paymentID int) int64 {
+	var sum int64
+	for _, r := range s.refunds {
+		if r.PaymentID == paymentID && r.Status == models.RefundSucceeded {
+			sum += r.Amount
+		}
+	}
+
+	return sum
+}
+
+// allEvents returns events ordered by date, then id. s.mu must be held.
+func (s *Store) allEvents() []models.Event {
+	events := make([]models.Event, 0, len(s.events))
+	for _, e := range s.events {
+		if event, err := s.event(e.ID); err == nil {
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+
+	return events
+}
+
+// find returns the index of the latest booking of the user for the event
+// with the given confirmation state, or -1. s.mu must be held.
+func (s *Store) find(eventID int, userID string, confirmed bool) int {
+	for i := len(s.bookings) - 1; i >= 0; i-- {
+		b := s.bookings[i]
+		if b.EventID == eventID && b.UserID == userID && b.Confirmed == confirmed {
+			return i
+		}
+	}
+
+	return -1
+}
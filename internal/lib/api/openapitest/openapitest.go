@@ -0,0 +1,118 @@
+package openapitest
+
+import (
+	"context"
+	"eventBooker/api"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	once    sync.Once
+	router  routers.Router
+	loadErr error
+
+	// extRouter routes paths documented with a file extension, such as
+	// /events/{id}.ics, by their path without it. The legacy router cannot
+	// match a variable followed by a suffix, and chi's URLFormat middleware
+	// routes these requests the same way.
+	extRouter routers.Router
+	// extPaths maps the paths in extRouter to the documented ones.
+	extPaths map[string]string
+)
+
+func load() {
+	// kin-openapi has no decoder for streamed NDJSON exports, iCalendar feeds or
+	// QR code images and PDF tickets, which the spec describes as plain strings.
+	openapi3filter.RegisterBodyDecoder("application/x-ndjson", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/calendar", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("image/png", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/pdf", openapi3filter.FileBodyDecoder)
+
+	doc, err := api.Load()
+	if err != nil {
+		loadErr = err
+		return
+	}
+
+	router, loadErr = legacy.NewRouter(doc)
+	if loadErr != nil {
+		return
+	}
+
+	extDoc := *doc
+	extDoc.Paths = openapi3.NewPaths()
+	extPaths = map[string]string{}
+	for p, item := range doc.Paths.Map() {
+		i := strings.LastIndex(p, "}.")
+		if i < 0 || strings.Contains(p[i:], "/") {
+			continue
+		}
+
+		extDoc.Paths.Set(p[:i+1], item)
+		extPaths[p[:i+1]] = p
+	}
+
+	extRouter, loadErr = legacy.NewRouter(&extDoc)
+}
+
+// findRoute finds the operation documented for req. A request with an
+// extension is matched to a path documented with it, e.g. /events/{id}.ics,
+// or else to the path without it, which serves it as another format.
+func findRoute(req *http.Request) (*routers.Route, map[string]string, error) {
+	if ext := path.Ext(req.URL.Path); ext != "" {
+		stripped := req.Clone(req.Context())
+		stripped.URL.Path = strings.TrimSuffix(req.URL.Path, ext)
+
+		route, pathParams, err := extRouter.FindRoute(stripped)
+		if err == nil && extPaths[route.Path] == route.Path+ext {
+			return route, pathParams, nil
+		}
+
+		if route, pathParams, err = router.FindRoute(stripped); err == nil {
+			return route, pathParams, nil
+		}
+	}
+
+	return router.FindRoute(req)
+}
+
+// ValidateResponse fails t unless the recorded response, including its status
+// code, is documented for req in the OpenAPI spec.
+func ValidateResponse(t testing.TB, req *http.Request, rr *httptest.ResponseRecorder) {
+	t.Helper()
+
+	once.Do(load)
+	require.NoError(t, loadErr)
+
+	route, pathParams, err := findRoute(req)
+	require.NoError(t, err, "route %s %s is not documented", req.Method, req.URL.Path)
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: rr.Code,
+		Header: rr.Header(),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+		},
+	}
+	input.SetBodyBytes(rr.Body.Bytes())
+
+	err = openapi3filter.ValidateResponse(context.Background(), input)
+	require.NoError(t, err, "response does not match the spec: %s", rr.Body.String())
+}
@@ -0,0 +1,207 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	ContentTypeJSON    = "application/json"
+	ContentTypeProblem = "application/problem+json"
+
+	// problemTypeBlank means the problem has no semantics beyond its HTTP status, see RFC 7807.
+	// Clients tell problems apart by Code.
+	problemTypeBlank = "about:blank"
+)
+
+// Problem is an RFC 7807 problem details body. Code, Errors and Details are extension members.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+	Details  any          `json:"details,omitempty"`
+}
+
+// FieldError describes a single failed validation rule of a request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+func Error(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	writeProblem(w, r, newProblem(r, status, code, detail))
+}
+
+// ErrorWithDetails is Error with an arbitrary details member, such as failed health checks.
+func ErrorWithDetails(w http.ResponseWriter, r *http.Request, status int, code, detail string, details any) {
+	p := newProblem(r, status, code, detail)
+	p.Details = details
+
+	writeProblem(w, r, p)
+}
+
+// ValidationError responds 400 with one FieldError per failed rule, so clients can point at the exact input.
+func ValidationError(w http.ResponseWriter, r *http.Request, errs validator.ValidationErrors) {
+	fields := FieldErrors(errs)
+
+	errMsgs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		errMsgs = append(errMsgs, f.Message)
+	}
+
+	p := newProblem(r, http.StatusBadRequest, CodeValidationFailed, strings.Join(errMsgs, ", "))
+	p.Errors = fields
+
+	writeProblem(w, r, p)
+}
+
+// FieldErrors converts validation errors into their FieldError form.
+func FieldErrors(errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, FieldError{
+			Field:   err.Field(),
+			Tag:     err.Tag(),
+			Param:   err.Param(),
+			Message: FieldMessage(err),
+		})
+	}
+
+	return fields
+}
+
+// ContentType returns the media type of a response with the given status to r.
+// It lets code that replays stored bodies label them the way they were written.
+func ContentType(r *http.Request, status int) string {
+	if status >= http.StatusBadRequest && !isLegacy(r) {
+		return ContentTypeProblem
+	}
+
+	return ContentTypeJSON
+}
+
+func newProblem(r *http.Request, status int, code, detail string) Problem {
+	return Problem{
+		Type:     problemTypeBlank,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: middleware.GetReqID(r.Context()),
+		Code:     code,
+	}
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, p Problem) {
+	var body any = p
+	if isLegacy(r) {
+		body = map[string]any{
+			"status": StatusError,
+			"error":  p.Detail,
+		}
+	}
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType(r, p.Status))
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(raw)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// FieldMessage describes a failed validation rule in words, e.g. "field title must be at least 3 characters".
+func FieldMessage(err validator.FieldError) string {
+	field, param := err.Field(), err.Param()
+	isTime := err.Type() == timeType
+
+	switch err.ActualTag() {
+	case "required", "required_if", "required_without":
+		return fmt.Sprintf("field %s is a required field", field)
+	case "url":
+		return fmt.Sprintf("field %s is not a valid URL", field)
+	case "email":
+		return fmt.Sprintf("field %s is not a valid email address", field)
+	case "alphanum":
+		return fmt.Sprintf("field %s must contain only letters and digits", field)
+	case "timezone":
+		return fmt.Sprintf("field %s is not an IANA time zone", field)
+	case "rrule":
+		return fmt.Sprintf("field %s is not a supported RFC 5545 recurrence rule", field)
+	case "iso4217":
+		return fmt.Sprintf("field %s is not an ISO 4217 currency code", field)
+	case "oneof":
+		return fmt.Sprintf("field %s must be one of: %s", field, strings.Join(strings.Fields(param), ", "))
+	case "future":
+		return fmt.Sprintf("field %s must be in the future", field)
+	case "len":
+		return fmt.Sprintf("field %s must be exactly %s%s", field, param, unit(err.Kind()))
+	case "min":
+		return fmt.Sprintf("field %s must be at least %s%s", field, param, unit(err.Kind()))
+	case "max":
+		return fmt.Sprintf("field %s must be at most %s%s", field, param, unit(err.Kind()))
+	case "gt":
+		if isTime && param == "" {
+			return fmt.Sprintf("field %s must be in the future", field)
+		}
+		return fmt.Sprintf("field %s must be greater than %s%s", field, param, unit(err.Kind()))
+	case "gte":
+		if isTime && param == "" {
+			return fmt.Sprintf("field %s must not be in the past", field)
+		}
+		return fmt.Sprintf("field %s must be greater than or equal to %s%s", field, param, unit(err.Kind()))
+	case "lt":
+		if isTime && param == "" {
+			return fmt.Sprintf("field %s must be in the past", field)
+		}
+		return fmt.Sprintf("field %s must be less than %s%s", field, param, unit(err.Kind()))
+	case "lte":
+		if isTime && param == "" {
+			return fmt.Sprintf("field %s must not be in the future", field)
+		}
+		return fmt.Sprintf("field %s must be less than or equal to %s%s", field, param, unit(err.Kind()))
+	case "eqfield":
+		return fmt.Sprintf("field %s must be equal to %s", field, param)
+	case "nefield":
+		return fmt.Sprintf("field %s must not be equal to %s", field, param)
+	case "gtfield":
+		return fmt.Sprintf("field %s must be greater than %s", field, param)
+	case "gtefield":
+		return fmt.Sprintf("field %s must be greater than or equal to %s", field, param)
+	case "ltfield":
+		return fmt.Sprintf("field %s must be less than %s", field, param)
+	case "ltefield":
+		return fmt.Sprintf("field %s must be less than or equal to %s", field, param)
+	case "ltuntil":
+		return fmt.Sprintf("field %s must be shorter than the time left until %s", field, param)
+	default:
+		return fmt.Sprintf("field %s is not valid", field)
+	}
+}
+
+// unit names what a size rule counts for kinds where the bare number is ambiguous.
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
@@ -1,50 +1,107 @@
 package response
 
 import (
-	"fmt"
-	"github.com/go-playground/validator/v10"
-	"strings"
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"net/http"
 )
 
-type Response struct {
-	Status string `json:"status"`
-	Error  string `json:"error,omitempty"`
+// Envelope is the body of every successful JSON API response.
+// Errors are rendered as problem details, see Problem.
+type Envelope struct {
+	Data any  `json:"data"`
+	Meta Meta `json:"meta"`
 }
 
+type Meta struct {
+	RequestID  string      `json:"request_id,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+type Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
+}
+
+const (
+	CodeBadRequest       = "bad_request"
+	CodeValidationFailed = "validation_failed"
+	CodeNotFound         = "not_found"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeConflict         = "conflict"
+	CodeUnprocessable    = "unprocessable"
+	CodeRateLimited      = "rate_limited"
+	CodeInternal         = "internal_error"
+	CodeUnavailable      = "unavailable"
+
+	CodeNoAvailableSeats  = "no_available_seats"
+	CodeDuplicateBooking  = "duplicate_booking"
+	CodeInvalidTicket     = "invalid_ticket"
+	CodeTicketUsed        = "ticket_used"
+	CodeSalesClosed       = "sales_closed"
+	CodePaymentRequired   = "payment_required"
+	CodePromoCodeRejected = "promo_code_rejected"
+	CodeSeatTaken         = "seat_taken"
+)
+
+// Legacy response statuses, used only for deprecated unversioned routes.
 const (
 	StatusOK    = "OK"
 	StatusError = "Error"
 )
 
-func OK() Response {
-	return Response{
-		Status: StatusOK,
-	}
+type legacyKey struct{}
+
+// WithLegacyFormat marks the request as served on a deprecated unversioned route.
+// Responses to such requests keep the pre-v1 flat {"status": ...} shape.
+func WithLegacyFormat(ctx context.Context) context.Context {
+	return context.WithValue(ctx, legacyKey{}, true)
 }
 
-func Error(msg string) Response {
-	return Response{
-		Status: StatusError,
-		Error:  msg,
-	}
+func isLegacy(r *http.Request) bool {
+	legacy, _ := r.Context().Value(legacyKey{}).(bool)
+	return legacy
 }
 
-func ValidationError(errs validator.ValidationErrors) Response {
-	var errMsgs []string
+// OK responds 200 with data.
+func OK(w http.ResponseWriter, r *http.Request, data any) {
+	write(w, r, http.StatusOK, Envelope{Data: data})
+}
 
-	for _, err := range errs {
-		switch err.ActualTag() {
-		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
-		case "url":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid URL", err.Field()))
-		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
-		}
+// List responds 200 with a page of data.
+func List(w http.ResponseWriter, r *http.Request, data any, page Pagination) {
+	write(w, r, http.StatusOK, Envelope{Data: data, Meta: Meta{Pagination: &page}})
+}
+
+func write(w http.ResponseWriter, r *http.Request, status int, env Envelope) {
+	render.Status(r, status)
+
+	if isLegacy(r) {
+		render.JSON(w, r, legacy(env))
+		return
 	}
 
-	return Response{
-		Status: StatusError,
-		Error:  strings.Join(errMsgs, ", "),
+	env.Meta.RequestID = middleware.GetReqID(r.Context())
+	render.JSON(w, r, env)
+}
+
+// legacy flattens data into {"status": "OK", ...data fields}, as unversioned
+// routes used to respond. Data that is not an object, or has a status field
+// of its own, is kept whole under "data" instead.
+func legacy(env Envelope) map[string]any {
+	body := map[string]any{}
+	if env.Data != nil {
+		if raw, err := json.Marshal(env.Data); err == nil {
+			if json.Unmarshal(raw, &body) != nil || body["status"] != nil {
+				body = map[string]any{"data": json.RawMessage(raw)}
+			}
+		}
 	}
+	body["status"] = StatusOK
+
+	return body
 }
@@ -0,0 +1,204 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	EventID int `json:"event_id"`
+}
+
+func TestEnvelope(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		legacy         bool
+		write          func(w http.ResponseWriter, r *http.Request)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "OK",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				OK(w, r, payload{EventID: 1})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"event_id":1},"meta":{"request_id":"req-1"}}`,
+		},
+		{
+			name: "List",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				List(w, r, []int{1, 2}, Pagination{Limit: 2, Offset: 4, Total: 10})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[1,2],"meta":{"request_id":"req-1","pagination":{"limit":2,"offset":4,"total":10}}}`,
+		},
+		{
+			name:   "Legacy OK",
+			legacy: true,
+			write: func(w http.ResponseWriter, r *http.Request) {
+				OK(w, r, payload{EventID: 1})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK","event_id":1}`,
+		},
+		{
+			name:   "Legacy OK with a list",
+			legacy: true,
+			write: func(w http.ResponseWriter, r *http.Request) {
+				OK(w, r, []int{1, 2})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK","data":[1,2]}`,
+		},
+		{
+			name:   "Legacy OK with a status field",
+			legacy: true,
+			write: func(w http.ResponseWriter, r *http.Request) {
+				OK(w, r, map[string]any{"event_id": 1, "status": "cancelled"})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK","data":{"event_id":1,"status":"cancelled"}}`,
+		},
+		{
+			name:   "Legacy OK without data",
+			legacy: true,
+			write: func(w http.ResponseWriter, r *http.Request) {
+				OK(w, r, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx := req.Context()
+			if tc.legacy {
+				ctx = WithLegacyFormat(ctx)
+			}
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			middleware.RequestID(http.HandlerFunc(tc.write)).ServeHTTP(rr, withRequestID(req, "req-1"))
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestProblem(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		legacy              bool
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "Problem details",
+			expectedContentType: ContentTypeProblem,
+			expectedBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"detail": "event not found",
+				"instance": "req-1",
+				"code": "not_found"
+			}`,
+		},
+		{
+			name:                "Legacy",
+			legacy:              true,
+			expectedContentType: ContentTypeJSON,
+			expectedBody:        `{"status":"Error","error":"event not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.legacy {
+				req = req.WithContext(WithLegacyFormat(req.Context()))
+			}
+
+			rr := httptest.NewRecorder()
+			middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Error(w, r, http.StatusNotFound, CodeNotFound, "event not found")
+			})).ServeHTTP(rr, withRequestID(req, "req-1"))
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+			assert.Equal(t, tc.expectedContentType, rr.Header().Get("Content-Type"))
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		UserId     string    `validate:"required"`
+		Title      string    `validate:"min=3,max=10"`
+		TotalSeats int       `validate:"gt=0"`
+		Tags       []string  `validate:"max=2"`
+		Starts     time.Time `validate:"gt"`
+		Ends       time.Time `validate:"gtefield=Starts"`
+	}
+
+	now := time.Now()
+	err := validator.New().Struct(request{
+		Title:  "ab",
+		Tags:   []string{"a", "b", "c"},
+		Starts: now.Add(-time.Hour),
+		Ends:   now.Add(-2 * time.Hour),
+	})
+	var validateErr validator.ValidationErrors
+	require.ErrorAs(t, err, &validateErr)
+
+	rr := httptest.NewRecorder()
+	ValidationError(rr, httptest.NewRequest(http.MethodPost, "/", nil), validateErr)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, ContentTypeProblem, rr.Header().Get("Content-Type"))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+
+	assert.Equal(t, CodeValidationFailed, problem.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "UserId", Tag: "required", Message: "field UserId is a required field"},
+		{Field: "Title", Tag: "min", Param: "3", Message: "field Title must be at least 3 characters"},
+		{Field: "TotalSeats", Tag: "gt", Param: "0", Message: "field TotalSeats must be greater than 0"},
+		{Field: "Tags", Tag: "max", Param: "2", Message: "field Tags must be at most 2 items"},
+		{Field: "Starts", Tag: "gt", Message: "field Starts must be in the future"},
+		{Field: "Ends", Tag: "gtefield", Param: "Starts", Message: "field Ends must be greater than or equal to Starts"},
+	}, problem.Errors)
+	assert.Equal(t, "field UserId is a required field, field Title must be at least 3 characters, "+
+		"field TotalSeats must be greater than 0, field Tags must be at most 2 items, "+
+		"field Starts must be in the future, field Ends must be greater than or equal to Starts", problem.Detail)
+}
+
+func withRequestID(r *http.Request, id string) *http.Request {
+	r.Header.Set(middleware.RequestIDHeader, id)
+	return r
+}
@@ -0,0 +1,133 @@
+package eventimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Columns read from CSV. Other columns are ignored, and deadline_minutes
+// and total_seats may be left out when Defaults provide them.
+const (
+	columnTitle      = "title"
+	columnDate       = "date"
+	columnTotalSeats = "total_seats"
+	columnDeadline   = "deadline_minutes"
+)
+
+// dateLayouts are tried in order. Dates without an offset are taken as UTC,
+// as spreadsheets usually export them.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+func parseCSV(r io.Reader, defaults Defaults) ([]Record, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+
+	for _, name := range []string{columnTitle, columnDate} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header has no %s column", name)
+		}
+	}
+
+	var records []Record
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		if isBlank(row) {
+			continue
+		}
+		if len(records) == MaxRecords {
+			return nil, ErrTooMany
+		}
+
+		line, _ := cr.FieldPos(0)
+		rec := Record{
+			Line:       line,
+			TotalSeats: defaults.TotalSeats,
+			Deadline:   defaults.Deadline,
+		}
+
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		rec.Title = value(columnTitle)
+
+		if v := value(columnDate); v != "" {
+			if rec.Date, err = parseDate(v); err != nil {
+				rec.addFormatError("date", "date")
+			}
+		}
+		if v := value(columnTotalSeats); v != "" {
+			if rec.TotalSeats, err = strconv.Atoi(v); err != nil {
+				rec.addFormatError("total_seats", "number")
+			}
+		}
+		if v := value(columnDeadline); v != "" {
+			if rec.Deadline, err = strconv.Atoi(v); err != nil {
+				rec.addFormatError("deadline", "number")
+			}
+		}
+
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+func parseDate(v string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown date format %q", v)
+}
+
+func isBlank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
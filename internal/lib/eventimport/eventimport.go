@@ -0,0 +1,88 @@
+// Package eventimport reads events to create in bulk from CSV and iCalendar files.
+package eventimport
+
+import (
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatICS = "ics"
+
+	// MaxRecords bounds a single import so it fits in one transaction.
+	MaxRecords = 1000
+
+	// tagFormat marks a value that could not be parsed at all.
+	tagFormat = "format"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown import format, expected CSV or iCalendar")
+	ErrTooMany       = fmt.Errorf("too many events, at most %d can be imported at once", MaxRecords)
+)
+
+// Defaults fill in values a file does not have, such as seats of iCalendar events.
+type Defaults struct {
+	TotalSeats int
+	Deadline   int
+}
+
+// Record is a single event read from a file.
+type Record struct {
+	// Line is the line the event starts at, counting from 1.
+	Line       int
+	Title      string
+	Date       time.Time
+	TotalSeats int
+	Deadline   int
+	// Errors lists values that could not be parsed, their fields are left zero.
+	Errors []response.FieldError
+}
+
+// Parse reads records in the given format.
+func Parse(r io.Reader, format string, defaults Defaults) ([]Record, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r, defaults)
+	case FormatICS:
+		return parseICS(r, defaults)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// DetectFormat tells the format from a media type, falling back to the file name extension.
+func DetectFormat(contentType, filename string) (string, error) {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch mediaType {
+		case "text/csv":
+			return FormatCSV, nil
+		case "text/calendar":
+			return FormatICS, nil
+		}
+	}
+
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".ics", ".ical", ".icalendar":
+		return FormatICS, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+func (rec *Record) addFormatError(field, what string) {
+	rec.Errors = append(rec.Errors, response.FieldError{
+		Field:   field,
+		Tag:     tagFormat,
+		Message: fmt.Sprintf("field %s is not a valid %s", field, what),
+	})
+}
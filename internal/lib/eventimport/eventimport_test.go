@@ -0,0 +1,149 @@
+package eventimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+
+	input := "\ufeffTitle,Date,Total_Seats,deadline_minutes,venue\n" +
+		"Go meetup,2099-06-01T19:00:00+03:00,100,30,Main hall\n" +
+		"\n" +
+		"\"Rust, night\",2099-06-02 18:30,,,\n" +
+		"Broken,tomorrow,many,30,\n"
+
+	records, err := Parse(strings.NewReader(input), FormatCSV, Defaults{TotalSeats: 50, Deadline: 15})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, Record{
+		Line:       2,
+		Title:      "Go meetup",
+		Date:       time.Date(2099, 6, 1, 16, 0, 0, 0, time.UTC),
+		TotalSeats: 100,
+		Deadline:   30,
+	}, normalize(records[0]))
+
+	assert.Equal(t, Record{
+		Line:       4,
+		Title:      "Rust, night",
+		Date:       time.Date(2099, 6, 2, 18, 30, 0, 0, time.UTC),
+		TotalSeats: 50,
+		Deadline:   15,
+	}, normalize(records[1]))
+
+	assert.Equal(t, 5, records[2].Line)
+	require.Len(t, records[2].Errors, 2)
+	assert.Equal(t, "date", records[2].Errors[0].Field)
+	assert.Equal(t, "format", records[2].Errors[0].Tag)
+	assert.Equal(t, "total_seats", records[2].Errors[1].Field)
+}
+
+func TestParseCSVHeader(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse(strings.NewReader("name,date\nGo meetup,2099-06-01 19:00\n"), FormatCSV, Defaults{})
+	assert.EqualError(t, err, "csv header has no title column")
+
+	_, err = Parse(strings.NewReader(""), FormatCSV, Defaults{})
+	assert.EqualError(t, err, "csv file is empty")
+}
+
+func TestParseICS(t *testing.T) {
+	t.Parallel()
+
+	input := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:Europe/Moscow\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:1@example.com\r\n" +
+		"SUMMARY:Go meetup\\, spring edition with a title long enough to be\r\n" +
+		"  folded\r\n" +
+		"DTSTART:20990601T160000Z\r\n" +
+		"X-TOTAL-SEATS:100\r\n" +
+		"X-DEADLINE-MINUTES:30\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Rust night\r\n" +
+		"DTSTART;TZID=Europe/Moscow:20990602T180000\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Broken\r\n" +
+		"DTSTART:someday\r\n" +
+		"X-TOTAL-SEATS:many\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	records, err := Parse(strings.NewReader(input), FormatICS, Defaults{TotalSeats: 50, Deadline: 15})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, Record{
+		Line:       6,
+		Title:      "Go meetup, spring edition with a title long enough to be folded",
+		Date:       time.Date(2099, 6, 1, 16, 0, 0, 0, time.UTC),
+		TotalSeats: 100,
+		Deadline:   30,
+	}, normalize(records[0]))
+
+	assert.Equal(t, Record{
+		Line:       14,
+		Title:      "Rust night",
+		Date:       time.Date(2099, 6, 2, 15, 0, 0, 0, time.UTC),
+		TotalSeats: 50,
+		Deadline:   15,
+	}, normalize(records[1]))
+
+	require.Len(t, records[2].Errors, 2)
+	assert.Equal(t, "date", records[2].Errors[0].Field)
+	assert.Equal(t, "total_seats", records[2].Errors[1].Field)
+}
+
+func TestParseICSNotCalendar(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse(strings.NewReader("title,date\n"), FormatICS, Defaults{})
+	assert.EqualError(t, err, "not an iCalendar file: no VCALENDAR")
+}
+
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		contentType string
+		filename    string
+		want        string
+		wantErr     bool
+	}{
+		{contentType: "text/csv; charset=utf-8", want: FormatCSV},
+		{contentType: "text/calendar", want: FormatICS},
+		{contentType: "application/octet-stream", filename: "season.ICS", want: FormatICS},
+		{filename: "season.csv", want: FormatCSV},
+		{contentType: "application/json", filename: "season.json", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := DetectFormat(tt.contentType, tt.filename)
+		if tt.wantErr {
+			assert.ErrorIs(t, err, ErrUnknownFormat)
+			continue
+		}
+
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+// normalize puts dates in UTC so records compare with assert.Equal.
+func normalize(rec Record) Record {
+	rec.Date = rec.Date.UTC()
+	return rec
+}
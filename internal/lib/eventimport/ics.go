@@ -0,0 +1,191 @@
+package eventimport
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Non-standard VEVENT properties carrying what iCalendar has no notion of.
+const (
+	propTotalSeats = "X-TOTAL-SEATS"
+	propDeadline   = "X-DEADLINE-MINUTES"
+)
+
+const (
+	icsDateTime    = "20060102T150405"
+	icsDateTimeUTC = "20060102T150405Z"
+	icsDate        = "20060102"
+)
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseICS reads the VEVENTs of a calendar. Only SUMMARY, DTSTART and the
+// X-TOTAL-SEATS and X-DEADLINE-MINUTES properties are used.
+func parseICS(r io.Reader, defaults Defaults) ([]Record, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		records []Record
+		rec     *Record
+		inCal   bool
+	)
+
+	for _, l := range lines {
+		prop, ok := parseProperty(l.text)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCALENDAR"):
+			inCal = true
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			if len(records) == MaxRecords {
+				return nil, ErrTooMany
+			}
+			rec = &Record{
+				Line:       l.number,
+				TotalSeats: defaults.TotalSeats,
+				Deadline:   defaults.Deadline,
+			}
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if rec != nil {
+				records = append(records, *rec)
+				rec = nil
+			}
+		case rec == nil:
+			continue
+		case prop.name == "SUMMARY":
+			rec.Title = strings.TrimSpace(unescapeText(prop.value))
+		case prop.name == "DTSTART":
+			if rec.Date, err = parseDateTime(prop); err != nil {
+				rec.addFormatError("date", "date")
+			}
+		case prop.name == propTotalSeats:
+			if rec.TotalSeats, err = strconv.Atoi(strings.TrimSpace(prop.value)); err != nil {
+				rec.addFormatError("total_seats", "number")
+			}
+		case prop.name == propDeadline:
+			if rec.Deadline, err = strconv.Atoi(strings.TrimSpace(prop.value)); err != nil {
+				rec.addFormatError("deadline", "number")
+			}
+		}
+	}
+
+	if !inCal {
+		return nil, errors.New("not an iCalendar file: no VCALENDAR")
+	}
+
+	return records, nil
+}
+
+type contentLine struct {
+	number int
+	text   string
+}
+
+// unfold joins continuation lines, which start with a space or a tab (RFC 5545, 3.1).
+func unfold(r io.Reader) ([]contentLine, error) {
+	var lines []contentLine
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	for n := 1; sc.Scan(); n++ {
+		text := strings.TrimRight(sc.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text != "" {
+			lines = append(lines, contentLine{number: n, text: text})
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseProperty splits "NAME;PARAM=VALUE:value". Quoted parameter values may contain ':' and ';'.
+func parseProperty(line string) (property, bool) {
+	var (
+		quoted bool
+		colon  = -1
+	)
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		}
+		if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: map[string]string{},
+		value:  value,
+	}
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		prop.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+
+	return prop, true
+}
+
+// parseDateTime reads DTSTART as UTC, in the zone named by TZID, or, for
+// floating times and whole-day dates, as UTC.
+func parseDateTime(prop property) (time.Time, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if prop.params["VALUE"] == "DATE" || len(value) == len(icsDate) {
+		return time.Parse(icsDate, value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(icsDateTimeUTC, value)
+	}
+
+	loc := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		var err error
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return time.Time{}, fmt.Errorf("unknown time zone %q: %w", tzid, err)
+		}
+	}
+
+	t, err := time.ParseInLocation(icsDateTime, value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.UTC(), nil
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
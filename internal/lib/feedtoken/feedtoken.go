@@ -0,0 +1,58 @@
+// Package feedtoken signs user ids into tokens for private calendar feed URLs.
+// Tokens carry the user id, so they need no storage, and all of them are
+// revoked at once by changing the secret.
+package feedtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+// macLength is the number of hex characters of the MAC kept in a token, i.e. 128 bits.
+const macLength = 32
+
+var ErrInvalid = errors.New("invalid feed token")
+
+type Signer struct {
+	secret []byte
+}
+
+func New(secret string) *Signer {
+	return &Signer{secret: []byte(secret)}
+}
+
+// Sign returns the token of the user's feed. It contains only URL-safe characters and no dots,
+// so it can be followed by a file extension.
+func (s *Signer) Sign(userID string) string {
+	return s.mac(userID) + base64.RawURLEncoding.EncodeToString([]byte(userID))
+}
+
+// Verify returns the user id signed into token.
+func (s *Signer) Verify(token string) (string, error) {
+	if len(token) <= macLength {
+		return "", ErrInvalid
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token[macLength:])
+	if err != nil {
+		return "", ErrInvalid
+	}
+
+	userID := string(raw)
+	if !hmac.Equal([]byte(token[:macLength]), []byte(s.mac(userID))) {
+		return "", ErrInvalid
+	}
+
+	return userID, nil
+}
+
+func (s *Signer) mac(userID string) string {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte("calendar:"))
+	h.Write([]byte(userID))
+
+	return hex.EncodeToString(h.Sum(nil))[:macLength]
+}
@@ -0,0 +1,49 @@
+package feedtoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+
+	signer := New("secret")
+
+	token := signer.Sign("user@example.com")
+	assert.NotContains(t, token, ".")
+	assert.NotContains(t, token, "/")
+
+	userID, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", userID)
+}
+
+func TestVerifyRejects(t *testing.T) {
+	t.Parallel()
+
+	signer := New("secret")
+	token := signer.Sign("user123")
+
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{name: "Empty", token: ""},
+		{name: "MAC only", token: token[:macLength]},
+		{name: "Other secret", token: New("other").Sign("user123")},
+		{name: "Other user", token: token[:macLength] + signer.Sign("user124")[macLength:]},
+		{name: "Invalid encoding", token: token + "!"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := signer.Verify(tc.token)
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
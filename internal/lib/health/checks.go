@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+type Check struct {
+	Name string
+	Func func(ctx context.Context) error
+}
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type SchemaVersioner interface {
+	SchemaVersion(ctx context.Context) (uint, bool, error)
+}
+
+func Database(db Pinger) Check {
+	return Check{
+		Name: "database",
+		Func: db.Ping,
+	}
+}
+
+func Schema(db SchemaVersioner, expected uint) Check {
+	return Check{
+		Name: "schema",
+		Func: func(ctx context.Context) error {
+			version, dirty, err := db.SchemaVersion(ctx)
+			if err != nil {
+				return err
+			}
+			if dirty {
+				return fmt.Errorf("migration %d is dirty", version)
+			}
+			if version != expected {
+				return fmt.Errorf("schema version is %d, expected %d", version, expected)
+			}
+			return nil
+		},
+	}
+}
+
+func Worker(name string, hb *Heartbeat, maxAge time.Duration) Check {
+	return Check{
+		Name: name,
+		Func: func(context.Context) error {
+			if age := time.Since(hb.Last()); age > maxAge {
+				return fmt.Errorf("last run %s ago", age.Round(time.Second))
+			}
+			return nil
+		},
+	}
+}
+
+// Draining fails once shutting down is set, so load balancers stop routing
+// new requests before the server stops accepting them.
+func Draining(shuttingDown *atomic.Bool) Check {
+	return Check{
+		Name: "shutdown",
+		Func: func(context.Context) error {
+			if shuttingDown.Load() {
+				return fmt.Errorf("server is shutting down")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package health
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat records when a background worker last finished a run.
+type Heartbeat struct {
+	last atomic.Int64
+}
+
+// NewHeartbeat returns a Heartbeat that counts as fresh from now, so a worker
+// is not reported as stale before its first run.
+func NewHeartbeat() *Heartbeat {
+	h := &Heartbeat{}
+	h.Beat()
+
+	return h
+}
+
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+func (h *Heartbeat) Last() time.Time {
+	return time.Unix(0, h.last.Load())
+}
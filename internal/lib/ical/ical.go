@@ -0,0 +1,148 @@
+// Package ical writes iCalendar (RFC 5545) feeds of events.
+package ical
+
+import (
+	"bufio"
+	"eventBooker/internal/models"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	StatusConfirmed = "CONFIRMED"
+
+	prodID = "-//Event Booker//Event Booker API//EN"
+
+	// maxLineOctets is the longest content line allowed before folding, see RFC 5545, 3.1.
+	maxLineOctets = 75
+
+	dateTimeUTC = "20060102T150405Z"
+)
+
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+type Event struct {
+	// UID identifies the event across feeds and over time, so calendar apps update it in place.
+	UID string
+	// Stamp is when this version of the event was generated.
+	Stamp       time.Time
+	Start       time.Time
+	Summary     string
+	Description string
+	Status      string
+}
+
+// EventUID returns a globally unique UID for the event. domain should be
+// a domain the service owns, as RFC 5545 recommends.
+func EventUID(eventID int, domain string) string {
+	return fmt.Sprintf("event-%d@%s", eventID, domain)
+}
+
+// FromEvent describes e as a calendar event generated at stamp.
+func FromEvent(e models.Event, domain string, stamp time.Time) Event {
+	return Event{
+		UID:         EventUID(e.ID, domain),
+		Stamp:       stamp,
+		Start:       e.Date,
+		Summary:     e.Title,
+		Description: fmt.Sprintf("%d of %d seats available", max(e.TotalSeats-e.BookedSeats, 0), e.TotalSeats),
+	}
+}
+
+// Write writes cal as a VCALENDAR. Times are written in UTC, which every
+// calendar app converts to the viewer's zone without needing a VTIMEZONE.
+func Write(w io.Writer, cal Calendar) error {
+	bw := bufio.NewWriter(w)
+	lw := &lineWriter{w: bw}
+
+	lw.line("BEGIN", "VCALENDAR")
+	lw.line("VERSION", "2.0")
+	lw.line("PRODID", prodID)
+	lw.line("CALSCALE", "GREGORIAN")
+	lw.line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		lw.line("X-WR-CALNAME", escapeText(cal.Name))
+	}
+
+	for _, e := range cal.Events {
+		lw.line("BEGIN", "VEVENT")
+		lw.line("UID", e.UID)
+		lw.line("DTSTAMP", formatTime(e.Stamp))
+		lw.line("DTSTART", formatTime(e.Start))
+		lw.line("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			lw.line("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.Status != "" {
+			lw.line("STATUS", e.Status)
+		}
+		lw.line("END", "VEVENT")
+	}
+
+	lw.line("END", "VCALENDAR")
+
+	if lw.err != nil {
+		return lw.err
+	}
+
+	return bw.Flush()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeUTC)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeText escapes a TEXT value, see RFC 5545, 3.3.11.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// lineWriter writes CRLF terminated content lines folded at 75 octets, keeping the first error.
+type lineWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (lw *lineWriter) line(name, value string) {
+	if lw.err != nil {
+		return
+	}
+
+	_, lw.err = io.WriteString(lw.w, fold(name+":"+value))
+}
+
+// fold splits a content line so that no line is longer than 75 octets,
+// never inside a UTF-8 sequence. Continuation lines start with a space.
+func fold(line string) string {
+	var b strings.Builder
+
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+
+		// The leading space counts towards the limit of continuation lines.
+		limit = maxLineOctets - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package ical
+
+import (
+	"bytes"
+	"eventBooker/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	stamp := time.Date(2099, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := FromEvent(models.Event{
+		ID:          3,
+		Title:       "Workshop: Go, SQL; and\nmore",
+		Date:        time.Date(2099, 6, 1, 10, 0, 0, 0, time.FixedZone("CET", 60*60)),
+		TotalSeats:  10,
+		BookedSeats: 12,
+	}, "events.example.com", stamp)
+	event.Status = StatusConfirmed
+
+	var buf bytes.Buffer
+	err := Write(&buf, Calendar{Name: "My events", Events: []Event{event}})
+	require.NoError(t, err)
+
+	expected := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//Event Booker//Event Booker API//EN\r\n" +
+		"CALSCALE:GREGORIAN\r\n" +
+		"METHOD:PUBLISH\r\n" +
+		"X-WR-CALNAME:My events\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:event-3@events.example.com\r\n" +
+		"DTSTAMP:20990102T030405Z\r\n" +
+		"DTSTART:20990601T090000Z\r\n" +
+		`SUMMARY:Workshop: Go\, SQL\; and\nmore` + "\r\n" +
+		"DESCRIPTION:0 of 10 seats available\r\n" +
+		"STATUS:CONFIRMED\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestFold(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Short line", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "SUMMARY:Go\r\n", fold("SUMMARY:Go"))
+	})
+
+	t.Run("Long line", func(t *testing.T) {
+		t.Parallel()
+
+		line := "SUMMARY:" + strings.Repeat("a", 200)
+		folded := fold(line)
+
+		for _, l := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(l), 75)
+		}
+		assert.Equal(t, line, strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""))
+	})
+
+	t.Run("Multi-byte characters are not split", func(t *testing.T) {
+		t.Parallel()
+
+		line := "SUMMARY:" + strings.Repeat("ё", 100)
+		folded := fold(line)
+
+		for _, l := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(l), 75)
+			assert.True(t, strings.ToValidUTF8(l, "?") == l, "line %q is not valid UTF-8", l)
+		}
+		assert.Equal(t, line, strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""))
+	})
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import "context"
+
+type BookingStorage interface {
+	BookEvent(ctx context.Context, eventID int, userID string, ticketTypeID int, promoCode string, seatID int) error
+	ConfirmBooking(ctx context.Context, eventID int, userID string) error
+	CancelBooking(ctx context.Context, eventID int, userID string) error
+	BookSeries(ctx context.Context, seriesID int, userID string) ([]int, error)
+}
+
+// Bookings wraps a BookingStorage and counts booking outcomes.
+type Bookings struct {
+	BookingStorage
+	m *Metrics
+}
+
+func (m *Metrics) InstrumentBookings(s BookingStorage) *Bookings {
+	return &Bookings{BookingStorage: s, m: m}
+}
+
+func (b *Bookings) BookEvent(ctx context.Context, eventID int, userID string, ticketTypeID int, promoCode string, seatID int) error {
+	err := b.BookingStorage.BookEvent(ctx, eventID, userID, ticketTypeID, promoCode, seatID)
+	b.record(err, OutcomeCreated)
+
+	return err
+}
+
+func (b *Bookings) ConfirmBooking(ctx context.Context, eventID int, userID string) error {
+	err := b.BookingStorage.ConfirmBooking(ctx, eventID, userID)
+	b.record(err, OutcomeConfirmed)
+
+	return err
+}
+
+func (b *Bookings) CancelBooking(ctx context.Context, eventID int, userID string) error {
+	err := b.BookingStorage.CancelBooking(ctx, eventID, userID)
+	b.record(err, OutcomeCancelled)
+
+	return err
+}
+
+// BookSeries counts a booking created for each occurrence booked.
+func (b *Bookings) BookSeries(ctx context.Context, seriesID int, userID string) ([]int, error) {
+	ids, err := b.BookingStorage.BookSeries(ctx, seriesID, userID)
+	if err == nil {
+		b.m.AddBookings(OutcomeCreated, len(ids))
+		return ids, nil
+	}
+	b.record(err, OutcomeCreated)
+
+	return nil, err
+}
+
+func (b *Bookings) record(err error, success string) {
+	if err == nil {
+		b.m.AddBookings(success, 1)
+		return
+	}
+
+	switch err.Error() {
+	case "no available seats":
+		b.m.AddBookings(OutcomeRejectedFull, 1)
+	case "user already has pending booking for this event":
+		b.m.AddBookings(OutcomeRejectedDuplicate, 1)
+	}
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "event_booker"
+
+const (
+	OutcomeCreated           = "created"
+	OutcomeConfirmed         = "confirmed"
+	OutcomeCancelled         = "cancelled"
+	OutcomeExpired           = "expired"
+	OutcomeRejectedFull      = "rejected_full"
+	OutcomeRejectedDuplicate = "rejected_duplicate"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	bookings      *prometheus.CounterVec
+	sweepDuration prometheus.Histogram
+	sweepRows     prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		bookings: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bookings_total",
+			Help:      "Number of bookings by outcome.",
+		}, []string{"outcome"}),
+		sweepDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "expiry_sweep",
+			Name:      "duration_seconds",
+			Help:      "Duration of the expired bookings sweep.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		sweepRows: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "expiry_sweep",
+			Name:      "rows_affected_total",
+			Help:      "Number of bookings removed by the expired bookings sweep.",
+		}),
+	}
+
+	// Pre-create every outcome so that rate() works from the first scrape.
+	for _, outcome := range []string{
+		OutcomeCreated,
+		OutcomeConfirmed,
+		OutcomeCancelled,
+		OutcomeExpired,
+		OutcomeRejectedFull,
+		OutcomeRejectedDuplicate,
+	} {
+		m.bookings.WithLabelValues(outcome)
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.duration,
+		m.bookings,
+		m.sweepDuration,
+		m.sweepRows,
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, d time.Duration) {
+	code := strconv.Itoa(status)
+
+	m.requests.WithLabelValues(method, route, code).Inc()
+	m.duration.WithLabelValues(method, route, code).Observe(d.Seconds())
+}
+
+func (m *Metrics) AddBookings(outcome string, n int) {
+	m.bookings.WithLabelValues(outcome).Add(float64(n))
+}
+
+func (m *Metrics) ObserveSweep(d time.Duration, rows int64) {
+	m.sweepDuration.Observe(d.Seconds())
+	m.sweepRows.Add(float64(rows))
+	m.AddBookings(OutcomeExpired, int(rows))
+}
+
+func (m *Metrics) RegisterDB(db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBookings struct {
+	err error
+}
+
+func (f fakeBookings) BookEvent(context.Context, int, string, int, string, int) error { return f.err }
+func (f fakeBookings) ConfirmBooking(context.Context, int, string) error              { return f.err }
+func (f fakeBookings) CancelBooking(context.Context, int, string) error               { return f.err }
+func (f fakeBookings) BookSeries(context.Context, int, string) ([]int, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []int{1, 2}, nil
+}
+
+type fakeEvents struct {
+	events []models.Event
+	err    error
+}
+
+// UpcomingEvents expects events to be upcoming and ordered by date.
+func (f fakeEvents) UpcomingEvents(_ context.Context, limit int) ([]models.Event, int, error) {
+	if f.err != nil {
+		return nil, 0, f.err
+	}
+	return f.events[:min(limit, len(f.events))], len(f.events), nil
+}
+
+func TestInstrumentBookings(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+
+	_ = m.InstrumentBookings(fakeBookings{}).BookEvent(context.Background(), 1, "u1", 0, "", 0)
+	_ = m.InstrumentBookings(fakeBookings{}).ConfirmBooking(context.Background(), 1, "u1")
+	_ = m.InstrumentBookings(fakeBookings{}).CancelBooking(context.Background(), 1, "u1")
+	_ = m.InstrumentBookings(fakeBookings{err: errors.New("no available seats")}).BookEvent(context.Background(), 1, "u1", 0, "", 0)
+	_ = m.InstrumentBookings(fakeBookings{err: errors.New("user already has pending booking for this event")}).BookEvent(context.Background(), 1, "u1", 0, "", 0)
+	_ = m.InstrumentBookings(fakeBookings{err: errors.New("database error")}).BookEvent(context.Background(), 1, "u1", 0, "", 0)
+	_, _ = m.InstrumentBookings(fakeBookings{}).BookSeries(context.Background(), 1, "u1")
+	_, _ = m.InstrumentBookings(fakeBookings{err: errors.New("no available seats")}).BookSeries(context.Background(), 1, "u1")
+	m.ObserveSweep(time.Millisecond, 3)
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.bookings.WithLabelValues(OutcomeCreated)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.bookings.WithLabelValues(OutcomeConfirmed)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.bookings.WithLabelValues(OutcomeCancelled)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.bookings.WithLabelValues(OutcomeRejectedFull)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.bookings.WithLabelValues(OutcomeRejectedDuplicate)))
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.bookings.WithLabelValues(OutcomeExpired)))
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.sweepRows))
+}
+
+func TestSeatsCollector(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	m := New()
+	m.RegisterSeats(fakeEvents{events: []models.Event{
+		{ID: 2, Date: now.Add(time.Hour), TotalSeats: 10, BookedSeats: 4},
+		{ID: 3, Date: now.Add(2 * time.Hour), TotalSeats: 5},
+		{ID: 4, Date: now.Add(3 * time.Hour), TotalSeats: 5},
+	}}, 2)
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	body := rr.Body.String()
+	assert.Contains(t, body, `event_booker_event_seats_available{event_id="2"} 6`)
+	assert.Contains(t, body, `event_booker_event_seats_available{event_id="3"} 5`)
+	assert.NotContains(t, body, `event_id="4"`, "events over the limit must not be exported")
+	assert.Contains(t, body, `event_booker_event_seats_series_truncated 1`)
+}
+
+func TestSeatsCollectorError(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+	m.RegisterSeats(fakeEvents{err: errors.New("database error")}, 10)
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	assert.Contains(t, rr.Body.String(), `event_booker_event_seats_scrape_errors_total 1`)
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"eventBooker/internal/models"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type EventsGetter interface {
+	// UpcomingEvents returns the limit nearest upcoming events with their
+	// booked seats counted, and how many upcoming events there are in all.
+	UpcomingEvents(ctx context.Context, limit int) ([]models.Event, int, error)
+}
+
+// seatsCollector reports available seats for the nearest upcoming events.
+// Only the first limit events are exported to keep the number of series bounded.
+type seatsCollector struct {
+	events EventsGetter
+	limit  int
+
+	available *prometheus.Desc
+	truncated *prometheus.Desc
+	errors    prometheus.Counter
+}
+
+func (m *Metrics) RegisterSeats(events EventsGetter, limit int) {
+	c := &seatsCollector{
+		events: events,
+		limit:  limit,
+		available: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "event", "seats_available"),
+			"Seats still available for an upcoming event.",
+			[]string{"event_id"}, nil,
+		),
+		truncated: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "event", "seats_series_truncated"),
+			"Number of upcoming events left out of seats_available by the cardinality limit.",
+			nil, nil,
+		),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "event",
+			Name:      "seats_scrape_errors_total",
+			Help:      "Number of failed attempts to load events for seats_available.",
+		}),
+	}
+
+	m.registry.MustRegister(c)
+}
+
+func (c *seatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.available
+	ch <- c.truncated
+	c.errors.Describe(ch)
+}
+
+func (c *seatsCollector) Collect(ch chan<- prometheus.Metric) {
+	defer c.errors.Collect(ch)
+
+	events, total, err := c.events.UpcomingEvents(context.Background(), c.limit)
+	if err != nil {
+		c.errors.Inc()
+		return
+	}
+
+	for _, event := range events {
+		ch <- prometheus.MustNewConstMetric(
+			c.available,
+			prometheus.GaugeValue,
+			float64(event.TotalSeats-event.BookedSeats),
+			strconv.Itoa(event.ID),
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.truncated, prometheus.GaugeValue, float64(total-len(events)))
+}
@@ -0,0 +1,292 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"eventBooker/internal/lib/logger/sl"
+	"fmt"
+	"html/template"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeSignatureHeader carries the hex HMAC-SHA256 of a fake callback's body.
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// maxCallbackSize limits the body of a callback read by VerifyCallback.
+const maxCallbackSize = 1 << 16
+
+// Fake is a Provider for local development and tests that moves no money.
+// Its checkout page, served by CheckoutHandler, lets the user pay or decline
+// and delivers the callback, signed with the secret, to the webhook URL.
+type Fake struct {
+	secret      []byte
+	checkoutURL string
+	webhookURL  string
+	client      *http.Client
+
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+type fakePayment struct {
+	Intent
+	ID       string
+	Paid     bool
+	Declined bool
+	Refunded int64
+	// RefundKeys are the idempotency keys of the refunds made.
+	RefundKeys map[string]bool
+}
+
+// fakeCallback is the body of a fake callback.
+type fakeCallback struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+const (
+	fakeSucceeded = "succeeded"
+	fakeFailed    = "failed"
+)
+
+// NewFake returns a fake provider whose checkout pages are served under
+// checkoutURL and whose callbacks are sent to webhookURL.
+func NewFake(secret, checkoutURL, webhookURL string) *Fake {
+	return &Fake{
+		secret:      []byte(secret),
+		checkoutURL: strings.TrimSuffix(checkoutURL, "/"),
+		webhookURL:  webhookURL,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		payments:    make(map[string]*fakePayment),
+	}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) CreateIntent(_ context.Context, intent Intent) (Checkout, error) {
+	raw := make([]byte, 12)
+	if _, err := rand.Read(raw); err != nil {
+		return Checkout{}, fmt.Errorf("failed to generate payment id: %w", err)
+	}
+	id := "fake_" + hex.EncodeToString(raw)
+
+	f.mu.Lock()
+	f.payments[id] = &fakePayment{Intent: intent, ID: id}
+	f.mu.Unlock()
+
+	return Checkout{ID: id, URL: f.checkoutURL + "/" + id}, nil
+}
+
+func (f *Fake) VerifyCallback(r *http.Request) (Callback, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackSize))
+	if err != nil {
+		return Callback{}, ErrInvalidCallback
+	}
+
+	signature, err := hex.DecodeString(r.Header.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(signature, f.sign(body)) {
+		return Callback{}, ErrInvalidCallback
+	}
+
+	var cb fakeCallback
+	if err = json.Unmarshal(body, &cb); err != nil || cb.ID == "" {
+		return Callback{}, ErrInvalidCallback
+	}
+
+	switch cb.Status {
+	case fakeSucceeded:
+		return Callback{ID: cb.ID, Succeeded: true}, nil
+	case fakeFailed:
+		return Callback{ID: cb.ID}, nil
+	default:
+		return Callback{}, ErrInvalidCallback
+	}
+}
+
+func (f *Fake) Refund(_ context.Context, id string, amount int64, idempotencyKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[id]
+	if !ok {
+		return fmt.Errorf("payment %s not found", id)
+	}
+	if p.RefundKeys[idempotencyKey] {
+		return nil
+	}
+	if !p.Paid {
+		return fmt.Errorf("payment %s is not paid", id)
+	}
+	if p.Refunded+amount > p.Amount {
+		return fmt.Errorf("refund of payment %s exceeds its amount", id)
+	}
+
+	p.Refunded += amount
+	if p.RefundKeys == nil {
+		p.RefundKeys = make(map[string]bool)
+	}
+	p.RefundKeys[idempotencyKey] = true
+
+	return nil
+}
+
+// NewCallback returns the signed request the checkout page sends to the
+// webhook when the payment with the given id is paid or declined.
+func (f *Fake) NewCallback(ctx context.Context, id string, succeeded bool) (*http.Request, error) {
+	status := fakeFailed
+	if succeeded {
+		status = fakeSucceeded
+	}
+
+	body, err := json.Marshal(fakeCallback{ID: id, Status: status})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(FakeSignatureHeader, hex.EncodeToString(f.sign(body)))
+
+	return req, nil
+}
+
+func (f *Fake) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+var checkoutPage = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>Тестовая оплата</title>
+<style>
+body { font-family: sans-serif; max-width: 28rem; margin: 3rem auto; }
+button { font-size: 1rem; margin-right: .5rem; }
+</style>
+</head>
+<body>
+<h1>Тестовая оплата</h1>
+<p>{{.Description}}</p>
+<p><strong>{{.Amount}}</strong></p>
+{{if .Result}}
+<p>{{.Result}}</p>
+<p><a href="/">Вернуться к мероприятиям</a></p>
+{{else}}
+<form method="post">
+<button name="result" value="pay">Оплатить</button>
+<button name="result" value="decline">Отклонить</button>
+</form>
+{{end}}
+<p><small>Деньги не списываются: это тестовый платёжный провайдер.</small></p>
+</body>
+</html>
+`))
+
+type checkoutData struct {
+	Description string
+	Amount      string
+	Result      string
+}
+
+// CheckoutHandler serves the checkout page of a payment, whose id is the
+// last path segment. Submitting the page sends the callback to the webhook.
+func (f *Fake) CheckoutHandler(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "payment.Fake.CheckoutHandler"
+
+		log := log.With(slog.String("op", op))
+
+		id := path.Base(r.URL.Path)
+
+		f.mu.Lock()
+		p, ok := f.payments[id]
+		var snapshot fakePayment
+		if ok {
+			snapshot = *p
+		}
+		f.mu.Unlock()
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		data := checkoutData{
+			Description: snapshot.Description,
+			Amount:      formatAmount(snapshot.Amount, snapshot.Currency),
+		}
+
+		switch {
+		case snapshot.Paid:
+			data.Result = "Платёж уже оплачен."
+		case snapshot.Declined:
+			data.Result = "Платёж отклонён."
+		case r.Method == http.MethodPost:
+			succeeded := r.PostFormValue("result") == "pay"
+
+			f.mu.Lock()
+			p.Paid = succeeded
+			p.Declined = !succeeded
+			f.mu.Unlock()
+
+			data.Result = "Платёж отклонён."
+			if succeeded {
+				data.Result = "Оплата прошла."
+			}
+
+			if err := f.deliver(r.Context(), id, succeeded); err != nil {
+				log.Error("failed to deliver callback", slog.String("payment_id", id), sl.Err(err))
+				data.Result = "Не удалось сообщить сервису об оплате: " + err.Error()
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := checkoutPage.Execute(w, data); err != nil {
+			log.Error("failed to render checkout page", sl.Err(err))
+		}
+	}
+}
+
+// deliver sends the callback of the payment to the webhook.
+func (f *Fake) deliver(ctx context.Context, id string, succeeded bool) error {
+	req, err := f.NewCallback(ctx, id, succeeded)
+	if err != nil {
+		return err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+
+	return nil
+}
+
+// formatAmount formats an amount in minor units, e.g. 150050 RUB as "1500.50 RUB".
+func formatAmount(amount int64, currency string) string {
+	return fmt.Sprintf("%d.%02d %s", amount/100, amount%100, currency)
+}
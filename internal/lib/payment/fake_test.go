@@ -0,0 +1,143 @@
+package payment
+
+import (
+	"context"
+	"eventBooker/internal/lib/logger/handlers/slogdiscard"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeCallback(t *testing.T) {
+	t.Parallel()
+
+	fake := NewFake("secret", "/payments/fake", "http://localhost/webhook")
+
+	for _, succeeded := range []bool{true, false} {
+		req, err := fake.NewCallback(context.Background(), "fake_1", succeeded)
+		require.NoError(t, err)
+
+		cb, err := fake.VerifyCallback(req)
+		require.NoError(t, err)
+		assert.Equal(t, Callback{ID: "fake_1", Succeeded: succeeded}, cb)
+	}
+}
+
+func TestFakeVerifyCallbackRejects(t *testing.T) {
+	t.Parallel()
+
+	fake := NewFake("secret", "/payments/fake", "http://localhost/webhook")
+
+	signed := func(f *Fake) *http.Request {
+		req, err := f.NewCallback(context.Background(), "fake_1", true)
+		require.NoError(t, err)
+		return req
+	}
+
+	testCases := []struct {
+		name string
+		req  func() *http.Request
+	}{
+		{
+			name: "Unsigned",
+			req: func() *http.Request {
+				req := signed(fake)
+				req.Header.Del(FakeSignatureHeader)
+				return req
+			},
+		},
+		{
+			name: "Other secret",
+			req:  func() *http.Request { return signed(NewFake("other", "", "http://localhost/webhook")) },
+		},
+		{
+			name: "Tampered body",
+			req: func() *http.Request {
+				req := signed(fake)
+				req.Body = io.NopCloser(strings.NewReader(`{"id":"fake_2","status":"succeeded"}`))
+				return req
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := fake.VerifyCallback(tc.req())
+			assert.ErrorIs(t, err, ErrInvalidCallback)
+		})
+	}
+}
+
+func TestFakeCheckout(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu        sync.Mutex
+		callbacks []Callback
+		fake      *Fake
+	)
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cb, err := fake.VerifyCallback(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		callbacks = append(callbacks, cb)
+		mu.Unlock()
+	}))
+	t.Cleanup(webhook.Close)
+
+	fake = NewFake("secret", "/payments/fake/", webhook.URL)
+
+	checkout, err := fake.CreateIntent(context.Background(), Intent{
+		PaymentID:   1,
+		Amount:      150050,
+		Currency:    "RUB",
+		Description: "Concert",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/payments/fake/"+checkout.ID, checkout.URL)
+
+	handler := fake.CheckoutHandler(slogdiscard.NewDiscardLogger())
+
+	rr := httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, checkout.URL, nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "1500.50 RUB")
+	assert.Contains(t, rr.Body.String(), "Concert")
+
+	require.Error(t, fake.Refund(context.Background(), checkout.ID, 150050, "refund-1"), "unpaid payment refunded")
+
+	form := url.Values{"result": {"pay"}}
+	req := httptest.NewRequest(http.MethodPost, checkout.URL, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr = httptest.NewRecorder()
+	handler(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Оплата прошла.")
+
+	mu.Lock()
+	assert.Equal(t, []Callback{{ID: checkout.ID, Succeeded: true}}, callbacks)
+	mu.Unlock()
+
+	require.NoError(t, fake.Refund(context.Background(), checkout.ID, 50, "refund-1"))
+	require.NoError(t, fake.Refund(context.Background(), checkout.ID, 50, "refund-1"))
+	assert.Equal(t, int64(50), fake.payments[checkout.ID].Refunded, "a repeated key is not paid out again")
+	require.Error(t, fake.Refund(context.Background(), checkout.ID, 150050, "refund-2"), "refund exceeds amount")
+
+	rr = httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, "/payments/fake/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,52 @@
+// Package payment takes payments for bookings through a provider. The user
+// pays on the provider's checkout page, and the provider reports the outcome
+// with a signed callback to the webhook, which is the only place a paid
+// booking gets confirmed.
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrInvalidCallback is returned for callbacks that are malformed or not
+// signed by the provider.
+var ErrInvalidCallback = errors.New("invalid payment callback")
+
+// Intent is a payment to be taken. Amount is in minor units of Currency.
+type Intent struct {
+	// PaymentID is the id of the payment in the payments table.
+	PaymentID   int
+	Amount      int64
+	Currency    string
+	Description string
+}
+
+// Checkout is a payment created at the provider.
+type Checkout struct {
+	// ID identifies the payment at the provider.
+	ID string
+	// URL is the provider's checkout page the user pays on.
+	URL string
+}
+
+// Callback is the outcome of a payment reported by the provider.
+type Callback struct {
+	// ID identifies the payment at the provider.
+	ID        string
+	Succeeded bool
+}
+
+type Provider interface {
+	// Name identifies the provider in the payments table.
+	Name() string
+	// CreateIntent creates the payment at the provider.
+	CreateIntent(ctx context.Context, intent Intent) (Checkout, error)
+	// VerifyCallback checks the signature of the provider's callback and
+	// returns the outcome it reports, or ErrInvalidCallback.
+	VerifyCallback(r *http.Request) (Callback, error)
+	// Refund returns amount of the succeeded payment to the user. Calls
+	// repeating an idempotency key are not paid out again.
+	Refund(ctx context.Context, id string, amount int64, idempotencyKey string) error
+}
@@ -0,0 +1,88 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/models"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrRefundFailed is returned when the provider does not return a refund.
+var ErrRefundFailed = errors.New("refund failed")
+
+// RefundCompleter claims refunds for sending and records their outcome.
+type RefundCompleter interface {
+	// ClaimRefund marks the pending refund as being sent. It fails for a
+	// refund claimed already, so that only one caller sends it.
+	ClaimRefund(ctx context.Context, id int) error
+	CompleteRefund(ctx context.Context, id int, succeeded bool) error
+}
+
+// StaleRefundsClaimer claims the refunds nobody is sending.
+type StaleRefundsClaimer interface {
+	// ClaimStaleRefunds claims the refunds left pending since before t and
+	// those claimed before t but never completed, oldest first.
+	ClaimStaleRefunds(ctx context.Context, t time.Time) ([]models.Refund, error)
+}
+
+// ExecuteRefund claims the pending refund, sends it to the provider and
+// records whether it succeeded. A refund the provider fails is recorded as
+// failed and reported with ErrRefundFailed.
+func ExecuteRefund(ctx context.Context, provider Provider, refunds RefundCompleter, r models.Refund) error {
+	if err := refunds.ClaimRefund(ctx, r.ID); err != nil {
+		return fmt.Errorf("failed to claim refund %d: %w", r.ID, err)
+	}
+
+	return sendRefund(ctx, provider, refunds, r)
+}
+
+// sendRefund sends the claimed refund to the provider and records whether
+// it succeeded. The refund's id is the idempotency key, so a refund sent
+// again after a crash is not paid out twice.
+func sendRefund(ctx context.Context, provider Provider, refunds RefundCompleter, r models.Refund) error {
+	refundErr := provider.Refund(ctx, r.ProviderID, r.Amount, RefundKey(r.ID))
+
+	if err := refunds.CompleteRefund(ctx, r.ID, refundErr == nil); err != nil {
+		return fmt.Errorf("failed to record refund %d: %w", r.ID, err)
+	}
+
+	if refundErr != nil {
+		return fmt.Errorf("%w: %w", ErrRefundFailed, refundErr)
+	}
+
+	return nil
+}
+
+// RefundKey is the idempotency key of the refund at the provider.
+func RefundKey(refundID int) string {
+	return "refund-" + strconv.Itoa(refundID)
+}
+
+// ResendRefunds claims and sends the refunds nobody has sent since before t:
+// those left behind when a server stopped between recording, or claiming,
+// and completing them, and those recorded by eventctl, which leaves sending
+// them to the server. It returns how many were sent and the errors of the
+// others.
+func ResendRefunds(ctx context.Context, provider Provider, refunds interface {
+	StaleRefundsClaimer
+	RefundCompleter
+}, before time.Time) (int, error) {
+	claimed, err := refunds.ClaimStaleRefunds(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, r := range claimed {
+		if err = sendRefund(ctx, provider, refunds, r); err != nil {
+			errs = append(errs, fmt.Errorf("refund %d: %w", r.ID, err))
+			continue
+		}
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
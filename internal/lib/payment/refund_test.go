@@ -0,0 +1,101 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type refundRecorder struct {
+	claimed   map[int]bool
+	completed map[int]bool
+}
+
+func newRefundRecorder() *refundRecorder {
+	return &refundRecorder{claimed: map[int]bool{}, completed: map[int]bool{}}
+}
+
+func (r *refundRecorder) ClaimRefund(_ context.Context, id int) error {
+	if r.claimed[id] {
+		return errors.New("refund not pending")
+	}
+	r.claimed[id] = true
+	return nil
+}
+
+func (r *refundRecorder) CompleteRefund(_ context.Context, id int, succeeded bool) error {
+	if _, ok := r.completed[id]; ok || !r.claimed[id] {
+		return errors.New("refund not found")
+	}
+	r.completed[id] = succeeded
+	return nil
+}
+
+func TestExecuteRefund(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake := NewFake("secret", "/payments/fake", "")
+
+	checkout, err := fake.CreateIntent(ctx, Intent{PaymentID: 1, Amount: 1000, Currency: "EUR"})
+	require.NoError(t, err)
+	fake.payments[checkout.ID].Paid = true
+
+	recorded := newRefundRecorder()
+
+	require.NoError(t, ExecuteRefund(ctx, fake, recorded, models.Refund{ID: 1, ProviderID: checkout.ID, Amount: 600}))
+	assert.True(t, recorded.completed[1])
+
+	err = ExecuteRefund(ctx, fake, recorded, models.Refund{ID: 2, ProviderID: checkout.ID, Amount: 600})
+	assert.ErrorIs(t, err, ErrRefundFailed, "refunds exceed the payment")
+	assert.False(t, recorded.completed[2])
+
+	err = ExecuteRefund(ctx, fake, recorded, models.Refund{ID: 1, ProviderID: checkout.ID, Amount: 100})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRefundFailed, "storage errors are not refund failures")
+	assert.Equal(t, int64(600), fake.payments[checkout.ID].Refunded, "a refund claimed already is not sent again")
+}
+
+type staleRefunds struct {
+	*refundRecorder
+	stale  []models.Refund
+	before time.Time
+}
+
+func (s *staleRefunds) ClaimStaleRefunds(_ context.Context, t time.Time) ([]models.Refund, error) {
+	s.before = t
+	for _, r := range s.stale {
+		s.claimed[r.ID] = true
+	}
+	return s.stale, nil
+}
+
+func TestResendRefunds(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake := NewFake("secret", "/payments/fake", "")
+
+	checkout, err := fake.CreateIntent(ctx, Intent{PaymentID: 1, Amount: 1000, Currency: "EUR"})
+	require.NoError(t, err)
+	fake.payments[checkout.ID].Paid = true
+
+	before := time.Now().Add(-time.Minute)
+	store := &staleRefunds{refundRecorder: newRefundRecorder(), stale: []models.Refund{
+		{ID: 1, ProviderID: checkout.ID, Amount: 600},
+		{ID: 2, ProviderID: "fake_unknown", Amount: 100},
+		{ID: 3, ProviderID: checkout.ID, Amount: 400},
+	}}
+
+	sent, err := ResendRefunds(ctx, fake, store, before)
+	assert.Equal(t, 2, sent)
+	assert.ErrorIs(t, err, ErrRefundFailed)
+	assert.ErrorContains(t, err, "refund 2")
+	assert.Equal(t, before, store.before)
+	assert.Equal(t, map[int]bool{1: true, 2: false, 3: true}, store.completed, "one failed refund does not hold up the others")
+}
@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory keeps buckets in process memory. It is only correct for a single instance.
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]Bucket
+	now     func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: make(map[string]Bucket),
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bucket, res := Take(m.buckets[key], m.now(), limit)
+	m.buckets[key] = bucket
+
+	return res, nil
+}
+
+func (m *Memory) Cleanup(_ context.Context, idle time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	threshold := m.now().Add(-idle)
+	for key, bucket := range m.buckets {
+		if bucket.Updated.Before(threshold) {
+			delete(m.buckets, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit describes a token bucket: Burst tokens at most, refilled at Rate tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Every returns a Limit that allows n requests per period with the given burst.
+// A zero burst defaults to n.
+func Every(n int, period time.Duration, burst int) Limit {
+	if burst <= 0 {
+		burst = n
+	}
+
+	return Limit{
+		Rate:  float64(n) / period.Seconds(),
+		Burst: burst,
+	}
+}
+
+// RefillTime returns how long an empty bucket takes to fill up again.
+// A bucket idle for longer is full, the same as one never used.
+func (l Limit) RefillTime() time.Duration {
+	return seconds(float64(l.Burst) / l.Rate)
+}
+
+// Bucket is the persisted state of a single token bucket.
+type Bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+	Cleanup(ctx context.Context, idle time.Duration) error
+}
+
+// Take refills the bucket up to now and tries to consume one token from it.
+// It is shared by all Limiter implementations so they agree on the math.
+func Take(b Bucket, now time.Time, l Limit) (Bucket, Result) {
+	burst := float64(l.Burst)
+
+	if b.Updated.IsZero() {
+		b.Tokens = burst
+	} else if elapsed := now.Sub(b.Updated).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(burst, b.Tokens+elapsed*l.Rate)
+	}
+	b.Updated = now
+
+	res := Result{Limit: l.Burst}
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - b.Tokens) / l.Rate)
+	}
+
+	res.Remaining = int(b.Tokens)
+	res.ResetAfter = seconds((burst - b.Tokens) / l.Rate)
+
+	return b, res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limit := Every(2, time.Second, 2)
+
+	bucket, res := Take(Bucket{}, now, limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Limit)
+	assert.Equal(t, 1, res.Remaining)
+
+	bucket, res = Take(bucket, now, limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, time.Second, res.ResetAfter)
+
+	bucket, res = Take(bucket, now, limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+
+	bucket, res = Take(bucket, now.Add(500*time.Millisecond), limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	_, res = Take(bucket, now.Add(time.Hour), limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining, "refill must not exceed burst")
+}
+
+func TestRefillTime(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Second, Every(2, time.Second, 2).RefillTime())
+	assert.Equal(t, 24*time.Hour, Every(10, 24*time.Hour, 0).RefillTime())
+	assert.Equal(t, 5*time.Minute, Every(2, time.Minute, 10).RefillTime(), "a burst above the rate takes longer to refill")
+}
+
+func TestMemoryCleanup(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	_, err := m.Allow(context.Background(), "a", Every(1, time.Minute, 0))
+	assert.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	assert.NoError(t, m.Cleanup(context.Background(), time.Hour))
+	assert.Empty(t, m.buckets)
+}
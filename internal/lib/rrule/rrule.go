@@ -0,0 +1,452 @@
+// Package rrule parses and expands iCalendar recurrence rules (RFC 5545, 3.3.10).
+//
+// It supports what recurring events need: FREQ of DAILY, WEEKLY, MONTHLY or
+// YEARLY with INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY and BYMONTH. Weeks
+// start on Monday. Other rule parts are rejected rather than ignored, so a
+// rule never expands to dates its author did not mean.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+const (
+	untilDateTime = "20060102T150405Z"
+	untilDate     = "20060102"
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Weekday is an entry of BYDAY. N picks the Nth such weekday of the month,
+// counting from the end when negative; 0 picks every one.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+func (w Weekday) String() string {
+	day := strings.ToUpper(w.Day.String()[:2])
+	if w.N == 0 {
+		return day
+	}
+
+	return strconv.Itoa(w.N) + day
+}
+
+// Rule is a parsed recurrence rule. The zero Count and Until do not limit it.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+// Parse parses a rule such as "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10". The
+// "RRULE:" prefix of a content line is accepted.
+func Parse(s string) (Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, errors.New("rrule: empty rule")
+	}
+
+	r := Rule{Interval: 1}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" {
+			return Rule{}, fmt.Errorf("rrule: invalid part %q", part)
+		}
+		if seen[name] {
+			return Rule{}, fmt.Errorf("rrule: %s is given twice", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+			if !slices.Contains([]Frequency{Daily, Weekly, Monthly, Yearly}, r.Freq) {
+				err = fmt.Errorf("unsupported frequency %s", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = positive(value)
+		case "COUNT":
+			r.Count, err = positive(value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value, 1, 31, true)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(value, 1, 12, false)
+			for _, m := range months {
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				err = errors.New("only weeks starting on MO are supported")
+			}
+		default:
+			err = errors.New("unsupported rule part")
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("rrule: %s: %w", name, err)
+		}
+	}
+
+	if err := r.check(); err != nil {
+		return Rule{}, fmt.Errorf("rrule: %w", err)
+	}
+
+	return r, nil
+}
+
+// check reports combinations of parts RFC 5545 forbids or this package does
+// not expand.
+func (r Rule) check() error {
+	if r.Freq == "" {
+		return errors.New("FREQ is required")
+	}
+	if r.Count != 0 && !r.Until.IsZero() {
+		return errors.New("COUNT and UNTIL cannot both be given")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	if r.Freq == Yearly && len(r.ByDay) > 0 && len(r.ByMonth) == 0 {
+		return errors.New("BYDAY with FREQ=YEARLY requires BYMONTH")
+	}
+	if r.Freq == Daily || r.Freq == Weekly {
+		for _, d := range r.ByDay {
+			if d.N != 0 {
+				return fmt.Errorf("BYDAY %s cannot have a position with FREQ=%s", d, r.Freq)
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns the rule with its parts in a fixed order, without the
+// "RRULE:" prefix.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilDateTime))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = strconv.Itoa(int(m))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Between returns the occurrences of the rule starting at start that fall
+// in [from, to), in order. Occurrences keep the wall-clock time of start in
+// its location, so they stay at the same local time across daylight saving
+// changes. start itself is an occurrence only if it matches the rule.
+func (r Rule) Between(start, from, to time.Time) []time.Time {
+	var (
+		out   []time.Time
+		count int
+	)
+
+	loc := start.Location()
+	year, month, day := start.Date()
+	hour, minute, sec := start.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, sec, 0, loc)
+	}
+
+	for period := 0; ; period++ {
+		step := period * max(r.Interval, 1)
+
+		var first time.Time
+		var days []time.Time
+		switch r.Freq {
+		case Daily:
+			first = at(year, month, day+step)
+			days = r.filter([]time.Time{first})
+		case Weekly:
+			monday := day - (int(start.Weekday())+6)%7 + 7*step
+			first = at(year, month, monday)
+			days = r.filter(r.weekDays(at, year, month, monday, start.Weekday()))
+		case Monthly:
+			first = at(year, month+time.Month(step), 1)
+			days = r.filter(r.monthDays(at, first.Year(), first.Month(), day, start.Weekday()))
+		case Yearly:
+			first = at(year+step, time.January, 1)
+			months := r.ByMonth
+			if len(months) == 0 {
+				months = []time.Month{month}
+			}
+			for _, m := range months {
+				days = append(days, r.monthDays(at, year+step, m, day, start.Weekday())...)
+			}
+			days = r.filter(days)
+		default:
+			return nil
+		}
+
+		if !first.Before(to) || (!r.Until.IsZero() && first.After(r.Until)) {
+			return out
+		}
+
+		slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+		days = slices.CompactFunc(days, time.Time.Equal)
+
+		for _, t := range days {
+			if t.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return out
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return out
+			}
+			if !t.Before(to) {
+				return out
+			}
+			if !t.Before(from) {
+				out = append(out, t)
+			}
+		}
+	}
+}
+
+// weekDays returns the days of the week starting on monday, the given day
+// of month, picked by BYDAY, or the weekday of the start without it.
+func (r Rule) weekDays(at func(int, time.Month, int) time.Time, year int, month time.Month, monday int, startDay time.Weekday) []time.Time {
+	if len(r.ByDay) == 0 {
+		return []time.Time{at(year, month, monday+(int(startDay)+6)%7)}
+	}
+
+	days := make([]time.Time, 0, len(r.ByDay))
+	for _, d := range r.ByDay {
+		days = append(days, at(year, month, monday+(int(d.Day)+6)%7))
+	}
+
+	return days
+}
+
+// monthDays returns the days of the month picked by BYMONTHDAY and BYDAY,
+// both if both are given, or the day of month of the start without them.
+// Days a month does not have, such as the 31st of April, are skipped.
+func (r Rule) monthDays(at func(int, time.Month, int) time.Time, year int, month time.Month, startDay int, startWeekday time.Weekday) []time.Time {
+	length := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	var candidates []int
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d += length + 1
+			}
+			if d >= 1 && d <= length {
+				candidates = append(candidates, d)
+			}
+		}
+	case len(r.ByDay) > 0:
+		for d := 1; d <= length; d++ {
+			candidates = append(candidates, d)
+		}
+	default:
+		if startDay <= length {
+			candidates = append(candidates, startDay)
+		}
+	}
+
+	days := make([]time.Time, 0, len(candidates))
+	for _, d := range candidates {
+		if len(r.ByDay) == 0 || r.matchesByDay(time.Date(year, month, d, 0, 0, 0, 0, time.UTC), length) {
+			days = append(days, at(year, month, d))
+		}
+	}
+
+	return days
+}
+
+// matchesByDay reports whether the day, in a month of the given length,
+// is picked by BYDAY.
+func (r Rule) matchesByDay(day time.Time, length int) bool {
+	for _, d := range r.ByDay {
+		if d.Day != day.Weekday() {
+			continue
+		}
+		switch {
+		case d.N == 0:
+			return true
+		case d.N > 0 && (day.Day()-1)/7+1 == d.N:
+			return true
+		case d.N < 0 && (length-day.Day())/7+1 == -d.N:
+			return true
+		}
+	}
+
+	return false
+}
+
+// filter drops the days BYMONTH, BYMONTHDAY or BYDAY exclude. Parts that
+// generated the days pass them, so only limiting parts take effect.
+func (r Rule) filter(days []time.Time) []time.Time {
+	out := days[:0]
+	for _, t := range days {
+		if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, t.Month()) {
+			continue
+		}
+		if r.Freq == Daily {
+			if len(r.ByMonthDay) > 0 && !r.matchesByMonthDay(t) {
+				continue
+			}
+			if len(r.ByDay) > 0 && !slices.ContainsFunc(r.ByDay, func(d Weekday) bool { return d.Day == t.Weekday() }) {
+				continue
+			}
+		}
+		out = append(out, t)
+	}
+
+	return out
+}
+
+func (r Rule) matchesByMonthDay(t time.Time) bool {
+	length := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range r.ByMonthDay {
+		if d == t.Day() || d+length+1 == t.Day() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func positive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive integer", value)
+	}
+
+	return n, nil
+}
+
+// parseUntil parses a UTC date-time or a date, which includes the whole day.
+// Floating date-times are taken as UTC.
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(untilDateTime, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(strings.TrimSuffix(untilDateTime, "Z"), value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(untilDate, value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not a date or a UTC date-time", value)
+}
+
+func parseByDay(value string) ([]Weekday, error) {
+	var days []Weekday
+	for _, item := range strings.Split(strings.ToUpper(value), ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+
+		var n int
+		if pos := item[:len(item)-2]; pos != "" {
+			var err error
+			n, err = strconv.Atoi(pos)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid weekday position %q", item)
+			}
+		}
+
+		days = append(days, Weekday{Day: day, N: n})
+	}
+
+	return days, nil
+}
+
+// parseInts parses a list of integers in [lo, hi], or also in [-hi, -lo]
+// when negative ones count from the end.
+func parseInts(value string, lo, hi int, negative bool) ([]int, error) {
+	var out []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", item)
+		}
+
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+		if abs < lo || abs > hi {
+			return nil, fmt.Errorf("%d is out of range", n)
+		}
+
+		out = append(out, n)
+	}
+
+	return out, nil
+}
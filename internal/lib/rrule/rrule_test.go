@@ -0,0 +1,160 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr string
+	}{
+		{name: "Weekly", rule: "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10", want: "FREQ=WEEKLY;COUNT=10;BYDAY=TU,TH"},
+		{name: "Content line", rule: "RRULE:freq=monthly;byday=-1fr;interval=2", want: "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR"},
+		{name: "Until date", rule: "FREQ=DAILY;UNTIL=20300601", want: "FREQ=DAILY;UNTIL=20300601T235959Z"},
+		{name: "Yearly", rule: "FREQ=YEARLY;BYMONTH=3,9;BYMONTHDAY=-1;WKST=MO", want: "FREQ=YEARLY;BYMONTH=3,9;BYMONTHDAY=-1"},
+		{name: "Empty", rule: " ", wantErr: "rrule: empty rule"},
+		{name: "No frequency", rule: "COUNT=3", wantErr: "rrule: FREQ is required"},
+		{name: "Unsupported frequency", rule: "FREQ=HOURLY", wantErr: "rrule: FREQ: unsupported frequency HOURLY"},
+		{name: "Unsupported part", rule: "FREQ=MONTHLY;BYSETPOS=-1", wantErr: "rrule: BYSETPOS: unsupported rule part"},
+		{name: "Repeated part", rule: "FREQ=DAILY;COUNT=1;COUNT=2", wantErr: "rrule: COUNT is given twice"},
+		{name: "Count and until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20300601T000000Z", wantErr: "rrule: COUNT and UNTIL cannot both be given"},
+		{name: "Zero interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: `rrule: INTERVAL: "0" is not a positive integer`},
+		{name: "Weekly by month day", rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: "rrule: BYMONTHDAY cannot be used with FREQ=WEEKLY"},
+		{name: "Weekly by day position", rule: "FREQ=WEEKLY;BYDAY=2MO", wantErr: "rrule: BYDAY 2MO cannot have a position with FREQ=WEEKLY"},
+		{name: "Invalid weekday", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: `rrule: BYDAY: invalid weekday "XX"`},
+		{name: "Month out of range", rule: "FREQ=YEARLY;BYMONTH=13", wantErr: "rrule: BYMONTH: 13 is out of range"},
+		{name: "Missing value", rule: "FREQ=DAILY;COUNT", wantErr: `rrule: invalid part "COUNT"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := Parse(tc.rule)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, r.String())
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// Tuesday.
+	start := time.Date(2030, time.January, 1, 19, 0, 0, 0, time.UTC)
+	to := start.AddDate(2, 0, 0)
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		want  []string
+	}{
+		{
+			name: "Daily with count",
+			rule: "FREQ=DAILY;COUNT=3",
+			want: []string{"2030-01-01T19:00:00Z", "2030-01-02T19:00:00Z", "2030-01-03T19:00:00Z"},
+		},
+		{
+			name: "Daily on weekdays",
+			rule: "FREQ=DAILY;BYDAY=SA,SU;COUNT=3",
+			want: []string{"2030-01-05T19:00:00Z", "2030-01-06T19:00:00Z", "2030-01-12T19:00:00Z"},
+		},
+		{
+			name: "Weekly on two days",
+			rule: "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4",
+			want: []string{"2030-01-01T19:00:00Z", "2030-01-03T19:00:00Z", "2030-01-08T19:00:00Z", "2030-01-10T19:00:00Z"},
+		},
+		{
+			name: "Every other week until",
+			rule: "FREQ=WEEKLY;INTERVAL=2;UNTIL=20300129T190000Z",
+			want: []string{"2030-01-01T19:00:00Z", "2030-01-15T19:00:00Z", "2030-01-29T19:00:00Z"},
+		},
+		{
+			name: "Monthly on the last Friday",
+			rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			want: []string{"2030-01-25T19:00:00Z", "2030-02-22T19:00:00Z", "2030-03-29T19:00:00Z"},
+		},
+		{
+			name:  "Monthly skips short months",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: time.Date(2030, time.January, 31, 19, 0, 0, 0, time.UTC),
+			want:  []string{"2030-01-31T19:00:00Z", "2030-03-31T19:00:00Z", "2030-05-31T19:00:00Z"},
+		},
+		{
+			name: "Monthly on the last day",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=2",
+			want: []string{"2030-01-31T19:00:00Z", "2030-02-28T19:00:00Z"},
+		},
+		{
+			name: "Monthly on Friday the 13th",
+			rule: "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=2",
+			want: []string{"2030-09-13T19:00:00Z", "2030-12-13T19:00:00Z"},
+		},
+		{
+			name: "Yearly in two months",
+			rule: "FREQ=YEARLY;BYMONTH=1,7;COUNT=3",
+			want: []string{"2030-01-01T19:00:00Z", "2030-07-01T19:00:00Z", "2031-01-01T19:00:00Z"},
+		},
+		{
+			name: "Yearly on the first Sunday of May",
+			rule: "FREQ=YEARLY;BYMONTH=5;BYDAY=1SU",
+			want: []string{"2030-05-05T19:00:00Z", "2031-05-04T19:00:00Z"},
+		},
+		{
+			name: "Window counts from start",
+			rule: "FREQ=WEEKLY;COUNT=4",
+			from: time.Date(2030, time.January, 10, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2030, time.January, 23, 0, 0, 0, 0, time.UTC),
+			want: []string{"2030-01-15T19:00:00Z", "2030-01-22T19:00:00Z"},
+		},
+		{
+			name:  "Wall clock across daylight saving",
+			rule:  "FREQ=WEEKLY;COUNT=2",
+			start: time.Date(2030, time.March, 26, 19, 0, 0, 0, berlin),
+			want:  []string{"2030-03-26T19:00:00+01:00", "2030-04-02T19:00:00+02:00"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := Parse(tc.rule)
+			require.NoError(t, err)
+
+			s, from, until := start, tc.from, to
+			if !tc.start.IsZero() {
+				s = tc.start
+			}
+			if !tc.to.IsZero() {
+				until = tc.to
+			}
+
+			got := make([]string, 0)
+			for _, d := range r.Between(s, from, until) {
+				got = append(got, d.Format(time.RFC3339))
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package ticket
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", ed25519.SeedSize)))
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+
+	signer, err := ParseKey(testKey)
+	require.NoError(t, err)
+
+	want := Ticket{BookingID: 12, EventID: 3, UserID: "mailto:user@example.com"}
+	token := signer.Sign(want)
+	assert.Equal(t, token, signer.Sign(want), "signing must be deterministic")
+
+	got, err := Verify(signer.PublicKey(), token)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestVerifyRejects(t *testing.T) {
+	t.Parallel()
+
+	signer, err := ParseKey(testKey)
+	require.NoError(t, err)
+
+	other, err := ParseKey(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", ed25519.SeedSize))))
+	require.NoError(t, err)
+
+	token := signer.Sign(Ticket{BookingID: 1, EventID: 1, UserID: "alice"})
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	raw[3] ^= 1
+
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{name: "Empty", token: ""},
+		{name: "Not base64", token: token + "!"},
+		{name: "Too short", token: token[:20]},
+		{name: "Tampered payload", token: base64.RawURLEncoding.EncodeToString(raw)},
+		{name: "Other key", token: other.Sign(Ticket{BookingID: 1, EventID: 1, UserID: "alice"})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := signer.Verify(tc.token)
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseKey("not base64!")
+	assert.Error(t, err)
+
+	_, err = ParseKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.EqualError(t, err, "ticket: signing key must be 32 bytes, got 5")
+}
@@ -0,0 +1,104 @@
+// Package ticketpdf renders printable tickets. The PDF is built in
+// process with embedded Go fonts, so it needs no external services and
+// can be served over HTTP or attached to an email alike.
+package ticketpdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	ContentType = "application/pdf"
+
+	// fontFamily is the name the embedded Go fonts are registered under.
+	fontFamily = "go"
+
+	// qrSide is the width and height of the QR code on the page in millimetres.
+	qrSide = 80.0
+)
+
+type Ticket struct {
+	EventTitle string
+	// Date is printed in its own location, so callers pass it in the event's time zone.
+	Date      time.Time
+	Attendee  string
+	BookingID int
+	// Code is the signed ticket token encoded into the QR code.
+	Code string
+}
+
+// Filename returns the suggested name of the ticket's file.
+func Filename(bookingID int) string {
+	return "ticket-" + strconv.Itoa(bookingID) + ".pdf"
+}
+
+// Render writes the ticket as a single A5 page.
+func Render(w io.Writer, t Ticket) error {
+	qr, err := qrcode.Encode(t.Code, qrcode.Medium, 512)
+	if err != nil {
+		return fmt.Errorf("ticketpdf: encode qr code: %w", err)
+	}
+
+	pdf := gofpdf.New("P", "mm", "A5", "")
+	pdf.SetTitle("Билет №"+strconv.Itoa(t.BookingID), true)
+	pdf.SetCreator("eventBooker", true)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", gobold.TTF)
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	width := pageWidth - left - right
+
+	pdf.SetFont(fontFamily, "B", 20)
+	pdf.MultiCell(width, 9, t.EventTitle, "", "L", false)
+	pdf.Ln(4)
+
+	field := func(label, value string) {
+		pdf.SetFont(fontFamily, "", 9)
+		pdf.SetTextColor(110, 110, 110)
+		pdf.CellFormat(width, 5, label, "", 1, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", 13)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.MultiCell(width, 6, value, "", "L", false)
+		pdf.Ln(3)
+	}
+
+	field("Дата и время", t.Date.Format("02.01.2006 15:04")+" ("+zoneName(t.Date)+")")
+	field("Участник", t.Attendee)
+	field("Номер брони", strconv.Itoa(t.BookingID))
+
+	pdf.RegisterImageOptionsReader("qr", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions("qr", (pageWidth-qrSide)/2, pdf.GetY()+2, qrSide, qrSide, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.SetY(pdf.GetY() + qrSide + 6)
+
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.SetTextColor(110, 110, 110)
+	pdf.MultiCell(width, 5, "Покажите QR-код на входе. Билет действителен для одного прохода.", "", "C", false)
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("ticketpdf: render: %w", err)
+	}
+
+	return nil
+}
+
+// zoneName returns the IANA name of the date's location, or its
+// abbreviation for locations without one, such as fixed offsets.
+func zoneName(date time.Time) string {
+	if name := date.Location().String(); name != "" && name != "Local" {
+		return name
+	}
+
+	abbr, _ := date.Zone()
+	return abbr
+}
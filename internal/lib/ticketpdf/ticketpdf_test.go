@@ -0,0 +1,41 @@
+package ticketpdf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := Render(&buf, Ticket{
+		EventTitle: "Конференция Go",
+		Date:       time.Date(2099, 6, 1, 19, 30, 0, 0, time.UTC),
+		Attendee:   "user123",
+		BookingID:  7,
+		Code:       "dDE6Nzox",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.Contains(t, buf.String(), "/Subtype /Image")
+	assert.True(t, bytes.HasSuffix(bytes.TrimSpace(buf.Bytes()), []byte("%%EOF")))
+}
+
+func TestZoneName(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	date := time.Date(2099, 6, 1, 19, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "UTC", zoneName(date))
+	assert.Equal(t, "Europe/Berlin", zoneName(date.In(berlin)))
+	assert.Equal(t, "+03", zoneName(date.In(time.FixedZone("+03", 3*60*60))))
+}
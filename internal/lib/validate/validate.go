@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"eventBooker/internal/config"
+	"eventBooker/internal/lib/rrule"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// TagFuture fails unless a time.Time field is after now.
+	TagFuture = "future"
+	// TagLtUntil fails unless a number of minutes is shorter than the time left
+	// until the time.Time field named by the param, e.g. `ltuntil=Date`.
+	TagLtUntil = "ltuntil"
+	// TagSeats is an alias for a positive seat count within the configured maximum.
+	TagSeats = "seats"
+	// TagRRule fails unless a string is a recurrence rule the rrule package can expand.
+	TagRRule = "rrule"
+)
+
+// New returns a validator with the custom tags registered. It is safe for
+// concurrent use and meant to be built once and shared by all handlers.
+// Field names in validation errors are taken from json tags, so they match the request body.
+func New(cfg config.Validation) *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(jsonName)
+
+	// Registering built-in style validators only fails on an empty tag name or nil func.
+	_ = v.RegisterValidation(TagFuture, future)
+	_ = v.RegisterValidation(TagLtUntil, ltUntil)
+	_ = v.RegisterValidation(TagRRule, recurrenceRule)
+
+	v.RegisterAlias(TagSeats, fmt.Sprintf("gt=0,max=%d", cfg.MaxSeats))
+
+	return v
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+func future(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+
+	return ok && t.After(time.Now())
+}
+
+func ltUntil(fl validator.FieldLevel) bool {
+	other, _, _, ok := fl.GetStructFieldOK2()
+	if !ok {
+		return false
+	}
+
+	until, ok := other.Interface().(time.Time)
+	if !ok {
+		return false
+	}
+
+	minutes := fl.Field().Int()
+
+	return time.Duration(minutes)*time.Minute < time.Until(until)
+}
+
+func recurrenceRule(fl validator.FieldLevel) bool {
+	_, err := rrule.Parse(fl.Field().String())
+
+	return err == nil
+}
@@ -0,0 +1,85 @@
+package validate
+
+import (
+	"errors"
+	"eventBooker/internal/config"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type event struct {
+	Date       time.Time `json:"date" validate:"future"`
+	TotalSeats int       `json:"total_seats" validate:"seats"`
+	Deadline   int       `json:"deadline" validate:"ltuntil=Date"`
+	Rule       string    `json:"rrule" validate:"omitempty,rrule"`
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	v := New(config.Validation{MaxSeats: 100})
+	inTwoHours := time.Now().Add(2 * time.Hour)
+
+	testCases := []struct {
+		name         string
+		event        event
+		expectedTags map[string]string
+	}{
+		{
+			name:  "Valid",
+			event: event{Date: inTwoHours, TotalSeats: 100, Deadline: 60, Rule: "FREQ=WEEKLY;BYDAY=MO"},
+		},
+		{
+			name:         "Date in the past",
+			event:        event{Date: time.Now().Add(-time.Minute), TotalSeats: 10, Deadline: 0},
+			expectedTags: map[string]string{"date": TagFuture, "deadline": TagLtUntil},
+		},
+		{
+			name:         "No seats",
+			event:        event{Date: inTwoHours, TotalSeats: 0, Deadline: 60},
+			expectedTags: map[string]string{"total_seats": TagSeats},
+		},
+		{
+			name:         "Seats above maximum",
+			event:        event{Date: inTwoHours, TotalSeats: 101, Deadline: 60},
+			expectedTags: map[string]string{"total_seats": TagSeats},
+		},
+		{
+			name:         "Unsupported recurrence rule",
+			event:        event{Date: inTwoHours, TotalSeats: 10, Deadline: 60, Rule: "FREQ=SECONDLY"},
+			expectedTags: map[string]string{"rrule": TagRRule},
+		},
+		{
+			name:         "Deadline after the event starts",
+			event:        event{Date: inTwoHours, TotalSeats: 10, Deadline: 120},
+			expectedTags: map[string]string{"deadline": TagLtUntil},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v.Struct(tc.event)
+			if len(tc.expectedTags) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var validateErr validator.ValidationErrors
+			require.True(t, errors.As(err, &validateErr), "unexpected error: %v", err)
+
+			tags := make(map[string]string, len(validateErr))
+			for _, fe := range validateErr {
+				tags[fe.Field()] = fe.Tag()
+			}
+
+			assert.Equal(t, tc.expectedTags, tags)
+		})
+	}
+}
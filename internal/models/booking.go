@@ -8,4 +8,24 @@ type Booking struct {
 	UserID    string    `json:"user_id"`
 	CreatedAt time.Time `json:"created_at"`
 	Confirmed bool      `json:"confirmed"`
+	// TicketTypeID is the tier the booking was made for, nil for events without ticket types.
+	TicketTypeID *int `json:"ticket_type_id,omitempty"`
+	// SeatID is the reserved seat, nil for events without a seat layout.
+	SeatID *int `json:"seat_id,omitempty"`
+	// CheckedInAt is when the ticket of the booking was scanned at the door, nil until then.
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+}
+
+const (
+	BookingPending   = "pending"
+	BookingConfirmed = "confirmed"
+)
+
+// Status returns BookingConfirmed or BookingPending.
+func (b Booking) Status() string {
+	if b.Confirmed {
+		return BookingConfirmed
+	}
+
+	return BookingPending
 }
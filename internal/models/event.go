@@ -1,12 +1,104 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Event struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	// Date is the start of the event in UTC.
 	Date        time.Time `json:"date"`
 	TotalSeats  int       `json:"total_seats"`
 	BookedSeats int       `json:"booked_seats"`
 	Deadline    int       `json:"deadline_minutes"`
+	// LayoutID is the seat layout of an event with reserved seating, nil for general admission.
+	LayoutID *int `json:"layout_id,omitempty"`
+	// VenueID is the venue the event is held at, nil if it has none.
+	VenueID *int `json:"venue_id,omitempty"`
+	// SeriesID is the series the event is an occurrence of, nil if it has none.
+	SeriesID *int `json:"series_id,omitempty"`
+	// Timezone is the IANA time zone the event is held in: its own, else
+	// its venue's, else UTC.
+	Timezone string `json:"timezone,omitempty"`
+	// LocalDate is Date on the wall clock of Timezone, set by Localize.
+	LocalDate *time.Time `json:"local_date,omitempty"`
+	Schedule
+}
+
+// Schedule is the lifecycle of an event: its status, when a draft is
+// published and when its tickets are sold. Nil times do not limit.
+type Schedule struct {
+	Status string `json:"status,omitempty"`
+	// PublishAt is when a draft is published, nil to publish it by hand.
+	PublishAt    *time.Time `json:"publish_at,omitempty"`
+	SalesOpenAt  *time.Time `json:"sales_open_at,omitempty"`
+	SalesCloseAt *time.Time `json:"sales_close_at,omitempty"`
+}
+
+const (
+	// EventDraft is hidden from the public list and takes no bookings.
+	EventDraft = "draft"
+	// EventPublished is listed and takes bookings within its sales window.
+	EventPublished = "published"
+	// EventSalesClosed is listed but takes no more bookings.
+	EventSalesClosed = "sales_closed"
+	// EventCancelled has had its bookings cancelled and refunded.
+	EventCancelled = "cancelled"
+	// EventCompleted has taken place.
+	EventCompleted = "completed"
+)
+
+// eventTransitions are the statuses an event may move to from each status.
+// Cancelled and completed events are final.
+var eventTransitions = map[string][]string{
+	EventDraft:       {EventPublished, EventCancelled},
+	EventPublished:   {EventSalesClosed, EventCancelled, EventCompleted},
+	EventSalesClosed: {EventPublished, EventCancelled, EventCompleted},
+}
+
+// CanTransition reports whether an event may move from status from to status to.
+func CanTransition(from, to string) bool {
+	return slices.Contains(eventTransitions[from], to)
+}
+
+// OnSale reports whether the event takes bookings at now: it is published
+// and now is within its sales window.
+func (s *Schedule) OnSale(now time.Time) bool {
+	if s.Status != EventPublished {
+		return false
+	}
+	if s.SalesOpenAt != nil && now.Before(*s.SalesOpenAt) {
+		return false
+	}
+
+	return s.SalesCloseAt == nil || now.Before(*s.SalesCloseAt)
+}
+
+// Location returns the event's time zone, UTC if it is empty or unknown.
+func (e *Event) Location() *time.Location {
+	return location(e.Timezone)
+}
+
+// Localize sets Date to UTC and LocalDate to the same instant in the
+// event's time zone.
+func (e *Event) Localize() {
+	e.Date = e.Date.UTC()
+	local := e.Date.In(e.Location())
+	e.LocalDate = &local
+}
+
+// location loads the named time zone, UTC if the name is empty or unknown.
+func location(name string) *time.Location {
+	if name == "" || name == "Local" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
 }
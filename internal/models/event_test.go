@@ -0,0 +1,101 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLocalize(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2030, time.June, 1, 19, 0, 0, 0, time.FixedZone("", 3*60*60))
+
+	tests := []struct {
+		name      string
+		timezone  string
+		wantLocal string
+	}{
+		{name: "Event zone", timezone: "America/New_York", wantLocal: "2030-06-01T12:00:00-04:00"},
+		{name: "No zone", timezone: "", wantLocal: "2030-06-01T16:00:00Z"},
+		{name: "Unknown zone", timezone: "Mars/Olympus_Mons", wantLocal: "2030-06-01T16:00:00Z"},
+		{name: "Local zone", timezone: "Local", wantLocal: "2030-06-01T16:00:00Z"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := Event{Date: date, Timezone: tc.timezone}
+			e.Localize()
+
+			assert.Equal(t, "2030-06-01T16:00:00Z", e.Date.Format(time.RFC3339))
+			require.NotNil(t, e.LocalDate)
+			assert.Equal(t, tc.wantLocal, e.LocalDate.Format(time.RFC3339))
+			assert.True(t, e.LocalDate.Equal(date))
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{from: EventDraft, to: EventPublished, want: true},
+		{from: EventDraft, to: EventCancelled, want: true},
+		{from: EventDraft, to: EventSalesClosed, want: false},
+		{from: EventDraft, to: EventCompleted, want: false},
+		{from: EventPublished, to: EventSalesClosed, want: true},
+		{from: EventPublished, to: EventCancelled, want: true},
+		{from: EventPublished, to: EventCompleted, want: true},
+		{from: EventPublished, to: EventDraft, want: false},
+		{from: EventPublished, to: EventPublished, want: false},
+		{from: EventSalesClosed, to: EventPublished, want: true},
+		{from: EventCancelled, to: EventPublished, want: false},
+		{from: EventCompleted, to: EventCancelled, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.from+" to "+tc.to, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, CanTransition(tc.from, tc.to))
+		})
+	}
+}
+
+func TestScheduleOnSale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, time.June, 1, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		want     bool
+	}{
+		{name: "Published", schedule: Schedule{Status: EventPublished}, want: true},
+		{name: "Draft", schedule: Schedule{Status: EventDraft}, want: false},
+		{name: "Sales closed", schedule: Schedule{Status: EventSalesClosed}, want: false},
+		{name: "Cancelled", schedule: Schedule{Status: EventCancelled}, want: false},
+		{name: "Within window", schedule: Schedule{Status: EventPublished, SalesOpenAt: &before, SalesCloseAt: &after}, want: true},
+		{name: "Before window", schedule: Schedule{Status: EventPublished, SalesOpenAt: &after}, want: false},
+		{name: "After window", schedule: Schedule{Status: EventPublished, SalesCloseAt: &before}, want: false},
+		{name: "At close", schedule: Schedule{Status: EventPublished, SalesCloseAt: &now}, want: false},
+		{name: "At open", schedule: Schedule{Status: EventPublished, SalesOpenAt: &now}, want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, tc.schedule.OnSale(now))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Notification tells a user about a change to an event they booked.
+type Notification struct {
+	ID        int       `json:"id"`
+	UserID    string    `json:"user_id"`
+	EventID   int       `json:"event_id"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NotificationEventCancelled tells a user their booking was cancelled
+// together with its event.
+const NotificationEventCancelled = "event_cancelled"
+
+// EventCancelledMessage returns the message of a NotificationEventCancelled
+// for the event with the title at date.
+func EventCancelledMessage(title string, date time.Time) string {
+	return fmt.Sprintf("%q on %s has been cancelled. Your booking was cancelled and any payment for it will be refunded in full.",
+		title, date.UTC().Format("2006-01-02 15:04 MST"))
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Payment is the payment for a booking of a priced ticket type.
+type Payment struct {
+	ID int `json:"id"`
+	// BookingID is nil once the booking is cancelled or expired.
+	BookingID *int   `json:"booking_id,omitempty"`
+	EventID   int    `json:"event_id"`
+	UserID    string `json:"user_id"`
+	// Provider and ProviderID identify the payment at the payment provider.
+	Provider   string `json:"provider"`
+	ProviderID string `json:"provider_payment_id,omitempty"`
+	// Amount is in the minor units of Currency.
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// A payment becomes PaymentRefunded once refunded in full, partial refunds
+// leave it PaymentSucceeded. See Refund.
+const (
+	PaymentPending   = "pending"
+	PaymentSucceeded = "succeeded"
+	PaymentFailed    = "failed"
+	PaymentRefunded  = "refunded"
+)
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// PromoCode discounts bookings of priced ticket types, e.g. for early birds
+// or partners. Each booking made with a code counts as a use until the
+// booking is cancelled or expires.
+type PromoCode struct {
+	ID int `json:"id"`
+	// Code is stored upper-case and matched regardless of case.
+	Code string `json:"code"`
+	Kind string `json:"kind"`
+	// Value is a percentage for PromoPercent and an amount in the minor
+	// units of Currency for PromoFixed.
+	Value    int64  `json:"value"`
+	Currency string `json:"currency,omitempty"`
+	// MaxUses and MaxUsesPerUser limit the uses in total and by one user, zero is unlimited.
+	MaxUses        int `json:"max_uses"`
+	MaxUsesPerUser int `json:"max_uses_per_user"`
+	// ValidFrom and ValidUntil bound when the code can be used. Nil leaves that side open.
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	// EventID and TicketTypeID restrict the code to an event or one of its
+	// ticket types, nil for codes valid everywhere.
+	EventID      *int      `json:"event_id,omitempty"`
+	TicketTypeID *int      `json:"ticket_type_id,omitempty"`
+	Uses         int       `json:"uses"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+const (
+	PromoPercent = "percent"
+	PromoFixed   = "fixed"
+)
+
+// Active reports whether the code can be used at now.
+func (p PromoCode) Active(now time.Time) bool {
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return false
+	}
+
+	return p.ValidUntil == nil || now.Before(*p.ValidUntil)
+}
+
+// AppliesTo reports whether the code can be used for a booking of the
+// ticket type, priced in currency, of the event. ticketTypeID is 0 and
+// currency empty for events without ticket types. Fixed amounts only apply
+// to prices in their currency.
+func (p PromoCode) AppliesTo(eventID, ticketTypeID int, currency string) bool {
+	if p.EventID != nil && *p.EventID != eventID {
+		return false
+	}
+	if p.TicketTypeID != nil && *p.TicketTypeID != ticketTypeID {
+		return false
+	}
+
+	return p.Kind != PromoFixed || p.Currency == currency
+}
+
+// Discount returns how much of price the code takes off, never more than
+// the price itself. Percentages are rounded down to whole minor units.
+func (p PromoCode) Discount(price int64) int64 {
+	if p.Kind == PromoPercent {
+		return price * p.Value / 100
+	}
+
+	return min(p.Value, price)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoCodeDiscount(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		code     PromoCode
+		price    int64
+		discount int64
+	}{
+		{name: "Percent", code: PromoCode{Kind: PromoPercent, Value: 20}, price: 1999, discount: 399},
+		{name: "Full percent", code: PromoCode{Kind: PromoPercent, Value: 100}, price: 1999, discount: 1999},
+		{name: "Fixed", code: PromoCode{Kind: PromoFixed, Value: 500, Currency: "EUR"}, price: 1999, discount: 500},
+		{name: "Fixed above price", code: PromoCode{Kind: PromoFixed, Value: 5000, Currency: "EUR"}, price: 1999, discount: 1999},
+		{name: "Free booking", code: PromoCode{Kind: PromoPercent, Value: 50}, price: 0, discount: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.discount, tc.code.Discount(tc.price))
+		})
+	}
+}
+
+func TestPromoCodeActive(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := from.Add(24 * time.Hour)
+	code := PromoCode{ValidFrom: &from, ValidUntil: &until}
+
+	assert.False(t, code.Active(from.Add(-time.Second)), "before the window")
+	assert.True(t, code.Active(from), "at the start")
+	assert.False(t, code.Active(until), "at the end")
+	assert.True(t, PromoCode{}.Active(from), "open window")
+}
+
+func TestPromoCodeAppliesTo(t *testing.T) {
+	t.Parallel()
+
+	event, ticketType := 1, 2
+
+	assert.True(t, PromoCode{Kind: PromoPercent}.AppliesTo(5, 0, ""), "unrestricted")
+	assert.True(t, PromoCode{Kind: PromoPercent, EventID: &event}.AppliesTo(1, 3, "EUR"))
+	assert.False(t, PromoCode{Kind: PromoPercent, EventID: &event}.AppliesTo(5, 3, "EUR"), "other event")
+	assert.True(t, PromoCode{Kind: PromoPercent, EventID: &event, TicketTypeID: &ticketType}.AppliesTo(1, 2, "EUR"))
+	assert.False(t, PromoCode{Kind: PromoPercent, EventID: &event, TicketTypeID: &ticketType}.AppliesTo(1, 3, "EUR"), "other ticket type")
+	assert.True(t, PromoCode{Kind: PromoFixed, Currency: "EUR"}.AppliesTo(1, 3, "EUR"))
+	assert.False(t, PromoCode{Kind: PromoFixed, Currency: "EUR"}.AppliesTo(1, 3, "USD"), "other currency")
+}
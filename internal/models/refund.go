@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// CancellationPolicy decides how much of a paid booking is refunded when it
+// is cancelled: all of it until FullRefundHours before the event,
+// PartialRefundPercent of it until CutoffHours before, and nothing after.
+// The zero policy, used for events without one, refunds in full until the
+// event starts.
+type CancellationPolicy struct {
+	EventID              int `json:"event_id"`
+	FullRefundHours      int `json:"full_refund_hours"`
+	PartialRefundPercent int `json:"partial_refund_percent"`
+	CutoffHours          int `json:"cutoff_hours"`
+}
+
+// RefundPercent returns the percentage of the price refunded for a
+// cancellation at now of a booking for an event at eventDate.
+func (p CancellationPolicy) RefundPercent(eventDate, now time.Time) int {
+	left := eventDate.Sub(now)
+
+	switch {
+	case left < 0:
+		return 0
+	case left >= time.Duration(p.FullRefundHours)*time.Hour:
+		return 100
+	case left >= time.Duration(p.CutoffHours)*time.Hour:
+		return p.PartialRefundPercent
+	default:
+		return 0
+	}
+}
+
+// RefundAmount returns the part of amount refunded for a cancellation at now,
+// rounded down to whole minor units.
+func (p CancellationPolicy) RefundAmount(amount int64, eventDate, now time.Time) int64 {
+	return amount * int64(p.RefundPercent(eventDate, now)) / 100
+}
+
+// Refund returns money of a succeeded payment to the user.
+type Refund struct {
+	ID        int `json:"id"`
+	PaymentID int `json:"payment_id"`
+	// BookingID is the booking the payment was for, kept after the booking is deleted.
+	BookingID *int   `json:"booking_id,omitempty"`
+	EventID   int    `json:"event_id"`
+	UserID    string `json:"user_id"`
+	// Amount is in the minor units of Currency.
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Reason   string `json:"reason"`
+	// Note and CreatedBy are the audit trail of refunds issued by an admin.
+	Note      string    `json:"note,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// ProviderID identifies the refunded payment at the payment provider.
+	ProviderID string `json:"-"`
+}
+
+const (
+	// RefundCancellation refunds a cancelled booking according to the event's policy.
+	RefundCancellation = "cancellation"
+	// RefundUnconfirmable refunds a payment whose booking could not be confirmed.
+	RefundUnconfirmable = "unconfirmable"
+	// RefundOverride is a refund issued by an admin regardless of the policy.
+	RefundOverride = "override"
+	// RefundEventCancelled refunds in full a booking of a cancelled event.
+	RefundEventCancelled = "event_cancelled"
+)
+
+// A refund is RefundPending until it is claimed as RefundSending, just
+// before it is sent to the provider, which makes it RefundSucceeded or
+// RefundFailed.
+const (
+	RefundPending   = "pending"
+	RefundSending   = "sending"
+	RefundSucceeded = "succeeded"
+	RefundFailed    = "failed"
+)
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancellationPolicyRefund(t *testing.T) {
+	t.Parallel()
+
+	event := time.Date(2099, 6, 1, 19, 0, 0, 0, time.UTC)
+	policy := CancellationPolicy{FullRefundHours: 72, PartialRefundPercent: 50, CutoffHours: 24}
+
+	testCases := []struct {
+		name    string
+		policy  CancellationPolicy
+		left    time.Duration
+		percent int
+		amount  int64
+	}{
+		{name: "Before full refund deadline", policy: policy, left: 100 * time.Hour, percent: 100, amount: 1999},
+		{name: "At full refund deadline", policy: policy, left: 72 * time.Hour, percent: 100, amount: 1999},
+		{name: "Partial refund", policy: policy, left: 48 * time.Hour, percent: 50, amount: 999},
+		{name: "At cutoff", policy: policy, left: 24 * time.Hour, percent: 50, amount: 999},
+		{name: "After cutoff", policy: policy, left: time.Hour, percent: 0, amount: 0},
+		{name: "Zero policy", left: time.Minute, percent: 100, amount: 1999},
+		{name: "Event started", left: -time.Minute, percent: 0, amount: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			now := event.Add(-tc.left)
+			assert.Equal(t, tc.percent, tc.policy.RefundPercent(event, now))
+			assert.Equal(t, tc.amount, tc.policy.RefundAmount(1999, event, now))
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package models
+
+const (
+	RoleAdmin     = "admin"
+	RoleOrganizer = "organizer"
+)
+
+// Roles lists every role that can be granted to a user.
+var Roles = []string{RoleAdmin, RoleOrganizer}
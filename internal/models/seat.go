@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// SeatLayout is a seating plan of sections, rows and numbered seats. Events
+// with a layout are booked seat by seat, events without one by count only.
+type SeatLayout struct {
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+	Sections  []SeatSection `json:"sections"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type SeatSection struct {
+	Name string    `json:"name"`
+	Rows []SeatRow `json:"rows"`
+}
+
+type SeatRow struct {
+	Name  string `json:"name"`
+	Seats []Seat `json:"seats"`
+}
+
+type Seat struct {
+	ID     int `json:"id"`
+	Number int `json:"number"`
+	// Status is one of SeatAvailable, SeatHeld and SeatBooked in the seat
+	// map of an event, empty in a layout.
+	Status string `json:"status,omitempty"`
+}
+
+const (
+	SeatAvailable = "available"
+	// SeatHeld is a seat of a pending booking.
+	SeatHeld   = "held"
+	SeatBooked = "booked"
+)
+
+// AddSeat appends the seat to the row of the section, adding the section
+// and the row after the existing ones if the layout has none by that name.
+func (l *SeatLayout) AddSeat(section, row string, seat Seat) {
+	s := len(l.Sections) - 1
+	for s >= 0 && l.Sections[s].Name != section {
+		s--
+	}
+	if s < 0 {
+		l.Sections = append(l.Sections, SeatSection{Name: section})
+		s = len(l.Sections) - 1
+	}
+
+	rows := l.Sections[s].Rows
+	r := len(rows) - 1
+	for r >= 0 && rows[r].Name != row {
+		r--
+	}
+	if r < 0 {
+		rows = append(rows, SeatRow{Name: row})
+		r = len(rows) - 1
+	}
+
+	rows[r].Seats = append(rows[r].Seats, seat)
+	l.Sections[s].Rows = rows
+}
+
+// SeatCount returns the number of seats in the layout.
+func (l SeatLayout) SeatCount() int {
+	n := 0
+	for _, s := range l.Sections {
+		for _, r := range s.Rows {
+			n += len(r.Seats)
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeatLayoutAddSeat(t *testing.T) {
+	t.Parallel()
+
+	var layout SeatLayout
+	layout.AddSeat("Stalls", "A", Seat{ID: 1, Number: 1})
+	layout.AddSeat("Stalls", "A", Seat{ID: 2, Number: 2})
+	layout.AddSeat("Stalls", "B", Seat{ID: 3, Number: 1})
+	layout.AddSeat("Balcony", "A", Seat{ID: 4, Number: 1})
+	layout.AddSeat("Stalls", "A", Seat{ID: 5, Number: 3})
+
+	assert.Equal(t, []SeatSection{
+		{Name: "Stalls", Rows: []SeatRow{
+			{Name: "A", Seats: []Seat{{ID: 1, Number: 1}, {ID: 2, Number: 2}, {ID: 5, Number: 3}}},
+			{Name: "B", Seats: []Seat{{ID: 3, Number: 1}}},
+		}},
+		{Name: "Balcony", Rows: []SeatRow{
+			{Name: "A", Seats: []Seat{{ID: 4, Number: 1}}},
+		}},
+	}, layout.Sections)
+	assert.Equal(t, 5, layout.SeatCount())
+}
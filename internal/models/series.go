@@ -0,0 +1,95 @@
+package models
+
+import (
+	"eventBooker/internal/lib/rrule"
+	"slices"
+	"time"
+)
+
+// Series is a recurring event. Its occurrences are events created from it
+// by Rule, an RFC 5545 RRULE, up to a horizon that moves forward with time.
+type Series struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	// Start is the first occurrence. Later ones keep its wall-clock time in Timezone.
+	Start      time.Time `json:"start"`
+	Rule       string    `json:"rrule"`
+	TotalSeats int       `json:"total_seats"`
+	Deadline   int       `json:"deadline_minutes"`
+	VenueID    *int      `json:"venue_id,omitempty"`
+	Timezone   string    `json:"timezone"`
+	// Exceptions are the starts of skipped occurrences.
+	Exceptions []time.Time `json:"exceptions"`
+	// EndsAt is where a series split by an edit of all future occurrences
+	// ends; the occurrences from it on belong to the series split off.
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+	// GeneratedUntil is how far occurrences have been created as events.
+	GeneratedUntil time.Time `json:"generated_until"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+const (
+	// ScopeThis edits a single occurrence of a series.
+	ScopeThis = "this"
+	// ScopeFuture edits an occurrence and all that follow it.
+	ScopeFuture = "future"
+)
+
+// Location returns the series' time zone, UTC if it is empty or unknown.
+func (s *Series) Location() *time.Location {
+	return location(s.Timezone)
+}
+
+// Occurrences returns the starts of the occurrences in [from, to), without
+// exceptions and those from EndsAt on.
+func (s *Series) Occurrences(from, to time.Time) ([]time.Time, error) {
+	rule, err := rrule.Parse(s.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.EndsAt != nil && s.EndsAt.Before(to) {
+		to = *s.EndsAt
+	}
+
+	dates := rule.Between(s.Start.In(s.Location()), from, to)
+
+	return slices.DeleteFunc(dates, func(d time.Time) bool {
+		return slices.ContainsFunc(s.Exceptions, d.Equal)
+	}), nil
+}
+
+// RuleFrom returns the rule of a series split off at the occurrence at.
+// A COUNT is reduced by the occurrences before at, skipped ones included,
+// so the two series together keep the original number.
+func (s *Series) RuleFrom(at time.Time) (string, error) {
+	rule, err := rrule.Parse(s.Rule)
+	if err != nil {
+		return "", err
+	}
+
+	if rule.Count > 0 {
+		start := s.Start.In(s.Location())
+		rule.Count -= len(rule.Between(start, start, at))
+	}
+
+	return rule.String(), nil
+}
+
+// Reclock returns the day of d at the wall-clock time of clock, both in the
+// series' time zone. An edit of all future occurrences moves them this way.
+func (s *Series) Reclock(d, clock time.Time) time.Time {
+	loc := s.Location()
+	d, clock = d.In(loc), clock.In(loc)
+
+	return time.Date(d.Year(), d.Month(), d.Day(), clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), loc)
+}
+
+// SameDay reports whether a and b fall on the same day in the series' time zone.
+func (s *Series) SameDay(a, b time.Time) bool {
+	loc := s.Location()
+	ay, am, ad := a.In(loc).Date()
+	by, bm, bd := b.In(loc).Date()
+
+	return ay == by && am == bm && ad == bd
+}
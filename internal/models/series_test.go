@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesOccurrences(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2030, time.January, 1, 19, 0, 0, 0, time.UTC)
+	week := func(n int) time.Time { return start.AddDate(0, 0, 7*n) }
+	endsAt := week(3)
+
+	s := Series{
+		Start:      start,
+		Rule:       "FREQ=WEEKLY;COUNT=5",
+		Exceptions: []time.Time{week(1)},
+		EndsAt:     &endsAt,
+	}
+
+	dates, err := s.Occurrences(start, week(10))
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{week(0), week(2)}, dates, "exceptions and occurrences from EndsAt on are left out")
+
+	rule, err := s.RuleFrom(week(3))
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;COUNT=2", rule, "skipped occurrences count too")
+
+	s.Rule = "FREQ=WEEKLY"
+	rule, err = s.RuleFrom(week(3))
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY", rule)
+
+	s.Rule = "FREQ=SECONDLY"
+	_, err = s.Occurrences(start, week(10))
+	assert.Error(t, err)
+}
+
+func TestSeriesReclock(t *testing.T) {
+	t.Parallel()
+
+	s := Series{Timezone: "Europe/Berlin"}
+
+	// Winter and summer time: the wall clock is kept, the offset is not.
+	d := time.Date(2030, time.March, 26, 18, 0, 0, 0, time.UTC)
+	clock := time.Date(2030, time.March, 20, 20, 30, 0, 0, time.UTC)
+
+	got := s.Reclock(d.AddDate(0, 0, 7), clock)
+	assert.Equal(t, "2030-04-02T21:30:00+02:00", got.Format(time.RFC3339))
+
+	assert.True(t, s.SameDay(d, time.Date(2030, time.March, 26, 22, 59, 0, 0, time.UTC)))
+	assert.False(t, s.SameDay(d, time.Date(2030, time.March, 26, 23, 0, 0, 0, time.UTC)), "midnight in Berlin")
+}
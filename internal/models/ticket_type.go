@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// TicketType is a priced tier of an event's seats, e.g. standard, student or VIP.
+// Bookings of a tier count against both its Capacity and the event's TotalSeats.
+type TicketType struct {
+	ID      int    `json:"id"`
+	EventID int    `json:"event_id"`
+	Name    string `json:"name"`
+	// Price is in the minor units of Currency, e.g. cents.
+	Price    int64  `json:"price"`
+	Currency string `json:"currency"`
+	Capacity int    `json:"capacity"`
+	// SalesStart and SalesEnd bound when the tier can be booked. Nil leaves that side open.
+	SalesStart *time.Time `json:"sales_start,omitempty"`
+	SalesEnd   *time.Time `json:"sales_end,omitempty"`
+	// BookedSeats counts confirmed bookings of the tier.
+	BookedSeats int `json:"booked_seats"`
+	// RemainingSeats is how many more seats of the tier can be confirmed,
+	// limited by both the tier and the whole event.
+	RemainingSeats int `json:"remaining_seats"`
+}
+
+// OnSale reports whether the tier can be booked at now.
+func (t TicketType) OnSale(now time.Time) bool {
+	if t.SalesStart != nil && now.Before(*t.SalesStart) {
+		return false
+	}
+
+	return t.SalesEnd == nil || now.Before(*t.SalesEnd)
+}
+
+// Remaining returns the seats left in the tier when eventRemaining seats are left in the event.
+func (t TicketType) Remaining(eventRemaining int) int {
+	return max(0, min(t.Capacity-t.BookedSeats, eventRemaining))
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Venue is a place events are held at. Events at a venue take their seat
+// count from it unless they set their own, which may not exceed Capacity.
+type Venue struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	// Timezone is the IANA name of the venue's time zone, e.g. "Europe/Moscow".
+	Timezone string `json:"timezone"`
+	Capacity int    `json:"capacity"`
+	// LayoutID is the seat layout new events at the venue get, nil for general admission.
+	LayoutID  *int      `json:"layout_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
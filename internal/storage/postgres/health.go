@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+func (s *Storage) Ping(ctx context.Context) error {
+	if err := s.DB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return nil
+}
+
+// SchemaVersion returns the version recorded by golang-migrate and whether the
+// last migration failed halfway.
+func (s *Storage) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	query := `
+		SELECT version, dirty
+		FROM schema_migrations
+		LIMIT 1`
+
+	var (
+		version uint
+		dirty   bool
+	)
+
+	spanCtx, span := startSpan(ctx, "SchemaVersion", query)
+	err := s.DB.QueryRowContext(spanCtx, query).Scan(&version, &dirty)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, fmt.Errorf("no migrations applied")
+		}
+		return 0, false, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+	"time"
+)
+
+// SaveIdempotencyKey reserves key for a new request. If the key is already taken
+// and not expired, the existing record is returned instead and nothing is saved.
+// A key reserved longer than lockTimeout ago whose request never completed is
+// taken over by the new request.
+func (s *Storage) SaveIdempotencyKey(ctx context.Context, key, requestHash string, ttl, lockTimeout time.Duration) (*models.IdempotencyKey, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1
+		  AND (expires_at < NOW()
+		   OR (NOT completed AND created_at < NOW() - $2 * INTERVAL '1 second'))`
+
+	spanCtx, span := startSpan(ctx, "SaveIdempotencyKey.DeleteExpired", deleteQuery)
+	_, err = tx.ExecContext(spanCtx, deleteQuery, key, lockTimeout.Seconds())
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired idempotency key: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO idempotency_keys (key, request_hash, expires_at)
+		VALUES ($1, $2, NOW() + $3 * INTERVAL '1 second')
+		ON CONFLICT (key) DO NOTHING`
+
+	spanCtx, span = startSpan(ctx, "SaveIdempotencyKey.Insert", insertQuery)
+	result, err := tx.ExecContext(spanCtx, insertQuery, key, requestHash, ttl.Seconds())
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+
+	if inserted > 0 {
+		return nil, tx.Commit()
+	}
+
+	selectQuery := `
+		SELECT key, request_hash, COALESCE(status_code, 0), response, completed, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = $1`
+
+	var record models.IdempotencyKey
+	spanCtx, span = startSpan(ctx, "SaveIdempotencyKey.Select", selectQuery)
+	err = tx.QueryRowContext(spanCtx, selectQuery, key).Scan(
+		&record.Key,
+		&record.RequestHash,
+		&record.StatusCode,
+		&record.Response,
+		&record.Completed,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("idempotency key disappeared")
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return &record, tx.Commit()
+}
+
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, response []byte) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $2, response = $3, completed = true
+		WHERE key = $1`
+
+	ctx, span := startSpan(ctx, "CompleteIdempotencyKey", query)
+	_, err := s.DB.ExecContext(ctx, query, key, statusCode, response)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1`
+
+	ctx, span := startSpan(ctx, "DeleteIdempotencyKey", query)
+	_, err := s.DB.ExecContext(ctx, query, key)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE expires_at < NOW()`
+
+	ctx, span := startSpan(ctx, "DeleteExpiredIdempotencyKeys", query)
+	_, err := s.DB.ExecContext(ctx, query)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return nil
+}
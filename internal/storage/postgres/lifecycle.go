@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+	"time"
+)
+
+// SetEventStatus moves the event to status if models.CanTransition allows
+// it, and returns the number of bookings cancelled. Cancelling an event
+// cancels all of its bookings: each of their users is notified, and each
+// paid booking gets a pending refund in full, to be sent to the payment
+// provider, see GetRefunds.
+func (s *Storage) SetEventStatus(ctx context.Context, eventID int, status string) (int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT title, date, status FROM events
+		WHERE id = $1
+		FOR UPDATE`
+
+	var (
+		title   string
+		date    time.Time
+		current string
+	)
+	spanCtx, span := startSpan(ctx, "SetEventStatus.Event", query)
+	err = tx.QueryRowContext(spanCtx, query, eventID).Scan(&title, &date, &current)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("event not found")
+		}
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !models.CanTransition(current, status) {
+		return 0, fmt.Errorf("invalid status transition")
+	}
+
+	updateQuery := `
+		UPDATE events
+		SET status = $2
+		WHERE id = $1`
+
+	spanCtx, span = startSpan(ctx, "SetEventStatus.Update", updateQuery)
+	_, err = tx.ExecContext(spanCtx, updateQuery, eventID, status)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update event status: %w", err)
+	}
+
+	var cancelled int
+	if status == models.EventCancelled {
+		cancelled, err = cancelEventBookings(ctx, tx, eventID, models.EventCancelledMessage(title, date))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit event status: %w", err)
+	}
+
+	return cancelled, nil
+}
+
+// cancelEventBookings refunds the paid bookings of the event in full,
+// notifies their users with message and deletes them. It returns the
+// number of bookings deleted.
+func cancelEventBookings(ctx context.Context, tx *sql.Tx, eventID int, message string) (int, error) {
+	paymentsQuery := `
+		SELECT p.id, p.amount
+		FROM payments p
+		JOIN bookings b ON b.id = p.booking_id
+		WHERE b.event_id = $1 AND p.status = 'succeeded'
+		ORDER BY p.id
+		FOR UPDATE OF p`
+
+	spanCtx, span := startSpan(ctx, "SetEventStatus.Payments", paymentsQuery)
+	rows, err := tx.QueryContext(spanCtx, paymentsQuery, eventID)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event payments: %w", err)
+	}
+
+	var payments []models.Payment
+	for rows.Next() {
+		var p models.Payment
+		if err = rows.Scan(&p.ID, &p.Amount); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		payments = append(payments, p)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read event payments: %w", err)
+	}
+
+	// The refunds are recorded first, deleting the bookings unlinks their payments.
+	for _, p := range payments {
+		amount, err := refundable(ctx, tx, "SetEventStatus", p.ID, p.Amount)
+		if err != nil {
+			return 0, err
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		_, err = insertRefund(ctx, tx, "SetEventStatus", models.Refund{
+			PaymentID: p.ID,
+			Amount:    amount,
+			Reason:    models.RefundEventCancelled,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = insertNotifications(ctx, tx, "SetEventStatus", eventID, models.NotificationEventCancelled, message); err != nil {
+		return 0, err
+	}
+
+	deleteQuery := `
+		DELETE FROM bookings
+		WHERE event_id = $1`
+
+	spanCtx, span = startSpan(ctx, "SetEventStatus.DeleteBookings", deleteQuery)
+	result, err := tx.ExecContext(spanCtx, deleteQuery, eventID)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel bookings: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cancelled bookings count: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// PublishScheduledEvents publishes the drafts whose publish time has come
+// and returns how many it published.
+func (s *Storage) PublishScheduledEvents(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE events
+		SET status = 'published'
+		WHERE status = 'draft' AND publish_at <= NOW()`
+
+	ctx, span := startSpan(ctx, "PublishScheduledEvents", query)
+	result, err := s.DB.ExecContext(ctx, query)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to publish scheduled events: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get published events count: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// CompleteEvents completes the published and sales closed events that have
+// started, as events have no end time, and returns how many it completed.
+func (s *Storage) CompleteEvents(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE events
+		SET status = 'completed'
+		WHERE status IN ('published', 'sales_closed') AND date <= NOW()`
+
+	ctx, span := startSpan(ctx, "CompleteEvents", query)
+	result, err := s.DB.ExecContext(ctx, query)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete events: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get completed events count: %w", err)
+	}
+
+	return rowsAffected, nil
+}
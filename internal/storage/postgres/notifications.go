@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+)
+
+// insertNotifications notifies each user with a booking for the event once.
+// span prefixes the span names.
+func insertNotifications(ctx context.Context, tx *sql.Tx, span string, eventID int, kind, message string) error {
+	query := `
+		INSERT INTO notifications (user_id, event_id, kind, message)
+		SELECT DISTINCT user_id, $1, $2, $3
+		FROM bookings
+		WHERE event_id = $1`
+
+	spanCtx, sp := startSpan(ctx, span+".Notify", query)
+	_, err := tx.ExecContext(spanCtx, query, eventID, kind, message)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to create notifications: %w", err)
+	}
+
+	return nil
+}
+
+// GetNotifications returns the user's notifications, newest first.
+func (s *Storage) GetNotifications(ctx context.Context, userID string) ([]models.Notification, error) {
+	query := `
+		SELECT id, user_id, event_id, kind, message, created_at
+		FROM notifications
+		WHERE user_id = $1
+		ORDER BY id DESC`
+
+	spanCtx, span := startSpan(ctx, "GetNotifications", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, userID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]models.Notification, 0)
+	for rows.Next() {
+		var n models.Notification
+		err = rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.EventID,
+			&n.Kind,
+			&n.Message,
+			&n.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read notifications: %w", err)
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+)
+
+// PendingBookingPrice returns the price of the ticket type of the user's
+// pending booking for the event less its promo code discount, 0 for a
+// booking without a ticket type.
+func (s *Storage) PendingBookingPrice(ctx context.Context, eventID int, userID string) (int64, error) {
+	query := `
+		SELECT COALESCE(t.price, 0) - COALESCE(r.discount, 0)
+		FROM bookings b
+		LEFT JOIN ticket_types t ON t.id = b.ticket_type_id
+		LEFT JOIN promo_redemptions r ON r.booking_id = b.id
+		WHERE b.event_id = $1 AND b.user_id = $2 AND b.confirmed = false`
+
+	var price int64
+	ctx, span := startSpan(ctx, "PendingBookingPrice", query)
+	err := s.DB.QueryRowContext(ctx, query, eventID, userID).Scan(&price)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("no pending booking found")
+		}
+		return 0, fmt.Errorf("failed to get booking price: %w", err)
+	}
+
+	return price, nil
+}
+
+// CreatePayment records a pending payment of the user's pending booking for
+// the event at the price of its ticket type less its promo code discount.
+// The provider's id of the payment is set later with SetPaymentProviderID.
+func (s *Storage) CreatePayment(ctx context.Context, eventID int, userID, provider string) (*models.Payment, error) {
+	query := `
+		SELECT b.id, COALESCE(t.price, 0) - COALESCE(r.discount, 0), COALESCE(t.currency, '')
+		FROM bookings b
+		LEFT JOIN ticket_types t ON t.id = b.ticket_type_id
+		LEFT JOIN promo_redemptions r ON r.booking_id = b.id
+		WHERE b.event_id = $1 AND b.user_id = $2 AND b.confirmed = false`
+
+	p := models.Payment{
+		EventID:  eventID,
+		UserID:   userID,
+		Provider: provider,
+		Status:   models.PaymentPending,
+	}
+
+	var bookingID int
+	spanCtx, span := startSpan(ctx, "CreatePayment.FindPending", query)
+	err := s.DB.QueryRowContext(spanCtx, query, eventID, userID).Scan(&bookingID, &p.Amount, &p.Currency)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no pending booking found")
+		}
+		return nil, fmt.Errorf("failed to get booking price: %w", err)
+	}
+
+	if p.Amount == 0 {
+		return nil, fmt.Errorf("payment not required")
+	}
+	p.BookingID = &bookingID
+
+	insertQuery := `
+		INSERT INTO payments (booking_id, event_id, user_id, provider, amount, currency)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	spanCtx, span = startSpan(ctx, "CreatePayment.Insert", insertQuery)
+	err = s.DB.QueryRowContext(spanCtx, insertQuery,
+		bookingID, eventID, userID, provider, p.Amount, p.Currency,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	return &p, nil
+}
+
+// SetPaymentProviderID records the provider's id of the payment.
+func (s *Storage) SetPaymentProviderID(ctx context.Context, id int, providerID string) error {
+	query := `
+		UPDATE payments
+		SET provider_payment_id = $2, updated_at = NOW()
+		WHERE id = $1`
+
+	ctx, span := startSpan(ctx, "SetPaymentProviderID", query)
+	_, err := s.DB.ExecContext(ctx, query, id, providerID)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to set payment provider id: %w", err)
+	}
+
+	return nil
+}
+
+// CompletePayment records the outcome reported by the provider for a pending
+// payment and, on success, confirms its booking in the same transaction.
+// Payments already completed are returned unchanged, so repeated callbacks
+// are harmless.
+//
+// When the payment succeeded but its booking can no longer be confirmed,
+// e.g. it expired, the seats ran out or another payment of the user confirmed
+// it first, the payment is still recorded as succeeded and a pending refund
+// of all of it is recorded in the same transaction. The refund is returned
+// together with the reason, to be sent to the provider; if it is not, a
+// repeated callback finds the payment completed and the refund is left to
+// ResendRefunds.
+func (s *Storage) CompletePayment(ctx context.Context, provider, providerID string, succeeded bool) (*models.Payment, *models.Refund, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, booking_id, event_id, user_id, provider, provider_payment_id,
+		       amount, currency, status, created_at, updated_at
+		FROM payments
+		WHERE provider = $1 AND provider_payment_id = $2
+		FOR UPDATE`
+
+	var (
+		p         models.Payment
+		bookingID sql.NullInt64
+	)
+	spanCtx, span := startSpan(ctx, "CompletePayment.Find", query)
+	err = tx.QueryRowContext(spanCtx, query, provider, providerID).Scan(
+		&p.ID,
+		&bookingID,
+		&p.EventID,
+		&p.UserID,
+		&p.Provider,
+		&p.ProviderID,
+		&p.Amount,
+		&p.Currency,
+		&p.Status,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("payment not found")
+		}
+		return nil, nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if bookingID.Valid {
+		id := int(bookingID.Int64)
+		p.BookingID = &id
+	}
+
+	if p.Status != models.PaymentPending {
+		return &p, nil, nil
+	}
+
+	p.Status = models.PaymentFailed
+	if succeeded {
+		p.Status = models.PaymentSucceeded
+	}
+
+	updateQuery := `
+		UPDATE payments
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	spanCtx, span = startSpan(ctx, "CompletePayment.Update", updateQuery)
+	err = tx.QueryRowContext(spanCtx, updateQuery, p.ID, p.Status).Scan(&p.UpdatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	var (
+		refund     *models.Refund
+		confirmErr error
+	)
+	if succeeded {
+		confirmErr = confirmPaidBooking(ctx, tx, bookingID)
+		if confirmErr != nil {
+			switch confirmErr.Error() {
+			case "no pending booking found", "booking already paid", "no available seats", "ticket type not found":
+				// The payment stays succeeded and is refunded.
+				refund, err = insertRefund(ctx, tx, "CompletePayment", models.Refund{
+					PaymentID: p.ID,
+					Amount:    p.Amount,
+					Reason:    models.RefundUnconfirmable,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+			default:
+				return nil, nil, confirmErr
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit payment: %w", err)
+	}
+
+	return &p, refund, confirmErr
+}
+
+// confirmPaidBooking confirms the booking of a succeeded payment. A booking
+// confirmed in the meantime was paid by another payment of the user, so this
+// one is to be refunded.
+func confirmPaidBooking(ctx context.Context, tx *sql.Tx, bookingID sql.NullInt64) error {
+	if !bookingID.Valid {
+		return fmt.Errorf("no pending booking found")
+	}
+
+	eventQuery := `
+		SELECT event_id FROM bookings
+		WHERE id = $1`
+
+	var eventID int
+	spanCtx, span := startSpan(ctx, "CompletePayment.FindEvent", eventQuery)
+	err := tx.QueryRowContext(spanCtx, eventQuery, bookingID.Int64).Scan(&eventID)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("no pending booking found")
+		}
+		return fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	// The event is locked before the booking, in the order SetEventStatus
+	// takes them, so the two cannot deadlock.
+	if _, err = lockEvent(ctx, tx, "CompletePayment", eventID); err != nil {
+		return err
+	}
+
+	query := `
+		SELECT confirmed, ticket_type_id
+		FROM bookings
+		WHERE id = $1
+		FOR UPDATE`
+
+	var (
+		confirmed  bool
+		ticketType sql.NullInt64
+	)
+	spanCtx, span = startSpan(ctx, "CompletePayment.FindBooking", query)
+	err = tx.QueryRowContext(spanCtx, query, bookingID.Int64).Scan(&confirmed, &ticketType)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("no pending booking found")
+		}
+		return fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	if confirmed {
+		return fmt.Errorf("booking already paid")
+	}
+
+	return confirmBooking(ctx, tx, "CompletePayment", int(bookingID.Int64), eventID, ticketType)
+}
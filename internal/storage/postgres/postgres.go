@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"eventBooker/internal/config"
 	"eventBooker/internal/models"
@@ -40,35 +41,205 @@ func (s *Storage) Close() error {
 	return s.DB.Close()
 }
 
-func (s *Storage) CreateEvent(title string, date time.Time, totalSeats, deadline int) (int, error) {
+const bookingColumns = `
+	id, event_id, user_id, created_at, confirmed, checked_in_at, ticket_type_id,
+	(SELECT es.seat_id FROM event_seats es WHERE es.booking_id = bookings.id)`
+
+// bookingFields returns the scan destinations of bookingColumns.
+func bookingFields(b *models.Booking) []any {
+	return []any{
+		&b.ID,
+		&b.EventID,
+		&b.UserID,
+		&b.CreatedAt,
+		&b.Confirmed,
+		&b.CheckedInAt,
+		&b.TicketTypeID,
+		&b.SeatID,
+	}
+}
+
+// scheduleColumns are the lifecycle columns of the event e.
+const scheduleColumns = `
+	e.status, e.publish_at, e.sales_open_at, e.sales_close_at`
+
+// scheduleFields returns the scan destinations of scheduleColumns.
+func scheduleFields(s *models.Schedule) []any {
+	return []any{
+		&s.Status,
+		&s.PublishAt,
+		&s.SalesOpenAt,
+		&s.SalesCloseAt,
+	}
+}
+
+// eventTimezone is the time zone of the event e: its own, else its venue's, else UTC.
+const eventTimezone = `
+	COALESCE(e.timezone, (SELECT v.timezone FROM venues v WHERE v.id = e.venue_id), 'UTC')`
+
+// CreateEvent creates an event and returns its id. An event at a venue
+// gets the venue's capacity when totalSeats is 0 and may not exceed it;
+// if the venue has a seat layout, the event gets the layout's seats instead.
+// venueID is 0 for events without a venue. An empty timezone takes the
+// venue's time zone, or UTC without a venue. An empty schedule status
+// publishes the event right away.
+func (s *Storage) CreateEvent(ctx context.Context, title string, date time.Time, totalSeats, deadline, venueID int, timezone string, schedule models.Schedule) (int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var (
+		venue    *int
+		layoutID *int
+	)
+	if venueID != 0 {
+		totalSeats, layoutID, err = venueSeats(ctx, tx, "CreateEvent", venueID, totalSeats)
+		if err != nil {
+			return 0, err
+		}
+		venue = &venueID
+	}
+
 	query := `
-		INSERT INTO events (title, date, total_seats, deadline_minutes)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO events (title, date, total_seats, deadline_minutes, venue_id, timezone,
+		                    status, publish_at, sales_open_at, sales_close_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), COALESCE(NULLIF($7, ''), 'published'), $8, $9, $10)
 		RETURNING id`
 
+	spanCtx, span := startSpan(ctx, "CreateEvent", query)
 	var id int
-	err := s.DB.QueryRow(query, title, date, totalSeats, deadline).Scan(&id)
+	err = tx.QueryRowContext(spanCtx, query, title, date, totalSeats, deadline, venue, timezone,
+		schedule.Status, schedule.PublishAt, schedule.SalesOpenAt, schedule.SalesCloseAt,
+	).Scan(&id)
+	endSpan(span, err)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create event: %w", err)
 	}
 
+	if layoutID != nil {
+		if err = assignLayout(ctx, tx, "CreateEvent", id, *layoutID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit event: %w", err)
+	}
+
 	return id, nil
 }
 
-func (s *Storage) GetEvent(id int) (*models.Event, error) {
+// ImportEvents creates all events in one transaction, so either every event
+// is created or none is. It returns their ids in order.
+func (s *Storage) ImportEvents(ctx context.Context, events []models.Event) ([]int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
-		SELECT id, title, date, total_seats, deadline_minutes
-		FROM events
-		WHERE id = $1`
+		INSERT INTO events (title, date, total_seats, deadline_minutes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
 
+	spanCtx, span := startSpan(ctx, "ImportEvents.Prepare", query)
+	stmt, err := tx.PrepareContext(spanCtx, query)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare event insert: %w", err)
+	}
+	defer stmt.Close()
+
+	ids := make([]int, 0, len(events))
+	for _, e := range events {
+		var id int
+
+		spanCtx, span = startSpan(ctx, "ImportEvents.Insert", query)
+		err = stmt.QueryRowContext(spanCtx, e.Title, e.Date, e.TotalSeats, e.Deadline).Scan(&id)
+		endSpan(span, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import event %q: %w", e.Title, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit imported events: %w", err)
+	}
+
+	return ids, nil
+}
+
+// UpdateEvent updates the event. The seats of an event with a seat layout
+// are its layout's, so totalSeats is ignored for it. The seats of an event
+// at a venue may not exceed the venue's capacity.
+func (s *Storage) UpdateEvent(ctx context.Context, id int, title string, date time.Time, totalSeats, deadline int) error {
+	query := `
+		UPDATE events
+		SET title = $2, date = $3, deadline_minutes = $5,
+		    total_seats = CASE WHEN layout_id IS NULL THEN $4 ELSE total_seats END
+		WHERE id = $1
+		  AND (layout_id IS NOT NULL OR venue_id IS NULL
+		       OR $4 <= (SELECT capacity FROM venues WHERE id = events.venue_id))`
+
+	spanCtx, span := startSpan(ctx, "UpdateEvent", query)
+	result, err := s.DB.ExecContext(spanCtx, query, id, title, date, totalSeats, deadline)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to update event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get updated events count: %w", err)
+	}
+
+	if rowsAffected == 1 {
+		return nil
+	}
+
+	existsQuery := `
+		SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)`
+
+	var exists bool
+	spanCtx, span = startSpan(ctx, "UpdateEvent.Exists", existsQuery)
+	err = s.DB.QueryRowContext(spanCtx, existsQuery, id).Scan(&exists)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("event not found")
+	}
+
+	return fmt.Errorf("event exceeds venue capacity")
+}
+
+func (s *Storage) GetEvent(ctx context.Context, id int) (*models.Event, error) {
+	query := `
+		SELECT e.id, e.title, e.date, e.total_seats, e.deadline_minutes, e.layout_id, e.venue_id, e.series_id,` + eventTimezone + `,` + scheduleColumns + `
+		FROM events e
+		WHERE e.id = $1`
+
+	spanCtx, span := startSpan(ctx, "GetEvent", query)
 	var event models.Event
-	err := s.DB.QueryRow(query, id).Scan(
+	err := s.DB.QueryRowContext(spanCtx, query, id).Scan(append([]any{
 		&event.ID,
 		&event.Title,
 		&event.Date,
 		&event.TotalSeats,
 		&event.Deadline,
-	)
+		&event.LayoutID,
+		&event.VenueID,
+		&event.SeriesID,
+		&event.Timezone,
+	}, scheduleFields(&event.Schedule)...)...)
+	endSpan(span, err)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("event not found")
@@ -81,36 +252,83 @@ func (s *Storage) GetEvent(id int) (*models.Event, error) {
 		FROM bookings 
 		WHERE event_id = $1 AND confirmed = true`
 
-	err = s.DB.QueryRow(bookedQuery, id).Scan(&event.BookedSeats)
+	spanCtx, span = startSpan(ctx, "GetEvent.CountBooked", bookedQuery)
+	err = s.DB.QueryRowContext(spanCtx, bookedQuery, id).Scan(&event.BookedSeats)
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get booked seats count: %w", err)
 	}
 
+	event.Localize()
+
 	return &event, nil
 }
 
-func (s *Storage) BookEvent(eventID int, userID string) error {
-	tx, err := s.DB.Begin()
+// BookEvent creates a pending booking. Events with ticket types must be booked
+// for one of them that is on sale; ticketTypeID is 0 for events without.
+// A non-empty promoCode discounts the booking, or fails it if the code
+// cannot be used. Events with a seat layout must be booked for a free seat;
+// seatID is 0 for events without. The event must be on sale, see
+// models.Schedule.OnSale.
+func (s *Storage) BookEvent(ctx context.Context, eventID int, userID string, ticketTypeID int, promoCode string, seatID int) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	var totalSeats, bookedSeats int
+	bookingID, err := insertBooking(ctx, tx, "BookEvent", eventID, userID, ticketTypeID, seatID)
+	if err != nil {
+		return err
+	}
+
+	if promoCode != "" {
+		if err = redeemPromoCode(ctx, tx, bookingID, eventID, ticketTypeID, userID, promoCode); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertBooking creates a pending booking in tx and returns its id, see
+// BookEvent for the rules. Like confirmBooking it locks the event before the
+// ticket type, so that a booking and a confirmation of the same event never
+// wait for each other's locks. span prefixes the span names.
+func insertBooking(ctx context.Context, tx *sql.Tx, span string, eventID int, userID string, ticketTypeID, seatID int) (int, error) {
+	if _, err := lockEvent(ctx, tx, span, eventID); err != nil {
+		return 0, err
+	}
+
+	var (
+		totalSeats, bookedSeats int
+		schedule                models.Schedule
+	)
 	countQuery := `
-		SELECT e.total_seats, COUNT(b.id)
+		SELECT e.total_seats, COUNT(b.id),` + scheduleColumns + `
 		FROM events e
 		LEFT JOIN bookings b ON e.id = b.event_id AND b.confirmed = true
 		WHERE e.id = $1
 		GROUP BY e.id, e.total_seats`
 
-	err = tx.QueryRow(countQuery, eventID).Scan(&totalSeats, &bookedSeats)
+	spanCtx, sp := startSpan(ctx, span+".CountSeats", countQuery)
+	err := tx.QueryRowContext(spanCtx, countQuery, eventID).Scan(
+		append([]any{&totalSeats, &bookedSeats}, scheduleFields(&schedule)...)...)
+	endSpan(sp, err)
 	if err != nil {
-		return fmt.Errorf("failed to get event seats info: %w", err)
+		return 0, fmt.Errorf("failed to get event seats info: %w", err)
+	}
+
+	if !schedule.OnSale(time.Now()) {
+		return 0, fmt.Errorf("event is not on sale")
 	}
 
 	if bookedSeats >= totalSeats {
-		return fmt.Errorf("no available seats")
+		return 0, fmt.Errorf("no available seats")
+	}
+
+	if err = checkTicketType(ctx, tx, span, eventID, ticketTypeID, true); err != nil {
+		return 0, err
 	}
 
 	var existingBooking bool
@@ -120,40 +338,58 @@ func (s *Storage) BookEvent(eventID int, userID string) error {
 			WHERE event_id = $1 AND user_id = $2 AND confirmed = false
 		)`
 
-	err = tx.QueryRow(checkQuery, eventID, userID).Scan(&existingBooking)
+	spanCtx, sp = startSpan(ctx, span+".CheckPending", checkQuery)
+	err = tx.QueryRowContext(spanCtx, checkQuery, eventID, userID).Scan(&existingBooking)
+	endSpan(sp, err)
 	if err != nil {
-		return fmt.Errorf("failed to check existing booking: %w", err)
+		return 0, fmt.Errorf("failed to check existing booking: %w", err)
 	}
 
 	if existingBooking {
-		return fmt.Errorf("user already has pending booking for this event")
+		return 0, fmt.Errorf("user already has pending booking for this event")
 	}
 
 	insertQuery := `
-		INSERT INTO bookings (event_id, user_id, created_at, confirmed)
-		VALUES ($1, $2, NOW(), false)`
+		INSERT INTO bookings (event_id, user_id, created_at, confirmed, ticket_type_id)
+		VALUES ($1, $2, NOW(), false, $3)
+		RETURNING id`
+
+	// An id of 0 is stored as NULL.
+	ticketType := sql.NullInt64{Int64: int64(ticketTypeID), Valid: ticketTypeID != 0}
 
-	_, err = tx.Exec(insertQuery, eventID, userID)
+	var bookingID int
+	spanCtx, sp = startSpan(ctx, span+".Insert", insertQuery)
+	err = tx.QueryRowContext(spanCtx, insertQuery, eventID, userID, ticketType).Scan(&bookingID)
+	endSpan(sp, err)
 	if err != nil {
-		return fmt.Errorf("failed to create booking: %w", err)
+		return 0, fmt.Errorf("failed to create booking: %w", err)
 	}
 
-	return tx.Commit()
+	if err = holdSeat(ctx, tx, span, bookingID, eventID, seatID); err != nil {
+		return 0, err
+	}
+
+	return bookingID, nil
 }
 
-func (s *Storage) ConfirmBooking(eventID int, userID string) error {
-	tx, err := s.DB.Begin()
+func (s *Storage) ConfirmBooking(ctx context.Context, eventID int, userID string) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	var bookingID int
+	var (
+		bookingID  int
+		ticketType sql.NullInt64
+	)
 	checkQuery := `
-		SELECT id FROM bookings 
+		SELECT id, ticket_type_id FROM bookings 
 		WHERE event_id = $1 AND user_id = $2 AND confirmed = false`
 
-	err = tx.QueryRow(checkQuery, eventID, userID).Scan(&bookingID)
+	spanCtx, span := startSpan(ctx, "ConfirmBooking.FindPending", checkQuery)
+	err = tx.QueryRowContext(spanCtx, checkQuery, eventID, userID).Scan(&bookingID, &ticketType)
+	endSpan(span, err)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("no pending booking found")
@@ -161,15 +397,70 @@ func (s *Storage) ConfirmBooking(eventID int, userID string) error {
 		return fmt.Errorf("failed to check booking: %w", err)
 	}
 
-	var totalSeats, bookedSeats int
+	if err = confirmBooking(ctx, tx, "ConfirmBooking", bookingID, eventID, ticketType); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockEvent locks the event's row until tx ends. Bookings and confirmations
+// take it before counting the event's seats or locking its ticket types, so
+// concurrent ones are counted one after another, never exceed the seats and
+// always lock in the same order. span prefixes the span names.
+func lockEvent(ctx context.Context, tx *sql.Tx, span string, eventID int) (totalSeats int, err error) {
+	query := `
+		SELECT total_seats FROM events
+		WHERE id = $1
+		FOR UPDATE`
+
+	spanCtx, sp := startSpan(ctx, span+".LockEvent", query)
+	err = tx.QueryRowContext(spanCtx, query, eventID).Scan(&totalSeats)
+	endSpan(sp, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("event not found")
+		}
+		return 0, fmt.Errorf("failed to lock event: %w", err)
+	}
+
+	return totalSeats, nil
+}
+
+// confirmBooking confirms the pending booking if the event and its ticket
+// type have a seat left. The event and the ticket type stay locked until tx
+// ends. span prefixes the span names.
+func confirmBooking(ctx context.Context, tx *sql.Tx, span string, bookingID, eventID int, ticketType sql.NullInt64) error {
+	totalSeats, err := lockEvent(ctx, tx, span, eventID)
+	if err != nil {
+		return err
+	}
+
+	// Whoever held the lock before may have confirmed or cancelled the booking.
+	var confirmed bool
+	pendingQuery := `
+		SELECT confirmed FROM bookings
+		WHERE id = $1
+		FOR UPDATE`
+
+	spanCtx, sp := startSpan(ctx, span+".LockBooking", pendingQuery)
+	err = tx.QueryRowContext(spanCtx, pendingQuery, bookingID).Scan(&confirmed)
+	endSpan(sp, err)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check booking: %w", err)
+	}
+	if err == sql.ErrNoRows || confirmed {
+		return fmt.Errorf("no pending booking found")
+	}
+
+	var bookedSeats int
 	countQuery := `
-		SELECT e.total_seats, COUNT(b.id)
-		FROM events e
-		LEFT JOIN bookings b ON e.id = b.event_id AND b.confirmed = true
-		WHERE e.id = $1
-		GROUP BY e.id, e.total_seats`
+		SELECT COUNT(*) FROM bookings
+		WHERE event_id = $1 AND confirmed = true`
 
-	err = tx.QueryRow(countQuery, eventID).Scan(&totalSeats, &bookedSeats)
+	spanCtx, sp = startSpan(ctx, span+".CountSeats", countQuery)
+	err = tx.QueryRowContext(spanCtx, countQuery, eventID).Scan(&bookedSeats)
+	endSpan(sp, err)
 	if err != nil {
 		return fmt.Errorf("failed to get event seats info: %w", err)
 	}
@@ -178,20 +469,165 @@ func (s *Storage) ConfirmBooking(eventID int, userID string) error {
 		return fmt.Errorf("no available seats")
 	}
 
+	// The sales window only limits new bookings, so a booking made before
+	// sales end can still be confirmed after.
+	if ticketType.Valid {
+		if err = checkTicketType(ctx, tx, span, eventID, int(ticketType.Int64), false); err != nil {
+			return err
+		}
+	}
+
 	updateQuery := `
 		UPDATE bookings 
 		SET confirmed = true 
 		WHERE id = $1`
 
-	_, err = tx.Exec(updateQuery, bookingID)
+	spanCtx, sp = startSpan(ctx, span+".Update", updateQuery)
+	_, err = tx.ExecContext(spanCtx, updateQuery, bookingID)
+	endSpan(sp, err)
 	if err != nil {
 		return fmt.Errorf("failed to confirm booking: %w", err)
 	}
 
+	return nil
+}
+
+// CancelBooking deletes the user's booking for the event, preferring a pending
+// one over the latest confirmed one, and frees its seat. A paid booking gets
+// a pending refund according to the event's cancellation policy, to be sent
+// to the payment provider, see PendingRefunds.
+func (s *Storage) CancelBooking(ctx context.Context, eventID int, userID string) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	findQuery := `
+		SELECT id FROM bookings
+		WHERE event_id = $1 AND user_id = $2
+		ORDER BY confirmed, id DESC
+		LIMIT 1
+		FOR UPDATE`
+
+	var bookingID int
+	spanCtx, span := startSpan(ctx, "CancelBooking.Find", findQuery)
+	err = tx.QueryRowContext(spanCtx, findQuery, eventID, userID).Scan(&bookingID)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("no booking found")
+		}
+		return fmt.Errorf("failed to find booking: %w", err)
+	}
+
+	// The refund is recorded first, deleting the booking unlinks its payment.
+	if err = recordCancellationRefund(ctx, tx, bookingID); err != nil {
+		return err
+	}
+
+	deleteQuery := `
+		DELETE FROM bookings
+		WHERE id = $1`
+
+	spanCtx, span = startSpan(ctx, "CancelBooking.Delete", deleteQuery)
+	_, err = tx.ExecContext(spanCtx, deleteQuery, bookingID)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to cancel booking: %w", err)
+	}
+
 	return tx.Commit()
 }
 
-func (s *Storage) CancelExpiredBookings() error {
+// GetConfirmedBooking returns the user's latest confirmed booking for the event.
+func (s *Storage) GetConfirmedBooking(ctx context.Context, eventID int, userID string) (*models.Booking, error) {
+	query := `
+		SELECT` + bookingColumns + `
+		FROM bookings
+		WHERE event_id = $1 AND user_id = $2 AND confirmed = true
+		ORDER BY id DESC
+		LIMIT 1`
+
+	var booking models.Booking
+	spanCtx, span := startSpan(ctx, "GetConfirmedBooking", query)
+	err := s.DB.QueryRowContext(spanCtx, query, eventID, userID).Scan(bookingFields(&booking)...)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no confirmed booking found")
+		}
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	return &booking, nil
+}
+
+// GetConfirmedBookingByID returns the booking with the id if it is confirmed.
+func (s *Storage) GetConfirmedBookingByID(ctx context.Context, id int) (*models.Booking, error) {
+	query := `
+		SELECT` + bookingColumns + `
+		FROM bookings
+		WHERE id = $1 AND confirmed = true`
+
+	var booking models.Booking
+	spanCtx, span := startSpan(ctx, "GetConfirmedBookingByID", query)
+	err := s.DB.QueryRowContext(spanCtx, query, id).Scan(bookingFields(&booking)...)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no confirmed booking found")
+		}
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	return &booking, nil
+}
+
+// CheckIn records that the ticket of a confirmed booking was used. A booking
+// is checked in once; later attempts fail with "ticket already used".
+func (s *Storage) CheckIn(ctx context.Context, bookingID, eventID int, userID string) (*models.Booking, error) {
+	query := `
+		UPDATE bookings
+		SET checked_in_at = NOW()
+		WHERE id = $1 AND event_id = $2 AND user_id = $3 AND confirmed = true
+		AND checked_in_at IS NULL
+		RETURNING` + bookingColumns
+
+	var booking models.Booking
+	spanCtx, span := startSpan(ctx, "CheckIn", query)
+	err := s.DB.QueryRowContext(spanCtx, query, bookingID, eventID, userID).Scan(bookingFields(&booking)...)
+	endSpan(span, err)
+	if err == nil {
+		return &booking, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to check in: %w", err)
+	}
+
+	usedQuery := `
+		SELECT EXISTS(
+			SELECT 1 FROM bookings
+			WHERE id = $1 AND event_id = $2 AND user_id = $3 AND confirmed = true
+		)`
+
+	var used bool
+	spanCtx, span = startSpan(ctx, "CheckIn.Used", usedQuery)
+	err = s.DB.QueryRowContext(spanCtx, usedQuery, bookingID, eventID, userID).Scan(&used)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check in: %w", err)
+	}
+
+	if used {
+		return nil, fmt.Errorf("ticket already used")
+	}
+
+	// The booking was cancelled after the ticket was issued.
+	return nil, fmt.Errorf("no confirmed booking found")
+}
+
+func (s *Storage) CancelExpiredBookings(ctx context.Context) (int64, error) {
 	query := `
 		DELETE FROM bookings 
 		WHERE confirmed = false 
@@ -201,32 +637,36 @@ func (s *Storage) CancelExpiredBookings() error {
 			WHERE id = bookings.event_id
 		)`
 
-	result, err := s.DB.Exec(query)
+	ctx, span := startSpan(ctx, "CancelExpiredBookings", query)
+	result, err := s.DB.ExecContext(ctx, query)
+	endSpan(span, err)
 	if err != nil {
-		return fmt.Errorf("failed to cancel expired bookings: %w", err)
+		return 0, fmt.Errorf("failed to cancel expired bookings: %w", err)
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected > 0 {
-		fmt.Printf("Cancelled %d expired bookings\n", rowsAffected)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cancelled bookings count: %w", err)
 	}
 
-	return nil
+	return rowsAffected, nil
 }
 
-func (s *Storage) GetEventWithBookings(eventID int) (*models.Event, []models.Booking, error) {
-	event, err := s.GetEvent(eventID)
+func (s *Storage) GetEventWithBookings(ctx context.Context, eventID int) (*models.Event, []models.Booking, error) {
+	event, err := s.GetEvent(ctx, eventID)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	query := `
-		SELECT id, event_id, user_id, created_at, confirmed
+		SELECT` + bookingColumns + `
 		FROM bookings
 		WHERE event_id = $1
 		ORDER BY created_at DESC`
 
-	rows, err := s.DB.Query(query, eventID)
+	spanCtx, span := startSpan(ctx, "GetEventWithBookings", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, eventID)
+	endSpan(span, err)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get bookings: %w", err)
 	}
@@ -235,13 +675,7 @@ func (s *Storage) GetEventWithBookings(eventID int) (*models.Event, []models.Boo
 	var bookings []models.Booking
 	for rows.Next() {
 		var booking models.Booking
-		err = rows.Scan(
-			&booking.ID,
-			&booking.EventID,
-			&booking.UserID,
-			&booking.CreatedAt,
-			&booking.Confirmed,
-		)
+		err = rows.Scan(bookingFields(&booking)...)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to scan booking: %w", err)
 		}
@@ -255,13 +689,115 @@ func (s *Storage) GetEventWithBookings(eventID int) (*models.Event, []models.Boo
 	return event, bookings, nil
 }
 
-func (s *Storage) GetAllEvents() ([]models.Event, error) {
+// StreamBookings calls fn for each booking of the event in the order they were
+// made, reading them one at a time. An empty status selects all bookings.
+// It fails with "event not found" before calling fn if there is no such event.
+func (s *Storage) StreamBookings(ctx context.Context, eventID int, status string, fn func(models.Booking) error) error {
+	existsQuery := `
+		SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)`
+
+	var exists bool
+	spanCtx, span := startSpan(ctx, "StreamBookings.EventExists", existsQuery)
+	err := s.DB.QueryRowContext(spanCtx, existsQuery, eventID).Scan(&exists)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("event not found")
+	}
+
 	query := `
-        SELECT id, title, date, total_seats, deadline_minutes
-        FROM events
-        ORDER BY date ASC`
+		SELECT` + bookingColumns + `
+		FROM bookings
+		WHERE event_id = $1
+		AND ($2 = '' OR confirmed = ($2 = 'confirmed'))
+		ORDER BY created_at, id`
+
+	spanCtx, span = startSpan(ctx, "StreamBookings", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, eventID, status)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to get bookings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var booking models.Booking
+		err = rows.Scan(bookingFields(&booking)...)
+		if err != nil {
+			return fmt.Errorf("failed to scan booking: %w", err)
+		}
+
+		if err = fn(booking); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to read bookings: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserConfirmedEvents returns the events the user holds a confirmed booking for, ordered by date.
+func (s *Storage) GetUserConfirmedEvents(ctx context.Context, userID string) ([]models.Event, error) {
+	query := `
+		SELECT e.id, e.title, e.date, e.total_seats, e.deadline_minutes,
+		       (SELECT COUNT(*) FROM bookings c WHERE c.event_id = e.id AND c.confirmed = true),` + eventTimezone + `,` + scheduleColumns + `
+		FROM events e
+		WHERE EXISTS(
+			SELECT 1 FROM bookings b
+			WHERE b.event_id = e.id AND b.user_id = $1 AND b.confirmed = true
+		)
+		ORDER BY e.date ASC, e.id ASC`
+
+	spanCtx, span := startSpan(ctx, "GetUserConfirmedEvents", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, userID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.Event
+	for rows.Next() {
+		var event models.Event
+		err = rows.Scan(append([]any{
+			&event.ID,
+			&event.Title,
+			&event.Date,
+			&event.TotalSeats,
+			&event.Deadline,
+			&event.BookedSeats,
+			&event.Timezone,
+		}, scheduleFields(&event.Schedule)...)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		event.Localize()
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read user events: %w", err)
+	}
 
-	rows, err := s.DB.Query(query)
+	return events, nil
+}
+
+// GetAllEvents returns the events ordered by date, without drafts.
+func (s *Storage) GetAllEvents(ctx context.Context) ([]models.Event, error) {
+	query := `
+        SELECT e.id, e.title, e.date, e.total_seats, e.deadline_minutes,` + eventTimezone + `,` + scheduleColumns + `
+        FROM events e
+        WHERE e.status <> 'draft'
+        ORDER BY e.date ASC`
+
+	spanCtx, span := startSpan(ctx, "GetAllEvents", query)
+	rows, err := s.DB.QueryContext(spanCtx, query)
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
@@ -270,23 +806,27 @@ func (s *Storage) GetAllEvents() ([]models.Event, error) {
 	var events []models.Event
 	for rows.Next() {
 		var event models.Event
-		err := rows.Scan(
+		err := rows.Scan(append([]any{
 			&event.ID,
 			&event.Title,
 			&event.Date,
 			&event.TotalSeats,
 			&event.Deadline,
-		)
+			&event.Timezone,
+		}, scheduleFields(&event.Schedule)...)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
+		event.Localize()
 
 		bookedQuery := `
             SELECT COUNT(*) 
             FROM bookings 
             WHERE event_id = $1 AND confirmed = true`
 
-		err = s.DB.QueryRow(bookedQuery, event.ID).Scan(&event.BookedSeats)
+		countCtx, countSpan := startSpan(ctx, "GetAllEvents.CountBooked", bookedQuery)
+		err = s.DB.QueryRowContext(countCtx, bookedQuery, event.ID).Scan(&event.BookedSeats)
+		endSpan(countSpan, err)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get booked seats count: %w", err)
 		}
@@ -300,3 +840,121 @@ func (s *Storage) GetAllEvents() ([]models.Event, error) {
 
 	return events, nil
 }
+
+// UpcomingEvents returns the limit nearest events that have not started yet
+// and are published or have closed their sales, with their confirmed
+// bookings counted, and the number of such events in all.
+func (s *Storage) UpcomingEvents(ctx context.Context, limit int) ([]models.Event, int, error) {
+	query := `
+		SELECT e.id, e.date, e.total_seats,
+		       (SELECT COUNT(*) FROM bookings b WHERE b.event_id = e.id AND b.confirmed = true),
+		       COUNT(*) OVER ()
+		FROM events e
+		WHERE e.date >= NOW() AND e.status IN ('published', 'sales_closed')
+		ORDER BY e.date ASC, e.id ASC
+		LIMIT $1`
+
+	spanCtx, span := startSpan(ctx, "UpcomingEvents", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, limit)
+	endSpan(span, err)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get upcoming events: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		events []models.Event
+		total  int
+	)
+	for rows.Next() {
+		var event models.Event
+		if err = rows.Scan(&event.ID, &event.Date, &event.TotalSeats, &event.BookedSeats, &total); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// ListEvents returns a page of events ordered by date and the total number of events.
+// A zero limit returns all events starting at offset. A non-zero venueID
+// only lists the events held at that venue. A non-empty day, "today" or
+// a date in the format 2006-01-02, only lists the events starting on that
+// day in their own time zones. Drafts are only listed with includeDrafts.
+func (s *Storage) ListEvents(ctx context.Context, limit, offset, venueID int, day string, includeDrafts bool) ([]models.Event, int, error) {
+	// The NULL date and false today do not filter.
+	var date sql.NullString
+	today := day == "today"
+	if day != "" && !today {
+		date = sql.NullString{String: day, Valid: true}
+	}
+
+	from := `
+		FROM events e
+		CROSS JOIN LATERAL (SELECT` + eventTimezone + ` AS name) tz
+		WHERE ($1 = 0 OR e.venue_id = $1)
+		  AND ($2::date IS NULL OR (e.date AT TIME ZONE tz.name)::date = $2::date)
+		  AND (NOT $3 OR (e.date AT TIME ZONE tz.name)::date = (NOW() AT TIME ZONE tz.name)::date)
+		  AND ($4 OR e.status <> 'draft')`
+
+	countQuery := `
+		SELECT COUNT(*)` + from
+
+	var total int
+	spanCtx, span := startSpan(ctx, "ListEvents.Count", countQuery)
+	err := s.DB.QueryRowContext(spanCtx, countQuery, venueID, date, today, includeDrafts).Scan(&total)
+	endSpan(span, err)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count events: %w", err)
+	}
+
+	query := `
+		SELECT e.id, e.title, e.date, e.total_seats, e.deadline_minutes, e.layout_id, e.venue_id, e.series_id, tz.name,
+		       (SELECT COUNT(*) FROM bookings b WHERE b.event_id = e.id AND b.confirmed = true),` + scheduleColumns + from + `
+		ORDER BY e.date ASC, e.id ASC
+		LIMIT $5 OFFSET $6`
+
+	// LIMIT NULL is the same as no limit.
+	pageLimit := sql.NullInt64{Int64: int64(limit), Valid: limit > 0}
+
+	spanCtx, span = startSpan(ctx, "ListEvents", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, venueID, date, today, includeDrafts, pageLimit, offset)
+	endSpan(span, err)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]models.Event, 0)
+	for rows.Next() {
+		var event models.Event
+		err = rows.Scan(append([]any{
+			&event.ID,
+			&event.Title,
+			&event.Date,
+			&event.TotalSeats,
+			&event.Deadline,
+			&event.LayoutID,
+			&event.VenueID,
+			&event.SeriesID,
+			&event.Timezone,
+			&event.BookedSeats,
+		}, scheduleFields(&event.Schedule)...)...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan event: %w", err)
+		}
+		event.Localize()
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return events, total, nil
+}
@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+	"time"
+)
+
+const promoCodeColumns = `
+	p.id, p.code, p.kind, p.value, COALESCE(p.currency, ''), p.max_uses, p.max_uses_per_user,
+	p.valid_from, p.valid_until, p.event_id, p.ticket_type_id, p.created_at`
+
+// CreatePromoCode creates a promo code and returns its id. A code restricted
+// to a ticket type is restricted to the ticket type's event as well.
+func (s *Storage) CreatePromoCode(ctx context.Context, p models.PromoCode) (int, error) {
+	if p.TicketTypeID != nil {
+		query := `
+			SELECT event_id FROM ticket_types WHERE id = $1`
+
+		var eventID int
+		spanCtx, span := startSpan(ctx, "CreatePromoCode.TicketType", query)
+		err := s.DB.QueryRowContext(spanCtx, query, *p.TicketTypeID).Scan(&eventID)
+		endSpan(span, err)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return 0, fmt.Errorf("ticket type not found")
+			}
+			return 0, fmt.Errorf("failed to get ticket type: %w", err)
+		}
+
+		if p.EventID != nil && *p.EventID != eventID {
+			return 0, fmt.Errorf("ticket type not found")
+		}
+		p.EventID = &eventID
+	} else if p.EventID != nil {
+		query := `
+			SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)`
+
+		var exists bool
+		spanCtx, span := startSpan(ctx, "CreatePromoCode.EventExists", query)
+		err := s.DB.QueryRowContext(spanCtx, query, *p.EventID).Scan(&exists)
+		endSpan(span, err)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get event: %w", err)
+		}
+
+		if !exists {
+			return 0, fmt.Errorf("event not found")
+		}
+	}
+
+	query := `
+		INSERT INTO promo_codes (code, kind, value, currency, max_uses, max_uses_per_user,
+		                         valid_from, valid_until, event_id, ticket_type_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (code) DO NOTHING
+		RETURNING id`
+
+	var id int
+	spanCtx, span := startSpan(ctx, "CreatePromoCode", query)
+	err := s.DB.QueryRowContext(spanCtx, query,
+		p.Code, p.Kind, p.Value, p.Currency, p.MaxUses, p.MaxUsesPerUser,
+		p.ValidFrom, p.ValidUntil, p.EventID, p.TicketTypeID,
+	).Scan(&id)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("promo code already exists")
+		}
+		return 0, fmt.Errorf("failed to create promo code: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetPromoCodes returns all promo codes, oldest first, with their uses counted.
+func (s *Storage) GetPromoCodes(ctx context.Context) ([]models.PromoCode, error) {
+	query := `
+		SELECT` + promoCodeColumns + `,
+		       (SELECT COUNT(*) FROM promo_redemptions r WHERE r.promo_code_id = p.id)
+		FROM promo_codes p
+		ORDER BY p.id`
+
+	spanCtx, span := startSpan(ctx, "GetPromoCodes", query)
+	rows, err := s.DB.QueryContext(spanCtx, query)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo codes: %w", err)
+	}
+	defer rows.Close()
+
+	codes := make([]models.PromoCode, 0)
+	for rows.Next() {
+		var p models.PromoCode
+		if err = rows.Scan(append(promoCodeFields(&p), &p.Uses)...); err != nil {
+			return nil, fmt.Errorf("failed to scan promo code: %w", err)
+		}
+		codes = append(codes, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read promo codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+// promoCodeFields returns the scan destinations of promoCodeColumns.
+func promoCodeFields(p *models.PromoCode) []any {
+	return []any{
+		&p.ID,
+		&p.Code,
+		&p.Kind,
+		&p.Value,
+		&p.Currency,
+		&p.MaxUses,
+		&p.MaxUsesPerUser,
+		&p.ValidFrom,
+		&p.ValidUntil,
+		&p.EventID,
+		&p.TicketTypeID,
+		&p.CreatedAt,
+	}
+}
+
+// redeemPromoCode applies the promo code to the new booking. The code's row
+// is locked until the transaction ends, so concurrent bookings with the same
+// code are counted one after another and never exceed its limits.
+func redeemPromoCode(ctx context.Context, tx *sql.Tx, bookingID, eventID, ticketTypeID int, userID, code string) error {
+	query := `
+		SELECT` + promoCodeColumns + `
+		FROM promo_codes p
+		WHERE p.code = UPPER($1)
+		FOR UPDATE`
+
+	var p models.PromoCode
+	spanCtx, span := startSpan(ctx, "BookEvent.PromoCode", query)
+	err := tx.QueryRowContext(spanCtx, query, code).Scan(promoCodeFields(&p)...)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("promo code not found")
+		}
+		return fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	if !p.Active(time.Now()) {
+		return fmt.Errorf("promo code is not active")
+	}
+
+	var (
+		price    int64
+		currency string
+	)
+	if ticketTypeID != 0 {
+		priceQuery := `
+			SELECT price, currency FROM ticket_types WHERE id = $1`
+
+		spanCtx, span = startSpan(ctx, "BookEvent.Price", priceQuery)
+		err = tx.QueryRowContext(spanCtx, priceQuery, ticketTypeID).Scan(&price, &currency)
+		endSpan(span, err)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket type price: %w", err)
+		}
+	}
+
+	if !p.AppliesTo(eventID, ticketTypeID, currency) {
+		return fmt.Errorf("promo code does not apply")
+	}
+
+	usesQuery := `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE user_id = $2)
+		FROM promo_redemptions
+		WHERE promo_code_id = $1`
+
+	var uses, userUses int
+	spanCtx, span = startSpan(ctx, "BookEvent.PromoUses", usesQuery)
+	err = tx.QueryRowContext(spanCtx, usesQuery, p.ID, userID).Scan(&uses, &userUses)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to count promo code uses: %w", err)
+	}
+
+	if p.MaxUses > 0 && uses >= p.MaxUses {
+		return fmt.Errorf("promo code usage limit reached")
+	}
+	if p.MaxUsesPerUser > 0 && userUses >= p.MaxUsesPerUser {
+		return fmt.Errorf("promo code already used")
+	}
+
+	insertQuery := `
+		INSERT INTO promo_redemptions (promo_code_id, booking_id, user_id, discount)
+		VALUES ($1, $2, $3, $4)`
+
+	spanCtx, span = startSpan(ctx, "BookEvent.Redeem", insertQuery)
+	_, err = tx.ExecContext(spanCtx, insertQuery, p.ID, bookingID, userID, p.Discount(price))
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to redeem promo code: %w", err)
+	}
+
+	return nil
+}
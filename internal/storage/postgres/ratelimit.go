@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/lib/ratelimit"
+	"fmt"
+	"time"
+)
+
+// RateLimiter stores token buckets in Postgres so that all replicas share them.
+type RateLimiter struct {
+	db *sql.DB
+}
+
+func NewRateLimiter(s *Storage) *RateLimiter {
+	return &RateLimiter{db: s.DB}
+}
+
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	// Create the bucket full on first sight so that FOR UPDATE always has a row to lock.
+	insertQuery := `
+		INSERT INTO rate_limits (key, tokens, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO NOTHING`
+
+	spanCtx, span := startSpan(ctx, "RateLimiter.Insert", insertQuery)
+	_, err = tx.ExecContext(spanCtx, insertQuery, key, float64(limit.Burst), now)
+	endSpan(span, err)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("failed to create rate limit bucket: %w", err)
+	}
+
+	var bucket ratelimit.Bucket
+	selectQuery := `
+		SELECT tokens, updated_at
+		FROM rate_limits
+		WHERE key = $1
+		FOR UPDATE`
+
+	spanCtx, span = startSpan(ctx, "RateLimiter.Select", selectQuery)
+	err = tx.QueryRowContext(spanCtx, selectQuery, key).Scan(&bucket.Tokens, &bucket.Updated)
+	endSpan(span, err)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("failed to get rate limit bucket: %w", err)
+	}
+
+	bucket, res := ratelimit.Take(bucket, now, limit)
+
+	updateQuery := `
+		UPDATE rate_limits
+		SET tokens = $2, updated_at = $3
+		WHERE key = $1`
+
+	spanCtx, span = startSpan(ctx, "RateLimiter.Update", updateQuery)
+	_, err = tx.ExecContext(spanCtx, updateQuery, key, bucket.Tokens, bucket.Updated)
+	endSpan(span, err)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("failed to save rate limit bucket: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return ratelimit.Result{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return res, nil
+}
+
+func (l *RateLimiter) Cleanup(ctx context.Context, idle time.Duration) error {
+	query := `
+		DELETE FROM rate_limits
+		WHERE updated_at < $1`
+
+	ctx, span := startSpan(ctx, "RateLimiter.Cleanup", query)
+	_, err := l.db.ExecContext(ctx, query, time.Now().Add(-idle))
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup rate limits: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,431 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SetCancellationPolicy sets the cancellation policy of the event, replacing the previous one.
+func (s *Storage) SetCancellationPolicy(ctx context.Context, p models.CancellationPolicy) error {
+	query := `
+		INSERT INTO cancellation_policies (event_id, full_refund_hours, partial_refund_percent, cutoff_hours)
+		SELECT id, $2, $3, $4
+		FROM events
+		WHERE id = $1
+		ON CONFLICT (event_id) DO UPDATE
+		SET full_refund_hours = EXCLUDED.full_refund_hours,
+		    partial_refund_percent = EXCLUDED.partial_refund_percent,
+		    cutoff_hours = EXCLUDED.cutoff_hours,
+		    updated_at = NOW()`
+
+	ctx, span := startSpan(ctx, "SetCancellationPolicy", query)
+	result, err := s.DB.ExecContext(ctx, query, p.EventID, p.FullRefundHours, p.PartialRefundPercent, p.CutoffHours)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to set cancellation policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get updated policies count: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("event not found")
+	}
+
+	return nil
+}
+
+// GetCancellationPolicy returns the cancellation policy of the event, the
+// zero policy for events without one.
+func (s *Storage) GetCancellationPolicy(ctx context.Context, eventID int) (*models.CancellationPolicy, error) {
+	query := `
+		SELECT e.id, COALESCE(p.full_refund_hours, 0), COALESCE(p.partial_refund_percent, 0), COALESCE(p.cutoff_hours, 0)
+		FROM events e
+		LEFT JOIN cancellation_policies p ON p.event_id = e.id
+		WHERE e.id = $1`
+
+	var p models.CancellationPolicy
+	ctx, span := startSpan(ctx, "GetCancellationPolicy", query)
+	err := s.DB.QueryRowContext(ctx, query, eventID).Scan(
+		&p.EventID,
+		&p.FullRefundHours,
+		&p.PartialRefundPercent,
+		&p.CutoffHours,
+	)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("event not found")
+		}
+		return nil, fmt.Errorf("failed to get cancellation policy: %w", err)
+	}
+
+	return &p, nil
+}
+
+// recordCancellationRefund records a pending refund of the succeeded payment
+// of the booking, if any, according to the event's cancellation policy.
+func recordCancellationRefund(ctx context.Context, tx *sql.Tx, bookingID int) error {
+	query := `
+		SELECT p.id, p.amount, e.date,
+		       COALESCE(c.full_refund_hours, 0), COALESCE(c.partial_refund_percent, 0), COALESCE(c.cutoff_hours, 0)
+		FROM payments p
+		JOIN events e ON e.id = p.event_id
+		LEFT JOIN cancellation_policies c ON c.event_id = p.event_id
+		WHERE p.booking_id = $1 AND p.status = 'succeeded'
+		FOR UPDATE OF p`
+
+	var (
+		paymentID int
+		amount    int64
+		eventDate time.Time
+		policy    models.CancellationPolicy
+	)
+	spanCtx, span := startSpan(ctx, "CancelBooking.Payment", query)
+	err := tx.QueryRowContext(spanCtx, query, bookingID).Scan(
+		&paymentID,
+		&amount,
+		&eventDate,
+		&policy.FullRefundHours,
+		&policy.PartialRefundPercent,
+		&policy.CutoffHours,
+	)
+	endSpan(span, err)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get booking payment: %w", err)
+	}
+
+	refundable, err := refundable(ctx, tx, "CancelBooking", paymentID, amount)
+	if err != nil {
+		return err
+	}
+
+	refund := min(policy.RefundAmount(amount, eventDate, time.Now()), refundable)
+	if refund <= 0 {
+		return nil
+	}
+
+	_, err = insertRefund(ctx, tx, "CancelBooking", models.Refund{
+		PaymentID: paymentID,
+		BookingID: &bookingID,
+		Amount:    refund,
+		Reason:    models.RefundCancellation,
+	})
+
+	return err
+}
+
+// CreateRefund records a pending refund of a succeeded payment. A zero
+// amount refunds the rest of the payment not refunded yet.
+func (s *Storage) CreateRefund(ctx context.Context, r models.Refund) (*models.Refund, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT amount, status
+		FROM payments
+		WHERE id = $1
+		FOR UPDATE`
+
+	var (
+		amount int64
+		status string
+	)
+	spanCtx, span := startSpan(ctx, "CreateRefund.Payment", query)
+	err = tx.QueryRowContext(spanCtx, query, r.PaymentID).Scan(&amount, &status)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("payment not found")
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if status != models.PaymentSucceeded {
+		return nil, fmt.Errorf("payment not refundable")
+	}
+
+	rest, err := refundable(ctx, tx, "CreateRefund", r.PaymentID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Amount == 0 {
+		r.Amount = rest
+	}
+	if r.Amount <= 0 || r.Amount > rest {
+		return nil, fmt.Errorf("refund exceeds payment")
+	}
+
+	refund, err := insertRefund(ctx, tx, "CreateRefund", r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit refund: %w", err)
+	}
+
+	return refund, nil
+}
+
+// refundable returns how much of the payment is not refunded yet. Pending
+// refunds count as refunded. span prefixes the span names.
+func refundable(ctx context.Context, tx *sql.Tx, span string, paymentID int, amount int64) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM refunds
+		WHERE payment_id = $1 AND status <> 'failed'`
+
+	var refunded int64
+	spanCtx, sp := startSpan(ctx, span+".Refunded", query)
+	err := tx.QueryRowContext(spanCtx, query, paymentID).Scan(&refunded)
+	endSpan(sp, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get refunded amount: %w", err)
+	}
+
+	return amount - refunded, nil
+}
+
+// insertRefund records a pending refund of the payment, copying the booking,
+// event, user and currency from it unless r has them. span prefixes the span names.
+func insertRefund(ctx context.Context, tx *sql.Tx, span string, r models.Refund) (*models.Refund, error) {
+	query := `
+		INSERT INTO refunds (payment_id, booking_id, event_id, user_id, amount, currency, reason, note, created_by)
+		SELECT id, COALESCE($2, booking_id), event_id, user_id, $3, currency, $4, $5, $6
+		FROM payments
+		WHERE id = $1
+		RETURNING id, booking_id, event_id, user_id, currency, status, created_at, updated_at,
+		          (SELECT provider_payment_id FROM payments WHERE id = $1)`
+
+	var bookingID sql.NullInt64
+	spanCtx, sp := startSpan(ctx, span+".InsertRefund", query)
+	err := tx.QueryRowContext(spanCtx, query,
+		r.PaymentID, r.BookingID, r.Amount, r.Reason, r.Note, r.CreatedBy,
+	).Scan(
+		&r.ID,
+		&bookingID,
+		&r.EventID,
+		&r.UserID,
+		&r.Currency,
+		&r.Status,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.ProviderID,
+	)
+	endSpan(sp, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refund: %w", err)
+	}
+
+	r.BookingID = nil
+	if bookingID.Valid {
+		id := int(bookingID.Int64)
+		r.BookingID = &id
+	}
+
+	return &r, nil
+}
+
+// ClaimRefund marks the pending refund as being sent to the provider. Only
+// one caller claims a refund, the others get "refund not pending".
+func (s *Storage) ClaimRefund(ctx context.Context, id int) error {
+	query := `
+		UPDATE refunds
+		SET status = 'sending', updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'`
+
+	ctx, span := startSpan(ctx, "ClaimRefund", query)
+	result, err := s.DB.ExecContext(ctx, query, id)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to claim refund: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get claimed refunds count: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("refund not pending")
+	}
+
+	return nil
+}
+
+// CompleteRefund records whether the provider returned the refund claimed
+// by ClaimRefund. A payment refunded in full becomes refunded.
+func (s *Storage) CompleteRefund(ctx context.Context, id int, succeeded bool) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status := models.RefundFailed
+	if succeeded {
+		status = models.RefundSucceeded
+	}
+
+	query := `
+		UPDATE refunds
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'sending'
+		RETURNING payment_id`
+
+	var paymentID int
+	spanCtx, span := startSpan(ctx, "CompleteRefund", query)
+	err = tx.QueryRowContext(spanCtx, query, id, status).Scan(&paymentID)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("refund not found")
+		}
+		return fmt.Errorf("failed to complete refund: %w", err)
+	}
+
+	if succeeded {
+		paymentQuery := `
+			UPDATE payments
+			SET status = 'refunded', updated_at = NOW()
+			WHERE id = $1 AND amount <= (
+				SELECT SUM(amount) FROM refunds WHERE payment_id = $1 AND status = 'succeeded'
+			)`
+
+		spanCtx, span = startSpan(ctx, "CompleteRefund.Payment", paymentQuery)
+		_, err = tx.ExecContext(spanCtx, paymentQuery, paymentID)
+		endSpan(span, err)
+		if err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PendingRefunds returns the refunds of the user's cancelled bookings for
+// the event not yet sent to the payment provider.
+func (s *Storage) PendingRefunds(ctx context.Context, eventID int, userID string) ([]models.Refund, error) {
+	return s.getRefunds(ctx, "PendingRefunds", `
+		WHERE r.event_id = $1 AND r.user_id = $2 AND r.status = 'pending' AND r.reason = 'cancellation'`,
+		eventID, userID)
+}
+
+// ClaimStaleRefunds claims, as ClaimRefund does, the refunds left pending
+// since before t and those claimed before t whose sending never completed,
+// oldest first. Rows claimed by a concurrent caller are skipped, so every
+// refund goes to one caller only.
+func (s *Storage) ClaimStaleRefunds(ctx context.Context, t time.Time) ([]models.Refund, error) {
+	query := `
+		WITH stale AS (
+			SELECT id FROM refunds
+			WHERE (status = 'pending' AND created_at < $1) OR (status = 'sending' AND updated_at < $1)
+			ORDER BY id
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE refunds r
+		SET status = 'sending', updated_at = NOW()
+		FROM stale, payments p
+		WHERE r.id = stale.id AND p.id = r.payment_id
+		RETURNING r.id, r.payment_id, r.booking_id, r.event_id, r.user_id, r.amount, r.currency,
+		          r.reason, r.note, r.created_by, r.status, r.created_at, r.updated_at, p.provider_payment_id`
+
+	spanCtx, span := startSpan(ctx, "ClaimStaleRefunds", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, t)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim refunds: %w", err)
+	}
+	defer rows.Close()
+
+	refunds, err := scanRefunds(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(refunds, func(i, j int) bool { return refunds[i].ID < refunds[j].ID })
+
+	return refunds, nil
+}
+
+// GetRefunds returns the refunds of the event's payments, oldest first.
+func (s *Storage) GetRefunds(ctx context.Context, eventID int) ([]models.Refund, error) {
+	return s.getRefunds(ctx, "GetRefunds", `
+		WHERE r.event_id = $1`,
+		eventID)
+}
+
+func (s *Storage) getRefunds(ctx context.Context, span, where string, args ...any) ([]models.Refund, error) {
+	query := `
+		SELECT r.id, r.payment_id, r.booking_id, r.event_id, r.user_id, r.amount, r.currency,
+		       r.reason, r.note, r.created_by, r.status, r.created_at, r.updated_at, p.provider_payment_id
+		FROM refunds r
+		JOIN payments p ON p.id = r.payment_id` + where + `
+		ORDER BY r.id`
+
+	spanCtx, sp := startSpan(ctx, span, query)
+	rows, err := s.DB.QueryContext(spanCtx, query, args...)
+	endSpan(sp, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refunds: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRefunds(rows)
+}
+
+// scanRefunds reads refunds with the columns selected by getRefunds.
+func scanRefunds(rows *sql.Rows) ([]models.Refund, error) {
+	refunds := make([]models.Refund, 0)
+	for rows.Next() {
+		var (
+			r         models.Refund
+			bookingID sql.NullInt64
+		)
+		err := rows.Scan(
+			&r.ID,
+			&r.PaymentID,
+			&bookingID,
+			&r.EventID,
+			&r.UserID,
+			&r.Amount,
+			&r.Currency,
+			&r.Reason,
+			&r.Note,
+			&r.CreatedBy,
+			&r.Status,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+			&r.ProviderID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan refund: %w", err)
+		}
+
+		if bookingID.Valid {
+			id := int(bookingID.Int64)
+			r.BookingID = &id
+		}
+		refunds = append(refunds, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read refunds: %w", err)
+	}
+
+	return refunds, nil
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// GrantRole grants role to the user. Granting a role the user already has is a no-op.
+func (s *Storage) GrantRole(ctx context.Context, userID, role string) error {
+	query := `
+		INSERT INTO user_roles (user_id, role)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, role) DO NOTHING`
+
+	ctx, span := startSpan(ctx, "GrantRole", query)
+	_, err := s.DB.ExecContext(ctx, query, userID, role)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	return nil
+}
+
+// HasRole reports whether the user has been granted role.
+func (s *Storage) HasRole(ctx context.Context, userID, role string) (bool, error) {
+	query := `
+		SELECT EXISTS(SELECT 1 FROM user_roles WHERE user_id = $1 AND role = $2)`
+
+	var has bool
+	ctx, span := startSpan(ctx, "HasRole", query)
+	err := s.DB.QueryRowContext(ctx, query, userID, role).Scan(&has)
+	endSpan(span, err)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	return has, nil
+}
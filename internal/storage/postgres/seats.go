@@ -0,0 +1,324 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+)
+
+// CreateSeatLayout creates the layout with its seats and returns its id.
+// The ids of the seats are ignored.
+func (s *Storage) CreateSeatLayout(ctx context.Context, layout models.SeatLayout) (int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO seat_layouts (name)
+		VALUES ($1)
+		RETURNING id`
+
+	var id int
+	spanCtx, span := startSpan(ctx, "CreateSeatLayout", query)
+	err = tx.QueryRowContext(spanCtx, query, layout.Name).Scan(&id)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create seat layout: %w", err)
+	}
+
+	seatQuery := `
+		INSERT INTO layout_seats (layout_id, section, row_name, number)
+		VALUES ($1, $2, $3, $4)`
+
+	spanCtx, span = startSpan(ctx, "CreateSeatLayout.Prepare", seatQuery)
+	stmt, err := tx.PrepareContext(spanCtx, seatQuery)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare seat insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, section := range layout.Sections {
+		for _, row := range section.Rows {
+			for _, seat := range row.Seats {
+				spanCtx, span = startSpan(ctx, "CreateSeatLayout.Seat", seatQuery)
+				_, err = stmt.ExecContext(spanCtx, id, section.Name, row.Name, seat.Number)
+				endSpan(span, err)
+				if err != nil {
+					return 0, fmt.Errorf("failed to create seat %s %s %d: %w", section.Name, row.Name, seat.Number, err)
+				}
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit seat layout: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetSeatLayout returns the layout with its seats in the order they were created.
+func (s *Storage) GetSeatLayout(ctx context.Context, id int) (*models.SeatLayout, error) {
+	query := `
+		SELECT id, name, created_at
+		FROM seat_layouts
+		WHERE id = $1`
+
+	var layout models.SeatLayout
+	spanCtx, span := startSpan(ctx, "GetSeatLayout", query)
+	err := s.DB.QueryRowContext(spanCtx, query, id).Scan(&layout.ID, &layout.Name, &layout.CreatedAt)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("seat layout not found")
+		}
+		return nil, fmt.Errorf("failed to get seat layout: %w", err)
+	}
+
+	seatsQuery := `
+		SELECT id, section, row_name, number, ''
+		FROM layout_seats
+		WHERE layout_id = $1
+		ORDER BY id`
+
+	if err = s.addSeats(ctx, "GetSeatLayout.Seats", seatsQuery, id, &layout); err != nil {
+		return nil, err
+	}
+
+	return &layout, nil
+}
+
+// GetSeatMap returns the layout of the event with the status of each seat.
+// It fails with "event has no seat layout" for general admission events.
+func (s *Storage) GetSeatMap(ctx context.Context, eventID int) (*models.SeatLayout, error) {
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.LayoutID == nil {
+		return nil, fmt.Errorf("event has no seat layout")
+	}
+
+	query := `
+		SELECT id, name, created_at
+		FROM seat_layouts
+		WHERE id = $1`
+
+	var layout models.SeatLayout
+	spanCtx, span := startSpan(ctx, "GetSeatMap.Layout", query)
+	err = s.DB.QueryRowContext(spanCtx, query, *event.LayoutID).Scan(&layout.ID, &layout.Name, &layout.CreatedAt)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat layout: %w", err)
+	}
+
+	seatsQuery := `
+		SELECT ls.id, ls.section, ls.row_name, ls.number,
+		       CASE
+		           WHEN b.id IS NULL THEN '` + models.SeatAvailable + `'
+		           WHEN b.confirmed THEN '` + models.SeatBooked + `'
+		           ELSE '` + models.SeatHeld + `'
+		       END
+		FROM event_seats es
+		JOIN layout_seats ls ON ls.id = es.seat_id
+		LEFT JOIN bookings b ON b.id = es.booking_id
+		WHERE es.event_id = $1
+		ORDER BY ls.id`
+
+	if err = s.addSeats(ctx, "GetSeatMap.Seats", seatsQuery, eventID, &layout); err != nil {
+		return nil, err
+	}
+
+	return &layout, nil
+}
+
+// addSeats adds the seats selected by query to the layout. The query
+// selects the id, section, row, number and status of each seat.
+func (s *Storage) addSeats(ctx context.Context, span, query string, id int, layout *models.SeatLayout) error {
+	spanCtx, sp := startSpan(ctx, span, query)
+	rows, err := s.DB.QueryContext(spanCtx, query, id)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to get seats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			seat         models.Seat
+			section, row string
+		)
+		if err = rows.Scan(&seat.ID, &section, &row, &seat.Number, &seat.Status); err != nil {
+			return fmt.Errorf("failed to scan seat: %w", err)
+		}
+		layout.AddSeat(section, row, seat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to read seats: %w", err)
+	}
+
+	return nil
+}
+
+// SetEventLayout switches the event to reserved seating with the layout's
+// seats, which also become its total seats. The layout of an event can
+// only be set before it has bookings.
+func (s *Storage) SetEventLayout(ctx context.Context, eventID, layoutID int) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the event waits for bookings being made, see holdSeat.
+	eventQuery := `
+		SELECT EXISTS(SELECT 1 FROM bookings WHERE event_id = e.id)
+		FROM events e
+		WHERE e.id = $1
+		FOR UPDATE`
+
+	var hasBookings bool
+	spanCtx, span := startSpan(ctx, "SetEventLayout.Event", eventQuery)
+	err = tx.QueryRowContext(spanCtx, eventQuery, eventID).Scan(&hasBookings)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("event not found")
+		}
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if hasBookings {
+		return fmt.Errorf("event already has bookings")
+	}
+
+	if err = assignLayout(ctx, tx, "SetEventLayout", eventID, layoutID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// assignLayout gives the event the layout's seats, which also become its
+// total seats. span prefixes the span names.
+func assignLayout(ctx context.Context, tx *sql.Tx, span string, eventID, layoutID int) error {
+	updateQuery := `
+		UPDATE events
+		SET layout_id = l.id,
+		    total_seats = (SELECT COUNT(*) FROM layout_seats ls WHERE ls.layout_id = l.id)
+		FROM seat_layouts l
+		WHERE events.id = $1 AND l.id = $2`
+
+	spanCtx, sp := startSpan(ctx, span+".Update", updateQuery)
+	result, err := tx.ExecContext(spanCtx, updateQuery, eventID, layoutID)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to set event layout: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get updated events count: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("seat layout not found")
+	}
+
+	deleteQuery := `
+		DELETE FROM event_seats
+		WHERE event_id = $1`
+
+	spanCtx, sp = startSpan(ctx, span+".DeleteSeats", deleteQuery)
+	_, err = tx.ExecContext(spanCtx, deleteQuery, eventID)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to delete event seats: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO event_seats (event_id, seat_id)
+		SELECT $1, id FROM layout_seats WHERE layout_id = $2`
+
+	spanCtx, sp = startSpan(ctx, span+".InsertSeats", insertQuery)
+	_, err = tx.ExecContext(spanCtx, insertQuery, eventID, layoutID)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to create event seats: %w", err)
+	}
+
+	return nil
+}
+
+// holdSeat reserves the seat of the event for the new booking. It fails
+// unless seatID is a free seat of an event with a layout, or 0 for an event
+// without. The seat is taken with a single conditional update, so of
+// concurrent bookings of the same seat only the first one gets it.
+// span prefixes the span names.
+func holdSeat(ctx context.Context, tx *sql.Tx, span string, bookingID, eventID, seatID int) error {
+	layoutQuery := `
+		SELECT layout_id FROM events WHERE id = $1 FOR SHARE`
+
+	var layoutID sql.NullInt64
+	spanCtx, sp := startSpan(ctx, span+".Layout", layoutQuery)
+	err := tx.QueryRowContext(spanCtx, layoutQuery, eventID).Scan(&layoutID)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to get event layout: %w", err)
+	}
+
+	if !layoutID.Valid {
+		if seatID != 0 {
+			return fmt.Errorf("event has no seat layout")
+		}
+		return nil
+	}
+
+	if seatID == 0 {
+		return fmt.Errorf("seat is required")
+	}
+
+	holdQuery := `
+		UPDATE event_seats
+		SET booking_id = $1
+		WHERE event_id = $2 AND seat_id = $3 AND booking_id IS NULL`
+
+	spanCtx, sp = startSpan(ctx, span+".HoldSeat", holdQuery)
+	result, err := tx.ExecContext(spanCtx, holdQuery, bookingID, eventID, seatID)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to hold seat: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get held seats count: %w", err)
+	}
+
+	if rowsAffected == 1 {
+		return nil
+	}
+
+	existsQuery := `
+		SELECT EXISTS(SELECT 1 FROM event_seats WHERE event_id = $1 AND seat_id = $2)`
+
+	var exists bool
+	spanCtx, sp = startSpan(ctx, span+".SeatExists", existsQuery)
+	err = tx.QueryRowContext(spanCtx, existsQuery, eventID, seatID).Scan(&exists)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to get seat: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("seat not found")
+	}
+
+	return fmt.Errorf("seat already taken")
+}
@@ -0,0 +1,699 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+	"time"
+)
+
+const seriesColumns = `
+	id, title, start_date, rrule, total_seats, deadline_minutes, venue_id, timezone, ends_at, generated_until, created_at`
+
+// CreateSeries creates a series and its occurrences starting before horizon
+// and returns the ids of the series and of the events created. A series at
+// a venue gets the venue's capacity when TotalSeats is 0 and may not exceed
+// it; occurrences get the venue's seat layout. An empty Timezone takes the
+// venue's time zone, or UTC without a venue.
+func (s *Storage) CreateSeries(ctx context.Context, sr models.Series, horizon time.Time) (int, []int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var layoutID *int
+	if sr.VenueID != nil {
+		sr.TotalSeats, layoutID, err = venueSeats(ctx, tx, "CreateSeries", *sr.VenueID, sr.TotalSeats)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	query := `
+		INSERT INTO event_series (title, start_date, rrule, total_seats, deadline_minutes, venue_id, timezone, generated_until)
+		VALUES ($1, $2, $3, $4, $5, $6,
+		        COALESCE(NULLIF($7, ''), (SELECT timezone FROM venues WHERE id = $6), 'UTC'), $8)
+		RETURNING id, timezone`
+
+	spanCtx, span := startSpan(ctx, "CreateSeries", query)
+	err = tx.QueryRowContext(spanCtx, query,
+		sr.Title, sr.Start, sr.Rule, sr.TotalSeats, sr.Deadline, sr.VenueID, sr.Timezone, horizon,
+	).Scan(&sr.ID, &sr.Timezone)
+	endSpan(span, err)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create series: %w", err)
+	}
+
+	for _, date := range sr.Exceptions {
+		if err = insertSeriesException(ctx, tx, "CreateSeries", sr.ID, date); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	ids, err := createOccurrences(ctx, tx, "CreateSeries", &sr, layoutID, sr.Start, horizon)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("failed to commit series: %w", err)
+	}
+
+	return sr.ID, ids, nil
+}
+
+// GetSeries returns the series and its occurrences ordered by date.
+func (s *Storage) GetSeries(ctx context.Context, id int) (*models.Series, []models.Event, error) {
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sr, err := selectSeries(ctx, tx, "GetSeries", id, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := `
+		SELECT e.id, e.title, e.date, e.total_seats, e.deadline_minutes, e.layout_id, e.venue_id, e.series_id, e.timezone,
+		       (SELECT COUNT(*) FROM bookings b WHERE b.event_id = e.id AND b.confirmed = true),` + scheduleColumns + `
+		FROM events e
+		WHERE e.series_id = $1
+		ORDER BY e.date, e.id`
+
+	spanCtx, span := startSpan(ctx, "GetSeries.Events", query)
+	rows, err := tx.QueryContext(spanCtx, query, id)
+	endSpan(span, err)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get series events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]models.Event, 0)
+	for rows.Next() {
+		var event models.Event
+		err = rows.Scan(append([]any{
+			&event.ID,
+			&event.Title,
+			&event.Date,
+			&event.TotalSeats,
+			&event.Deadline,
+			&event.LayoutID,
+			&event.VenueID,
+			&event.SeriesID,
+			&event.Timezone,
+			&event.BookedSeats,
+		}, scheduleFields(&event.Schedule)...)...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		event.Localize()
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read series events: %w", err)
+	}
+
+	return sr, events, nil
+}
+
+// UpdateSeriesEvent edits the occurrence eventID of the series and returns
+// the id of the series it belongs to afterwards. With models.ScopeThis only
+// the occurrence changes, like UpdateEvent. With models.ScopeFuture it and
+// all later occurrences get change's title, seats, deadline and wall-clock
+// time; change.Date must stay on the occurrence's day. Unless the occurrence
+// is the first one, the series is split there: a new series takes over the
+// occurrences from it on and the old one ends before it.
+func (s *Storage) UpdateSeriesEvent(ctx context.Context, seriesID, eventID int, scope string, change models.Event) (int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sr, err := selectSeries(ctx, tx, "UpdateSeriesEvent", seriesID, true)
+	if err != nil {
+		return 0, err
+	}
+
+	occurrenceQuery := `
+		SELECT occurrence_date FROM events
+		WHERE id = $1 AND series_id = $2
+		FOR UPDATE`
+
+	var occurrence time.Time
+	spanCtx, span := startSpan(ctx, "UpdateSeriesEvent.Occurrence", occurrenceQuery)
+	err = tx.QueryRowContext(spanCtx, occurrenceQuery, eventID, seriesID).Scan(&occurrence)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("occurrence not found")
+		}
+		return 0, fmt.Errorf("failed to get occurrence: %w", err)
+	}
+
+	if scope == models.ScopeThis {
+		query := `
+			UPDATE events
+			SET title = $2, date = $3, deadline_minutes = $5,
+			    total_seats = CASE WHEN layout_id IS NULL THEN $4 ELSE total_seats END
+			WHERE id = $1
+			  AND (layout_id IS NOT NULL OR venue_id IS NULL
+			       OR $4 <= (SELECT capacity FROM venues WHERE id = events.venue_id))`
+
+		spanCtx, span = startSpan(ctx, "UpdateSeriesEvent.Event", query)
+		result, err := tx.ExecContext(spanCtx, query, eventID, change.Title, change.Date, change.TotalSeats, change.Deadline)
+		endSpan(span, err)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update event: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get updated events count: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return 0, fmt.Errorf("event exceeds venue capacity")
+		}
+
+		return seriesID, tx.Commit()
+	}
+
+	if !sr.SameDay(change.Date, occurrence) {
+		return 0, fmt.Errorf("occurrence moved to another day")
+	}
+
+	if sr.VenueID != nil {
+		change.TotalSeats, _, err = venueSeats(ctx, tx, "UpdateSeriesEvent", *sr.VenueID, change.TotalSeats)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	targetID := seriesID
+	if occurrence.Equal(sr.Start) {
+		query := `
+			UPDATE event_series
+			SET title = $2, start_date = $3, total_seats = $4, deadline_minutes = $5
+			WHERE id = $1`
+
+		spanCtx, span = startSpan(ctx, "UpdateSeriesEvent.Series", query)
+		_, err = tx.ExecContext(spanCtx, query, seriesID, change.Title, change.Date, change.TotalSeats, change.Deadline)
+		endSpan(span, err)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update series: %w", err)
+		}
+	} else {
+		rule, err := sr.RuleFrom(occurrence)
+		if err != nil {
+			return 0, fmt.Errorf("failed to split series rule: %w", err)
+		}
+
+		insertQuery := `
+			INSERT INTO event_series (title, start_date, rrule, total_seats, deadline_minutes, venue_id, timezone, ends_at, generated_until)
+			SELECT $2, $3, $4, $5, $6, venue_id, timezone, ends_at, generated_until
+			FROM event_series
+			WHERE id = $1
+			RETURNING id`
+
+		spanCtx, span = startSpan(ctx, "UpdateSeriesEvent.Split", insertQuery)
+		err = tx.QueryRowContext(spanCtx, insertQuery,
+			seriesID, change.Title, change.Date, rule, change.TotalSeats, change.Deadline,
+		).Scan(&targetID)
+		endSpan(span, err)
+		if err != nil {
+			return 0, fmt.Errorf("failed to split series: %w", err)
+		}
+
+		endQuery := `
+			UPDATE event_series SET ends_at = $2 WHERE id = $1`
+
+		spanCtx, span = startSpan(ctx, "UpdateSeriesEvent.End", endQuery)
+		_, err = tx.ExecContext(spanCtx, endQuery, seriesID, occurrence)
+		endSpan(span, err)
+		if err != nil {
+			return 0, fmt.Errorf("failed to end series: %w", err)
+		}
+	}
+
+	// Later exceptions skip the same days of the edited occurrences.
+	deleteQuery := `
+		DELETE FROM series_exceptions
+		WHERE series_id = $1 AND date >= $2`
+
+	spanCtx, span = startSpan(ctx, "UpdateSeriesEvent.DeleteExceptions", deleteQuery)
+	_, err = tx.ExecContext(spanCtx, deleteQuery, seriesID, occurrence)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete series exceptions: %w", err)
+	}
+
+	for _, date := range sr.Exceptions {
+		if date.Before(occurrence) {
+			continue
+		}
+		if err = insertSeriesException(ctx, tx, "UpdateSeriesEvent", targetID, sr.Reclock(date, change.Date)); err != nil {
+			return 0, err
+		}
+	}
+
+	eventsQuery := `
+		SELECT id, occurrence_date FROM events
+		WHERE series_id = $1 AND occurrence_date >= $2
+		FOR UPDATE`
+
+	spanCtx, span = startSpan(ctx, "UpdateSeriesEvent.Events", eventsQuery)
+	rows, err := tx.QueryContext(spanCtx, eventsQuery, seriesID, occurrence)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get series events: %w", err)
+	}
+
+	moved := make(map[int]time.Time)
+	for rows.Next() {
+		var (
+			id   int
+			date time.Time
+		)
+		if err = rows.Scan(&id, &date); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan event: %w", err)
+		}
+		moved[id] = sr.Reclock(date, change.Date)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read series events: %w", err)
+	}
+
+	moveQuery := `
+		UPDATE events
+		SET series_id = $2, occurrence_date = $3, date = $3, title = $4, deadline_minutes = $5,
+		    total_seats = CASE WHEN layout_id IS NULL THEN $6 ELSE total_seats END
+		WHERE id = $1`
+
+	for id, date := range moved {
+		spanCtx, span = startSpan(ctx, "UpdateSeriesEvent.Move", moveQuery)
+		_, err = tx.ExecContext(spanCtx, moveQuery, id, targetID, date, change.Title, change.Deadline, change.TotalSeats)
+		endSpan(span, err)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update event: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit series: %w", err)
+	}
+
+	return targetID, nil
+}
+
+// AddSeriesException skips the occurrence of the series starting at date
+// and cancels its event: paid bookings are refunded in full and their users
+// notified, as SetEventStatus does. Skipping an occurrence twice does
+// nothing.
+func (s *Storage) AddSeriesException(ctx context.Context, seriesID int, date time.Time) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sr, err := selectSeries(ctx, tx, "AddSeriesException", seriesID, true)
+	if err != nil {
+		return err
+	}
+
+	dates, err := sr.Occurrences(date, date.Add(time.Nanosecond))
+	if err != nil {
+		return fmt.Errorf("failed to get series occurrences: %w", err)
+	}
+
+	if len(dates) == 0 {
+		for _, d := range sr.Exceptions {
+			if d.Equal(date) {
+				return nil
+			}
+		}
+		return fmt.Errorf("date is not an occurrence of the series")
+	}
+
+	if err = insertSeriesException(ctx, tx, "AddSeriesException", seriesID, date); err != nil {
+		return err
+	}
+
+	eventQuery := `
+		SELECT id, title, date, status FROM events
+		WHERE series_id = $1 AND occurrence_date = $2
+		FOR UPDATE`
+
+	var (
+		eventID   int
+		title     string
+		eventDate time.Time
+		status    string
+	)
+	spanCtx, span := startSpan(ctx, "AddSeriesException.Event", eventQuery)
+	err = tx.QueryRowContext(spanCtx, eventQuery, seriesID, date).Scan(&eventID, &title, &eventDate, &status)
+	endSpan(span, err)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get occurrence: %w", err)
+	}
+
+	// The occurrence's event is kept, so its payments and refunds stay on
+	// record, and cancelled like any other event. An occurrence not created
+	// yet, or completed or cancelled already, is only excluded.
+	if err == nil && models.CanTransition(status, models.EventCancelled) {
+		cancelQuery := `
+			UPDATE events
+			SET status = 'cancelled'
+			WHERE id = $1`
+
+		spanCtx, span = startSpan(ctx, "AddSeriesException.CancelEvent", cancelQuery)
+		_, err = tx.ExecContext(spanCtx, cancelQuery, eventID)
+		endSpan(span, err)
+		if err != nil {
+			return fmt.Errorf("failed to cancel occurrence: %w", err)
+		}
+
+		if _, err = cancelEventBookings(ctx, tx, eventID, models.EventCancelledMessage(title, eventDate)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// BookSeries creates a pending booking for each upcoming occurrence of the
+// series and returns the ids of the events booked, in order of date. Either
+// every occurrence is booked or none is, so a single full or already booked
+// occurrence fails the whole series.
+func (s *Storage) BookSeries(ctx context.Context, seriesID int, userID string) ([]int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	existsQuery := `
+		SELECT EXISTS(SELECT 1 FROM event_series WHERE id = $1)`
+
+	var exists bool
+	spanCtx, span := startSpan(ctx, "BookSeries.Exists", existsQuery)
+	err = tx.QueryRowContext(spanCtx, existsQuery, seriesID).Scan(&exists)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("series not found")
+	}
+
+	// Locking the occurrences keeps their seat counts from changing until
+	// every booking is made. Occurrences not published, e.g. cancelled
+	// ones, are left out. A series booking has no ticket type or seat, so
+	// occurrences that require one are rejected before any is booked.
+	query := `
+		SELECT e.id,
+		       e.layout_id IS NOT NULL OR EXISTS(SELECT 1 FROM ticket_types t WHERE t.event_id = e.id)
+		FROM events e
+		WHERE e.series_id = $1 AND e.date > NOW() AND e.status = 'published'
+		ORDER BY e.date, e.id
+		FOR UPDATE OF e`
+
+	spanCtx, span = startSpan(ctx, "BookSeries.Events", query)
+	rows, err := tx.QueryContext(spanCtx, query, seriesID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series events: %w", err)
+	}
+
+	ids := make([]int, 0)
+	perOccurrence := false
+	for rows.Next() {
+		var (
+			id       int
+			required bool
+		)
+		if err = rows.Scan(&id, &required); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		ids = append(ids, id)
+		perOccurrence = perOccurrence || required
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read series events: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("series has no upcoming occurrences")
+	}
+	if perOccurrence {
+		return nil, fmt.Errorf("series must be booked by occurrence")
+	}
+
+	for _, id := range ids {
+		if _, err = insertBooking(ctx, tx, "BookSeries", id, userID, 0, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit series bookings: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ExtendSeries creates the occurrences of all series up to horizon and
+// returns the number of events created. Each series is extended in its own
+// transaction.
+func (s *Storage) ExtendSeries(ctx context.Context, horizon time.Time) (int, error) {
+	query := `
+		SELECT id FROM event_series
+		WHERE generated_until < $1 AND (ends_at IS NULL OR generated_until < ends_at)
+		ORDER BY id`
+
+	spanCtx, span := startSpan(ctx, "ExtendSeries", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, horizon)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan series: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read series: %w", err)
+	}
+
+	created := 0
+	for _, id := range ids {
+		n, err := s.extendSeries(ctx, id, horizon)
+		if err != nil {
+			return created, err
+		}
+		created += n
+	}
+
+	return created, nil
+}
+
+// extendSeries creates the occurrences of the series up to horizon and
+// returns the number of events created.
+func (s *Storage) extendSeries(ctx context.Context, id int, horizon time.Time) (int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sr, err := selectSeries(ctx, tx, "ExtendSeries", id, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Extended concurrently meanwhile.
+	if !sr.GeneratedUntil.Before(horizon) {
+		return 0, nil
+	}
+
+	var layoutID *int
+	if sr.VenueID != nil {
+		_, layoutID, err = venueSeats(ctx, tx, "ExtendSeries", *sr.VenueID, sr.TotalSeats)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	ids, err := createOccurrences(ctx, tx, "ExtendSeries", sr, layoutID, sr.GeneratedUntil, horizon)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		UPDATE event_series SET generated_until = $2 WHERE id = $1`
+
+	spanCtx, span := startSpan(ctx, "ExtendSeries.Update", query)
+	_, err = tx.ExecContext(spanCtx, query, id, horizon)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update series: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit series: %w", err)
+	}
+
+	return len(ids), nil
+}
+
+// selectSeries returns the series with its exceptions, locked until tx ends
+// if forUpdate is set. span prefixes the span names.
+func selectSeries(ctx context.Context, tx *sql.Tx, span string, id int, forUpdate bool) (*models.Series, error) {
+	query := `
+		SELECT` + seriesColumns + `
+		FROM event_series
+		WHERE id = $1`
+	if forUpdate {
+		query += `
+		FOR UPDATE`
+	}
+
+	var sr models.Series
+	spanCtx, sp := startSpan(ctx, span+".Series", query)
+	err := tx.QueryRowContext(spanCtx, query, id).Scan(seriesFields(&sr)...)
+	endSpan(sp, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("series not found")
+		}
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	exceptionsQuery := `
+		SELECT date FROM series_exceptions
+		WHERE series_id = $1
+		ORDER BY date`
+
+	spanCtx, sp = startSpan(ctx, span+".Exceptions", exceptionsQuery)
+	rows, err := tx.QueryContext(spanCtx, exceptionsQuery, id)
+	endSpan(sp, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series exceptions: %w", err)
+	}
+	defer rows.Close()
+
+	sr.Exceptions = make([]time.Time, 0)
+	for rows.Next() {
+		var date time.Time
+		if err = rows.Scan(&date); err != nil {
+			return nil, fmt.Errorf("failed to scan series exception: %w", err)
+		}
+		sr.Exceptions = append(sr.Exceptions, date.UTC())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read series exceptions: %w", err)
+	}
+
+	sr.Start = sr.Start.UTC()
+
+	return &sr, nil
+}
+
+// insertSeriesException skips the occurrence of the series starting at date.
+// span prefixes the span names.
+func insertSeriesException(ctx context.Context, tx *sql.Tx, span string, seriesID int, date time.Time) error {
+	query := `
+		INSERT INTO series_exceptions (series_id, date)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+
+	spanCtx, sp := startSpan(ctx, span+".Exception", query)
+	_, err := tx.ExecContext(spanCtx, query, seriesID, date)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to create series exception: %w", err)
+	}
+
+	return nil
+}
+
+// createOccurrences creates the events of the series' occurrences in
+// [from, to) that do not exist yet and returns their ids. They get the seat
+// layout layoutID unless it is nil. span prefixes the span names.
+func createOccurrences(ctx context.Context, tx *sql.Tx, span string, sr *models.Series, layoutID *int, from, to time.Time) ([]int, error) {
+	dates, err := sr.Occurrences(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series occurrences: %w", err)
+	}
+
+	query := `
+		INSERT INTO events (title, date, total_seats, deadline_minutes, venue_id, timezone, series_id, occurrence_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $2)
+		ON CONFLICT (series_id, occurrence_date) DO NOTHING
+		RETURNING id`
+
+	ids := make([]int, 0, len(dates))
+	for _, date := range dates {
+		var id int
+		spanCtx, sp := startSpan(ctx, span+".Occurrence", query)
+		err = tx.QueryRowContext(spanCtx, query,
+			sr.Title, date, sr.TotalSeats, sr.Deadline, sr.VenueID, sr.Timezone, sr.ID,
+		).Scan(&id)
+		endSpan(sp, err)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create occurrence: %w", err)
+		}
+
+		if layoutID != nil {
+			if err = assignLayout(ctx, tx, span, id, *layoutID); err != nil {
+				return nil, err
+			}
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// seriesFields returns the scan destinations of seriesColumns.
+func seriesFields(sr *models.Series) []any {
+	return []any{
+		&sr.ID,
+		&sr.Title,
+		&sr.Start,
+		&sr.Rule,
+		&sr.TotalSeats,
+		&sr.Deadline,
+		&sr.VenueID,
+		&sr.Timezone,
+		&sr.EndsAt,
+		&sr.GeneratedUntil,
+		&sr.CreatedAt,
+	}
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+	"time"
+)
+
+// CreateTicketType adds a ticket type to the event and returns its id.
+// Names are unique per event.
+func (s *Storage) CreateTicketType(ctx context.Context, t models.TicketType) (int, error) {
+	query := `
+		INSERT INTO ticket_types (event_id, name, price, currency, capacity, sales_start, sales_end)
+		SELECT id, $2, $3, $4, $5, $6, $7
+		FROM events
+		WHERE id = $1
+		ON CONFLICT (event_id, name) DO NOTHING
+		RETURNING id`
+
+	var id int
+	spanCtx, span := startSpan(ctx, "CreateTicketType", query)
+	err := s.DB.QueryRowContext(spanCtx, query,
+		t.EventID, t.Name, t.Price, t.Currency, t.Capacity, t.SalesStart, t.SalesEnd,
+	).Scan(&id)
+	endSpan(span, err)
+	if err == nil {
+		return id, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to create ticket type: %w", err)
+	}
+
+	existsQuery := `
+		SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)`
+
+	var exists bool
+	spanCtx, span = startSpan(ctx, "CreateTicketType.EventExists", existsQuery)
+	err = s.DB.QueryRowContext(spanCtx, existsQuery, t.EventID).Scan(&exists)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !exists {
+		return 0, fmt.Errorf("event not found")
+	}
+
+	return 0, fmt.Errorf("ticket type already exists")
+}
+
+// GetTicketTypes returns the ticket types of the event, cheapest first, with
+// their confirmed bookings counted. An event without ticket types has none.
+func (s *Storage) GetTicketTypes(ctx context.Context, eventID int) ([]models.TicketType, error) {
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT t.id, t.event_id, t.name, t.price, t.currency, t.capacity, t.sales_start, t.sales_end,
+		       (SELECT COUNT(*) FROM bookings b WHERE b.ticket_type_id = t.id AND b.confirmed = true)
+		FROM ticket_types t
+		WHERE t.event_id = $1
+		ORDER BY t.price, t.id`
+
+	spanCtx, span := startSpan(ctx, "GetTicketTypes", query)
+	rows, err := s.DB.QueryContext(spanCtx, query, eventID)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket types: %w", err)
+	}
+	defer rows.Close()
+
+	ticketTypes := make([]models.TicketType, 0)
+	for rows.Next() {
+		var t models.TicketType
+		err = rows.Scan(
+			&t.ID,
+			&t.EventID,
+			&t.Name,
+			&t.Price,
+			&t.Currency,
+			&t.Capacity,
+			&t.SalesStart,
+			&t.SalesEnd,
+			&t.BookedSeats,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket type: %w", err)
+		}
+
+		t.RemainingSeats = t.Remaining(event.TotalSeats - event.BookedSeats)
+		ticketTypes = append(ticketTypes, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ticket types: %w", err)
+	}
+
+	return ticketTypes, nil
+}
+
+// checkTicketType fails unless ticketTypeID names a ticket type of the event
+// with a seat left, or is 0 for an event without ticket types. With
+// checkSales the ticket type must also be on sale. The ticket type's row is
+// locked until tx ends, so concurrent confirmations are counted one after
+// another and never exceed its capacity. span prefixes the span names.
+func checkTicketType(ctx context.Context, tx *sql.Tx, span string, eventID, ticketTypeID int, checkSales bool) error {
+	if ticketTypeID == 0 {
+		query := `
+			SELECT EXISTS(SELECT 1 FROM ticket_types WHERE event_id = $1)`
+
+		var hasTypes bool
+		spanCtx, sp := startSpan(ctx, span+".HasTicketTypes", query)
+		err := tx.QueryRowContext(spanCtx, query, eventID).Scan(&hasTypes)
+		endSpan(sp, err)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket types: %w", err)
+		}
+
+		if hasTypes {
+			return fmt.Errorf("ticket type is required")
+		}
+
+		return nil
+	}
+
+	query := `
+		SELECT capacity, sales_start, sales_end
+		FROM ticket_types
+		WHERE id = $1 AND event_id = $2
+		FOR UPDATE`
+
+	var t models.TicketType
+	spanCtx, sp := startSpan(ctx, span+".TicketType", query)
+	err := tx.QueryRowContext(spanCtx, query, ticketTypeID, eventID).Scan(
+		&t.Capacity,
+		&t.SalesStart,
+		&t.SalesEnd,
+	)
+	endSpan(sp, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("ticket type not found")
+		}
+		return fmt.Errorf("failed to get ticket type: %w", err)
+	}
+
+	// Counted after the lock is taken, so it sees the confirmations
+	// committed by whoever held it before.
+	countQuery := `
+		SELECT COUNT(*) FROM bookings
+		WHERE ticket_type_id = $1 AND confirmed = true`
+
+	spanCtx, sp = startSpan(ctx, span+".CountTicketTypeSeats", countQuery)
+	err = tx.QueryRowContext(spanCtx, countQuery, ticketTypeID).Scan(&t.BookedSeats)
+	endSpan(sp, err)
+	if err != nil {
+		return fmt.Errorf("failed to get booked seats count: %w", err)
+	}
+
+	if checkSales && !t.OnSale(time.Now()) {
+		return fmt.Errorf("ticket type is not on sale")
+	}
+
+	if t.BookedSeats >= t.Capacity {
+		return fmt.Errorf("no available seats")
+	}
+
+	return nil
+}
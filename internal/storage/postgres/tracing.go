@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "eventBooker/internal/storage/postgres"
+
+var (
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteral = regexp.MustCompile(`(^|[^$\w])\d+(?:\.\d+)?\b`)
+)
+
+// startSpan starts a child span for a single SQL statement. Arguments are never
+// recorded and literals are masked, so the statement is safe to export.
+func startSpan(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "postgres."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBQueryText(sanitizeSQL(query)),
+			attribute.String("db.operation.name", name),
+		),
+	)
+}
+
+// endSpan records err on span, if any, and ends it. sql.ErrNoRows is an
+// expected outcome and does not mark the span as failed.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func sanitizeSQL(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+	query = numericLiteral.ReplaceAllString(query, "${1}?")
+
+	return strings.Join(strings.Fields(query), " ")
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeSQL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name: "Placeholders are kept",
+			query: `
+				SELECT id FROM bookings
+				WHERE event_id = $1 AND user_id = $2`,
+			expected: "SELECT id FROM bookings WHERE event_id = $1 AND user_id = $2",
+		},
+		{
+			name:     "String literals are masked",
+			query:    `SELECT * FROM users WHERE name = 'O''Brien' AND role = 'admin'`,
+			expected: "SELECT * FROM users WHERE name = ? AND role = ?",
+		},
+		{
+			name:     "Numeric literals are masked",
+			query:    `DELETE FROM bookings WHERE id = 42 AND created_at < NOW() - INTERVAL '1 minute' * 1.5`,
+			expected: "DELETE FROM bookings WHERE id = ? AND created_at < NOW() - INTERVAL ? * ?",
+		},
+		{
+			name:     "Identifiers with digits are kept",
+			query:    `SELECT col1 FROM t2`,
+			expected: "SELECT col1 FROM t2",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, sanitizeSQL(tc.query))
+		})
+	}
+}
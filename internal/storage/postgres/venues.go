@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"eventBooker/internal/models"
+	"fmt"
+)
+
+const venueColumns = `
+	id, name, address, timezone, capacity, layout_id, created_at`
+
+// CreateVenue creates a venue and returns its id. The seats of the venue's
+// layout must fit into its capacity.
+func (s *Storage) CreateVenue(ctx context.Context, v models.Venue) (int, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkVenueLayout(ctx, tx, "CreateVenue", v); err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO venues (name, address, timezone, capacity, layout_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	var id int
+	spanCtx, span := startSpan(ctx, "CreateVenue", query)
+	err = tx.QueryRowContext(spanCtx, query, v.Name, v.Address, v.Timezone, v.Capacity, v.LayoutID).Scan(&id)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create venue: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit venue: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetVenue(ctx context.Context, id int) (*models.Venue, error) {
+	query := `
+		SELECT` + venueColumns + `
+		FROM venues
+		WHERE id = $1`
+
+	var v models.Venue
+	spanCtx, span := startSpan(ctx, "GetVenue", query)
+	err := s.DB.QueryRowContext(spanCtx, query, id).Scan(venueFields(&v)...)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("venue not found")
+		}
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	return &v, nil
+}
+
+// GetVenues returns all venues ordered by name.
+func (s *Storage) GetVenues(ctx context.Context) ([]models.Venue, error) {
+	query := `
+		SELECT` + venueColumns + `
+		FROM venues
+		ORDER BY name, id`
+
+	spanCtx, span := startSpan(ctx, "GetVenues", query)
+	rows, err := s.DB.QueryContext(spanCtx, query)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venues: %w", err)
+	}
+	defer rows.Close()
+
+	venues := make([]models.Venue, 0)
+	for rows.Next() {
+		var v models.Venue
+		if err = rows.Scan(venueFields(&v)...); err != nil {
+			return nil, fmt.Errorf("failed to scan venue: %w", err)
+		}
+		venues = append(venues, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read venues: %w", err)
+	}
+
+	return venues, nil
+}
+
+// UpdateVenue updates the venue. Its capacity may not drop below the seats
+// of an event held at it. A new layout applies to events created afterwards
+// only.
+func (s *Storage) UpdateVenue(ctx context.Context, v models.Venue) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the venue waits for events being created at it, see venueSeats.
+	// Series count too, their future occurrences are created at the venue.
+	lockQuery := `
+		SELECT GREATEST(
+			COALESCE((SELECT MAX(total_seats) FROM events WHERE venue_id = v.id), 0),
+			COALESCE((SELECT MAX(total_seats) FROM event_series WHERE venue_id = v.id), 0))
+		FROM venues v
+		WHERE v.id = $1
+		FOR UPDATE`
+
+	var maxSeats int
+	spanCtx, span := startSpan(ctx, "UpdateVenue.Venue", lockQuery)
+	err = tx.QueryRowContext(spanCtx, lockQuery, v.ID).Scan(&maxSeats)
+	endSpan(span, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("venue not found")
+		}
+		return fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	if v.Capacity < maxSeats {
+		return fmt.Errorf("venue capacity below event seats")
+	}
+
+	if err = checkVenueLayout(ctx, tx, "UpdateVenue", v); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE venues
+		SET name = $2, address = $3, timezone = $4, capacity = $5, layout_id = $6
+		WHERE id = $1`
+
+	spanCtx, span = startSpan(ctx, "UpdateVenue", query)
+	_, err = tx.ExecContext(spanCtx, query, v.ID, v.Name, v.Address, v.Timezone, v.Capacity, v.LayoutID)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to update venue: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteVenue deletes the venue. Venues events or series are held at
+// cannot be deleted.
+func (s *Storage) DeleteVenue(ctx context.Context, id int) error {
+	query := `
+		DELETE FROM venues
+		WHERE id = $1
+		  AND NOT EXISTS(SELECT 1 FROM events WHERE venue_id = $1)
+		  AND NOT EXISTS(SELECT 1 FROM event_series WHERE venue_id = $1)`
+
+	spanCtx, span := startSpan(ctx, "DeleteVenue", query)
+	result, err := s.DB.ExecContext(spanCtx, query, id)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to delete venue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get deleted venues count: %w", err)
+	}
+
+	if rowsAffected == 1 {
+		return nil
+	}
+
+	existsQuery := `
+		SELECT EXISTS(SELECT 1 FROM venues WHERE id = $1)`
+
+	var exists bool
+	spanCtx, span = startSpan(ctx, "DeleteVenue.Exists", existsQuery)
+	err = s.DB.QueryRowContext(spanCtx, existsQuery, id).Scan(&exists)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("venue not found")
+	}
+
+	return fmt.Errorf("venue has events")
+}
+
+// checkVenueLayout fails unless the venue has no layout or one whose seats
+// fit into its capacity. span prefixes the span names.
+func checkVenueLayout(ctx context.Context, tx *sql.Tx, span string, v models.Venue) error {
+	if v.LayoutID == nil {
+		return nil
+	}
+
+	query := `
+		SELECT (SELECT COUNT(*) FROM layout_seats WHERE layout_id = l.id)
+		FROM seat_layouts l
+		WHERE l.id = $1`
+
+	var seats int
+	spanCtx, sp := startSpan(ctx, span+".Layout", query)
+	err := tx.QueryRowContext(spanCtx, query, *v.LayoutID).Scan(&seats)
+	endSpan(sp, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("seat layout not found")
+		}
+		return fmt.Errorf("failed to get seat layout: %w", err)
+	}
+
+	if seats > v.Capacity {
+		return fmt.Errorf("seat layout exceeds venue capacity")
+	}
+
+	return nil
+}
+
+// venueSeats returns the seats of a new event at the venue and the layout
+// it gets, nil for none. totalSeats 0 defaults to the venue's capacity.
+// The venue is locked until tx ends so its capacity cannot change meanwhile.
+// span prefixes the span names.
+func venueSeats(ctx context.Context, tx *sql.Tx, span string, venueID, totalSeats int) (int, *int, error) {
+	query := `
+		SELECT capacity, layout_id FROM venues WHERE id = $1 FOR SHARE`
+
+	var (
+		capacity int
+		layoutID *int
+	)
+	spanCtx, sp := startSpan(ctx, span+".Venue", query)
+	err := tx.QueryRowContext(spanCtx, query, venueID).Scan(&capacity, &layoutID)
+	endSpan(sp, err)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil, fmt.Errorf("venue not found")
+		}
+		return 0, nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	if totalSeats == 0 {
+		totalSeats = capacity
+	}
+
+	if totalSeats > capacity {
+		return 0, nil, fmt.Errorf("event exceeds venue capacity")
+	}
+
+	return totalSeats, layoutID, nil
+}
+
+// venueFields returns the scan destinations of venueColumns.
+func venueFields(v *models.Venue) []any {
+	return []any{
+		&v.ID,
+		&v.Name,
+		&v.Address,
+		&v.Timezone,
+		&v.Capacity,
+		&v.LayoutID,
+		&v.CreatedAt,
+	}
+}
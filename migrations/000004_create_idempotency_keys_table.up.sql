@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    key          TEXT PRIMARY KEY,
+    request_hash TEXT                     NOT NULL,
+    status_code  INTEGER,
+    response     BYTEA,
+    completed    BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+    expires_at   TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
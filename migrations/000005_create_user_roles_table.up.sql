@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS user_roles
+(
+    user_id    TEXT NOT NULL,
+    role       TEXT NOT NULL,
+    granted_at TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+
+    PRIMARY KEY (user_id, role)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS checked_in_at;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
\ No newline at end of file
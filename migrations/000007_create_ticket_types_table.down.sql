@@ -0,0 +1,4 @@
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS ticket_type_id;
+
+DROP TABLE IF EXISTS ticket_types;
\ No newline at end of file
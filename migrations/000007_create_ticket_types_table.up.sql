@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS ticket_types
+(
+    id          SERIAL PRIMARY KEY,
+    event_id    INTEGER NOT NULL,
+    name        TEXT    NOT NULL,
+    price       BIGINT  NOT NULL CHECK (price >= 0),
+    currency    CHAR(3) NOT NULL,
+    capacity    INTEGER NOT NULL CHECK (capacity > 0),
+    sales_start TIMESTAMP WITH TIME ZONE,
+    sales_end   TIMESTAMP WITH TIME ZONE,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+
+    CONSTRAINT fk_event
+        FOREIGN KEY (event_id)
+            REFERENCES events (id)
+            ON DELETE CASCADE,
+    CONSTRAINT chk_sales_window
+        CHECK (sales_start IS NULL OR sales_end IS NULL OR sales_start < sales_end)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_ticket_type_name_per_event
+    ON ticket_types (event_id, name);
+
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS ticket_type_id INTEGER REFERENCES ticket_types (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_ticket_type_id ON bookings (ticket_type_id);
\ No newline at end of file
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS payments
+(
+    id                  SERIAL PRIMARY KEY,
+    booking_id          INTEGER,
+    event_id            INTEGER NOT NULL,
+    user_id             TEXT    NOT NULL,
+    provider            TEXT    NOT NULL,
+    provider_payment_id TEXT,
+    amount              BIGINT  NOT NULL CHECK (amount > 0),
+    currency            CHAR(3) NOT NULL,
+    status              TEXT    NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'succeeded', 'failed', 'refunded')),
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+
+    -- Bookings are deleted on cancellation and expiry, payments are kept.
+    CONSTRAINT fk_booking
+        FOREIGN KEY (booking_id)
+            REFERENCES bookings (id)
+            ON DELETE SET NULL,
+    CONSTRAINT fk_event
+        FOREIGN KEY (event_id)
+            REFERENCES events (id)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_provider_payment_id
+    ON payments (provider, provider_payment_id);
+
+CREATE INDEX IF NOT EXISTS idx_payments_booking_id ON payments (booking_id);
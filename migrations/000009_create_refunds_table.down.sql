@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS refunds;
+
+DROP TABLE IF EXISTS cancellation_policies;
\ No newline at end of file
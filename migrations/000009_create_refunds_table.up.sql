@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS cancellation_policies
+(
+    event_id               INTEGER PRIMARY KEY,
+    full_refund_hours      INTEGER NOT NULL CHECK (full_refund_hours >= 0),
+    partial_refund_percent INTEGER NOT NULL CHECK (partial_refund_percent BETWEEN 0 AND 100),
+    cutoff_hours           INTEGER NOT NULL CHECK (cutoff_hours >= 0),
+    updated_at             TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+
+    CONSTRAINT fk_event
+        FOREIGN KEY (event_id)
+            REFERENCES events (id)
+            ON DELETE CASCADE,
+    CONSTRAINT chk_cutoff_before_full_refund
+        CHECK (cutoff_hours <= full_refund_hours)
+);
+
+CREATE TABLE IF NOT EXISTS refunds
+(
+    id         SERIAL PRIMARY KEY,
+    payment_id INTEGER NOT NULL,
+    -- Not a foreign key: cancelled bookings are deleted, their refunds are kept.
+    booking_id INTEGER,
+    event_id   INTEGER NOT NULL,
+    user_id    TEXT    NOT NULL,
+    amount     BIGINT  NOT NULL CHECK (amount > 0),
+    currency   CHAR(3) NOT NULL,
+    reason     TEXT    NOT NULL CHECK (reason IN ('cancellation', 'unconfirmable', 'override')),
+    note       TEXT    NOT NULL DEFAULT '',
+    created_by TEXT    NOT NULL DEFAULT '',
+    status     TEXT    NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'succeeded', 'failed')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+
+    CONSTRAINT fk_payment
+        FOREIGN KEY (payment_id)
+            REFERENCES payments (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds (payment_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_event_user ON refunds (event_id, user_id);
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS promo_codes
+(
+    id                SERIAL PRIMARY KEY,
+    code              TEXT    NOT NULL,
+    kind              TEXT    NOT NULL CHECK (kind IN ('percent', 'fixed')),
+    value             BIGINT  NOT NULL CHECK (value > 0),
+    currency          CHAR(3),
+    max_uses          INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    max_uses_per_user INTEGER NOT NULL DEFAULT 0 CHECK (max_uses_per_user >= 0),
+    valid_from        TIMESTAMP WITH TIME ZONE,
+    valid_until       TIMESTAMP WITH TIME ZONE,
+    event_id          INTEGER REFERENCES events (id) ON DELETE CASCADE,
+    ticket_type_id    INTEGER REFERENCES ticket_types (id) ON DELETE CASCADE,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL,
+
+    CONSTRAINT chk_percent
+        CHECK (kind <> 'percent' OR value <= 100),
+    CONSTRAINT chk_fixed_currency
+        CHECK (kind <> 'fixed' OR currency IS NOT NULL),
+    CONSTRAINT chk_validity_window
+        CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_from < valid_until)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_promo_code ON promo_codes (code);
+
+-- A redemption lives as long as its booking, so cancelled and expired
+-- bookings give their use back.
+CREATE TABLE IF NOT EXISTS promo_redemptions
+(
+    id            SERIAL PRIMARY KEY,
+    promo_code_id INTEGER NOT NULL REFERENCES promo_codes (id) ON DELETE CASCADE,
+    booking_id    INTEGER NOT NULL UNIQUE REFERENCES bookings (id) ON DELETE CASCADE,
+    user_id       TEXT    NOT NULL,
+    discount      BIGINT  NOT NULL CHECK (discount >= 0),
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_code_user ON promo_redemptions (promo_code_id, user_id);
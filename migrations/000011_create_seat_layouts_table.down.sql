@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS event_seats;
+
+ALTER TABLE events
+    DROP COLUMN IF EXISTS layout_id;
+
+DROP TABLE IF EXISTS layout_seats;
+
+DROP TABLE IF EXISTS seat_layouts;
\ No newline at end of file
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS seat_layouts
+(
+    id         SERIAL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS layout_seats
+(
+    id        SERIAL PRIMARY KEY,
+    layout_id INTEGER NOT NULL REFERENCES seat_layouts (id) ON DELETE CASCADE,
+    section   TEXT    NOT NULL,
+    row_name  TEXT    NOT NULL,
+    number    INTEGER NOT NULL CHECK (number > 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_layout_seat
+    ON layout_seats (layout_id, section, row_name, number);
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS layout_id INTEGER REFERENCES seat_layouts (id);
+
+-- The seat inventory of an event with a layout, one row per seat. A seat
+-- is held by at most one booking and freed when the booking is cancelled
+-- or expires.
+CREATE TABLE IF NOT EXISTS event_seats
+(
+    event_id   INTEGER NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    seat_id    INTEGER NOT NULL REFERENCES layout_seats (id) ON DELETE CASCADE,
+    booking_id INTEGER UNIQUE REFERENCES bookings (id) ON DELETE SET NULL,
+
+    PRIMARY KEY (event_id, seat_id)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS venues
+(
+    id         SERIAL PRIMARY KEY,
+    name       TEXT    NOT NULL,
+    address    TEXT    NOT NULL DEFAULT '',
+    timezone   TEXT    NOT NULL DEFAULT 'UTC',
+    capacity   INTEGER NOT NULL CHECK (capacity > 0),
+    layout_id  INTEGER REFERENCES seat_layouts (id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL
+);
+
+-- A venue cannot be deleted while events are held at it.
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS venue_id INTEGER REFERENCES venues (id);
+
+CREATE INDEX IF NOT EXISTS idx_events_venue_id ON events (venue_id);
@@ -0,0 +1,3 @@
+-- NULL takes the time zone of the event's venue, or UTC without one.
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS timezone TEXT;
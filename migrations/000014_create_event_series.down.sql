@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_events_series_occurrence;
+
+ALTER TABLE events
+    DROP COLUMN IF EXISTS occurrence_date,
+    DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS series_exceptions;
+
+DROP TABLE IF EXISTS event_series;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS event_series
+(
+    id               SERIAL PRIMARY KEY,
+    title            TEXT                     NOT NULL,
+    start_date       TIMESTAMP WITH TIME ZONE NOT NULL,
+    rrule            TEXT                     NOT NULL,
+    total_seats      INTEGER                  NOT NULL CHECK (total_seats > 0),
+    deadline_minutes INTEGER                  NOT NULL,
+    venue_id         INTEGER REFERENCES venues (id),
+    timezone         TEXT                     NOT NULL,
+    -- A series split by an edit of all future occurrences ends at the
+    -- occurrence it was split at, those from it on belong to the new series.
+    ends_at          TIMESTAMP WITH TIME ZONE,
+    -- Occurrences starting before generated_until have been created as events.
+    generated_until  TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL
+);
+
+-- Skipped occurrences, by their start in the recurrence.
+CREATE TABLE IF NOT EXISTS series_exceptions
+(
+    series_id INTEGER                  NOT NULL REFERENCES event_series (id) ON DELETE CASCADE,
+    date      TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (series_id, date)
+);
+
+-- occurrence_date is the start of an occurrence in the recurrence. It stays
+-- when the occurrence alone is moved, so it is never created twice.
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS series_id INTEGER REFERENCES event_series (id),
+    ADD COLUMN IF NOT EXISTS occurrence_date TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_series_occurrence ON events (series_id, occurrence_date);
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS notifications;
+
+UPDATE refunds SET reason = 'cancellation' WHERE reason = 'event_cancelled';
+
+ALTER TABLE refunds
+    DROP CONSTRAINT IF EXISTS refunds_reason_check,
+    ADD CONSTRAINT refunds_reason_check
+        CHECK (reason IN ('cancellation', 'unconfirmable', 'override'));
+
+DROP INDEX IF EXISTS idx_events_status;
+
+ALTER TABLE events
+    DROP COLUMN IF EXISTS sales_close_at,
+    DROP COLUMN IF EXISTS sales_open_at,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,32 @@
+-- Existing events stay published. A draft is hidden from the public list
+-- until it is published, by hand or at publish_at. Bookings are only taken
+-- for published events between sales_open_at and sales_close_at, either
+-- of which may be NULL for no limit.
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'published', 'sales_closed', 'cancelled', 'completed')),
+    ADD COLUMN IF NOT EXISTS publish_at     TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS sales_open_at  TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS sales_close_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_events_status ON events (status);
+
+-- Refunds of the bookings of a cancelled event are in full, regardless of
+-- its cancellation policy.
+ALTER TABLE refunds
+    DROP CONSTRAINT IF EXISTS refunds_reason_check,
+    ADD CONSTRAINT refunds_reason_check
+        CHECK (reason IN ('cancellation', 'unconfirmable', 'override', 'event_cancelled'));
+
+-- Notifications are kept after their event is deleted, like refunds.
+CREATE TABLE IF NOT EXISTS notifications
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    TEXT    NOT NULL,
+    event_id   INTEGER NOT NULL,
+    kind       TEXT    NOT NULL CHECK (kind IN ('event_cancelled')),
+    message    TEXT    NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT TIMEZONE('utc', NOW()) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id);
@@ -0,0 +1,2 @@
+-- The sweeper resends the refunds left pending.
+CREATE INDEX IF NOT EXISTS idx_refunds_pending ON refunds (created_at) WHERE status = 'pending';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_refunds_sending;
+
+UPDATE refunds SET status = 'pending' WHERE status = 'sending';
+
+ALTER TABLE refunds
+    DROP CONSTRAINT IF EXISTS refunds_status_check,
+    ADD CONSTRAINT refunds_status_check
+        CHECK (status IN ('pending', 'succeeded', 'failed'));
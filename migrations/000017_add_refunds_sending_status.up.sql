@@ -0,0 +1,9 @@
+-- A refund is claimed as sending before it goes to the provider, so that
+-- a request and the sweepers of all replicas never send it twice. Sends
+-- interrupted by a crash are claimed again by the sweeper.
+ALTER TABLE refunds
+    DROP CONSTRAINT IF EXISTS refunds_status_check,
+    ADD CONSTRAINT refunds_status_check
+        CHECK (status IN ('pending', 'sending', 'succeeded', 'failed'));
+
+CREATE INDEX IF NOT EXISTS idx_refunds_sending ON refunds (updated_at) WHERE status = 'sending';
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatest(t *testing.T) {
+	t.Parallel()
+
+	latest, err := Latest()
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, latest, uint(4))
+}
@@ -0,0 +1,764 @@
+// Package client is a Go client for the Event Booker HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"eventBooker/internal/models"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiPrefix = "/api/v1"
+
+	idempotencyHeader = "Idempotency-Key"
+	apiKeyHeader      = "X-API-Key"
+
+	defaultRetries = 3
+	defaultBackoff = 200 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+type (
+	Event      = models.Event
+	Booking    = models.Booking
+	TicketType = models.TicketType
+	Pagination = response.Pagination
+
+	CancellationPolicy = models.CancellationPolicy
+	Refund             = models.Refund
+	PromoCode          = models.PromoCode
+	SeatLayout         = models.SeatLayout
+	Seat               = models.Seat
+	Venue              = models.Venue
+	Series             = models.Series
+	Notification       = models.Notification
+)
+
+// Scopes of UpdateSeriesEvent.
+const (
+	ScopeThis   = models.ScopeThis
+	ScopeFuture = models.ScopeFuture
+)
+
+// Statuses of an event, see SetEventStatus.
+const (
+	EventDraft       = models.EventDraft
+	EventPublished   = models.EventPublished
+	EventSalesClosed = models.EventSalesClosed
+	EventCancelled   = models.EventCancelled
+	EventCompleted   = models.EventCompleted
+)
+
+// EventInput describes an event to create. Deadline is how many minutes
+// a booking may stay unconfirmed. Events at a venue may leave TotalSeats
+// zero to get the venue's capacity. Events are published right away unless
+// Status is EventDraft or PublishAt is set; nil sales bounds leave that
+// side open.
+type EventInput struct {
+	Title      string    `json:"title"`
+	Date       time.Time `json:"date"`
+	TotalSeats int       `json:"total_seats,omitempty"`
+	Deadline   int       `json:"deadline"`
+	VenueID    int       `json:"venue_id,omitempty"`
+	// Timezone is an IANA time zone name, the venue's or UTC if empty.
+	Timezone string `json:"timezone,omitempty"`
+
+	Status       string     `json:"status,omitempty"`
+	PublishAt    *time.Time `json:"publish_at,omitempty"`
+	SalesOpenAt  *time.Time `json:"sales_open_at,omitempty"`
+	SalesCloseAt *time.Time `json:"sales_close_at,omitempty"`
+}
+
+// TicketTypeInput describes a ticket type to add to an event. Price is in
+// the minor units of Currency; nil sales bounds leave that side open.
+type TicketTypeInput struct {
+	Name       string     `json:"name"`
+	Price      int64      `json:"price"`
+	Currency   string     `json:"currency"`
+	Capacity   int        `json:"capacity"`
+	SalesStart *time.Time `json:"sales_start,omitempty"`
+	SalesEnd   *time.Time `json:"sales_end,omitempty"`
+}
+
+// PromoCodeInput describes a promo code to create. Kind is "percent" or
+// "fixed"; Value is a percentage or an amount in the minor units of
+// Currency. Zero limits and ids and nil bounds leave the code unrestricted.
+type PromoCodeInput struct {
+	Code           string     `json:"code"`
+	Kind           string     `json:"kind"`
+	Value          int64      `json:"value"`
+	Currency       string     `json:"currency,omitempty"`
+	MaxUses        int        `json:"max_uses,omitempty"`
+	MaxUsesPerUser int        `json:"max_uses_per_user,omitempty"`
+	ValidFrom      *time.Time `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time `json:"valid_until,omitempty"`
+	EventID        int        `json:"event_id,omitempty"`
+	TicketTypeID   int        `json:"ticket_type_id,omitempty"`
+}
+
+// SeatLayoutInput describes a seat layout to create.
+type SeatLayoutInput struct {
+	Name     string             `json:"name"`
+	Sections []SeatSectionInput `json:"sections"`
+}
+
+type SeatSectionInput struct {
+	Name string         `json:"name"`
+	Rows []SeatRowInput `json:"rows"`
+}
+
+// SeatRowInput is a row of Seats seats numbered from 1.
+type SeatRowInput struct {
+	Name  string `json:"name"`
+	Seats int    `json:"seats"`
+}
+
+// VenueInput describes a venue to create or update. An empty Timezone
+// is UTC and a zero LayoutID leaves the venue without a seat layout.
+type VenueInput struct {
+	Name     string `json:"name"`
+	Address  string `json:"address,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	Capacity int    `json:"capacity"`
+	LayoutID int    `json:"layout_id,omitempty"`
+}
+
+// SeriesInput describes a recurring event series to create. Rule is an
+// RFC 5545 RRULE, e.g. "FREQ=WEEKLY;BYDAY=TU;COUNT=10", and Start the first
+// occurrence. Series at a venue may leave TotalSeats zero like events.
+type SeriesInput struct {
+	Title      string    `json:"title"`
+	Start      time.Time `json:"start"`
+	Rule       string    `json:"rrule"`
+	TotalSeats int       `json:"total_seats,omitempty"`
+	Deadline   int       `json:"deadline"`
+	VenueID    int       `json:"venue_id,omitempty"`
+	Timezone   string    `json:"timezone,omitempty"`
+	// Exceptions are the starts of occurrences to skip.
+	Exceptions []time.Time `json:"exceptions,omitempty"`
+}
+
+// SeriesEventInput is the edit of an occurrence of a series.
+type SeriesEventInput struct {
+	Title      string    `json:"title"`
+	Date       time.Time `json:"date"`
+	TotalSeats int       `json:"total_seats"`
+	Deadline   int       `json:"deadline"`
+}
+
+// Checkout is a started payment of a booking. The user pays on CheckoutURL,
+// and the booking is confirmed once the payment succeeds. Amount is in the
+// minor units of Currency.
+type Checkout struct {
+	PaymentID   int    `json:"payment_id"`
+	CheckoutURL string `json:"checkout_url"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+}
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+	retries    int
+	backoff    time.Duration
+}
+
+type Option func(c *Client)
+
+// WithHTTPClient sets the client used to send requests. http.DefaultClient is used by default.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithAPIKey sends key in the X-API-Key header of every request.
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithRetries sets how many times a request failed with a 5xx status or
+// a network error is retried. The delay starts at backoff and doubles each attempt.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client for the API served at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/") + apiPrefix,
+		httpClient: http.DefaultClient,
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// CreateEvent creates an event and returns its id.
+func (c *Client) CreateEvent(ctx context.Context, in EventInput) (int, error) {
+	var resp struct {
+		EventID int `json:"event_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/events", in, &resp, nil); err != nil {
+		return 0, err
+	}
+
+	return resp.EventID, nil
+}
+
+// ListEvents returns a page of events ordered by date. A zero limit uses the server default.
+func (c *Client) ListEvents(ctx context.Context, limit, offset int) ([]Event, Pagination, error) {
+	return c.listEvents(ctx, url.Values{}, limit, offset)
+}
+
+// ListAllEvents is ListEvents with drafts. The client's API key must
+// authenticate a user with the admin role, see WithAPIKey.
+func (c *Client) ListAllEvents(ctx context.Context, limit, offset int) ([]Event, Pagination, error) {
+	return c.listEvents(ctx, url.Values{"drafts": {"true"}}, limit, offset)
+}
+
+// ListVenueEvents returns a page of the events held at the venue, ordered by date.
+func (c *Client) ListVenueEvents(ctx context.Context, venueID, limit, offset int) ([]Event, Pagination, error) {
+	return c.listEvents(ctx, url.Values{"venue_id": {strconv.Itoa(venueID)}}, limit, offset)
+}
+
+// ListEventsOnDay returns a page of the events starting on day, ordered by
+// date. day is "today" or a date in the format 2006-01-02 and is taken in
+// the time zone of each event.
+func (c *Client) ListEventsOnDay(ctx context.Context, day string, limit, offset int) ([]Event, Pagination, error) {
+	return c.listEvents(ctx, url.Values{"day": {day}}, limit, offset)
+}
+
+func (c *Client) listEvents(ctx context.Context, q url.Values, limit, offset int) ([]Event, Pagination, error) {
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	}
+
+	path := "/events"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var (
+		resp struct {
+			Events []Event `json:"events"`
+		}
+		meta response.Meta
+	)
+	if err := c.do(ctx, http.MethodGet, path, nil, &resp, &meta); err != nil {
+		return nil, Pagination{}, err
+	}
+
+	var page Pagination
+	if meta.Pagination != nil {
+		page = *meta.Pagination
+	}
+
+	return resp.Events, page, nil
+}
+
+// GetEvent returns the event with its bookings, newest first.
+func (c *Client) GetEvent(ctx context.Context, eventID int) (*Event, []Booking, error) {
+	var resp struct {
+		Event    *Event    `json:"event"`
+		Bookings []Booking `json:"bookings"`
+	}
+	if err := c.do(ctx, http.MethodGet, eventPath(eventID, ""), nil, &resp, nil); err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Event, resp.Bookings, nil
+}
+
+// CreateTicketType adds a ticket type to the event and returns its id.
+func (c *Client) CreateTicketType(ctx context.Context, eventID int, in TicketTypeInput) (int, error) {
+	var resp struct {
+		TicketTypeID int `json:"ticket_type_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, eventPath(eventID, "/ticket-types"), in, &resp, nil); err != nil {
+		return 0, err
+	}
+
+	return resp.TicketTypeID, nil
+}
+
+// TicketTypes returns the ticket types of the event, cheapest first, with their remaining seats.
+func (c *Client) TicketTypes(ctx context.Context, eventID int) ([]TicketType, error) {
+	var resp struct {
+		TicketTypes []TicketType `json:"ticket_types"`
+	}
+	if err := c.do(ctx, http.MethodGet, eventPath(eventID, ""), nil, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return resp.TicketTypes, nil
+}
+
+// Book creates a pending booking of the event for the user. Events with
+// ticket types are booked with BookTicketType.
+func (c *Client) Book(ctx context.Context, eventID int, userID string) error {
+	return c.do(ctx, http.MethodPost, eventPath(eventID, "/book"), userRequest{UserID: userID}, nil, nil)
+}
+
+// BookTicketType creates a pending booking of a ticket type of the event for the user.
+func (c *Client) BookTicketType(ctx context.Context, eventID, ticketTypeID int, userID string) error {
+	return c.BookWithPromoCode(ctx, eventID, ticketTypeID, userID, "")
+}
+
+// BookWithPromoCode creates a pending booking of a ticket type of the event
+// for the user, discounted by the promo code. Codes that cannot be used for
+// the booking fail with ErrPromoCodeRejected, unknown ones with ErrNotFound.
+func (c *Client) BookWithPromoCode(ctx context.Context, eventID, ticketTypeID int, userID, promoCode string) error {
+	in := bookingRequest{UserID: userID, TicketTypeID: ticketTypeID, PromoCode: promoCode}
+
+	return c.do(ctx, http.MethodPost, eventPath(eventID, "/book"), in, nil, nil)
+}
+
+// BookSeat creates a pending booking of a seat of an event with reserved
+// seating for the user. A seat held by someone else fails with ErrSeatTaken.
+func (c *Client) BookSeat(ctx context.Context, eventID, seatID int, userID string) error {
+	in := bookingRequest{UserID: userID, SeatID: seatID}
+
+	return c.do(ctx, http.MethodPost, eventPath(eventID, "/book"), in, nil, nil)
+}
+
+// CreateSeatLayout creates a seat layout and returns its id.
+func (c *Client) CreateSeatLayout(ctx context.Context, in SeatLayoutInput) (int, error) {
+	var resp struct {
+		LayoutID int `json:"layout_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/layouts", in, &resp, nil); err != nil {
+		return 0, err
+	}
+
+	return resp.LayoutID, nil
+}
+
+// SeatLayout returns the seat layout with its seats.
+func (c *Client) SeatLayout(ctx context.Context, layoutID int) (*SeatLayout, error) {
+	var layout SeatLayout
+	if err := c.do(ctx, http.MethodGet, "/layouts/"+strconv.Itoa(layoutID), nil, &layout, nil); err != nil {
+		return nil, err
+	}
+
+	return &layout, nil
+}
+
+// SetEventLayout switches the event to reserved seating with the seats of
+// the layout. Events with bookings fail with ErrConflict.
+func (c *Client) SetEventLayout(ctx context.Context, eventID, layoutID int) error {
+	in := struct {
+		LayoutID int `json:"layout_id"`
+	}{LayoutID: layoutID}
+
+	return c.do(ctx, http.MethodPut, eventPath(eventID, "/layout"), in, nil, nil)
+}
+
+// SetEventStatus moves the event to status and returns how many bookings
+// were cancelled with it. Moves the lifecycle does not allow fail with
+// ErrConflict. The client's API key must authenticate a user with the admin
+// role, see WithAPIKey.
+func (c *Client) SetEventStatus(ctx context.Context, eventID int, status string) (int, error) {
+	in := struct {
+		Status string `json:"status"`
+	}{Status: status}
+
+	var resp struct {
+		CancelledBookings int `json:"cancelled_bookings"`
+	}
+	if err := c.do(ctx, http.MethodPut, eventPath(eventID, "/status"), in, &resp, nil); err != nil {
+		return 0, err
+	}
+
+	return resp.CancelledBookings, nil
+}
+
+// Notifications returns the notifications of the user, newest first.
+func (c *Client) Notifications(ctx context.Context, userID string) ([]Notification, error) {
+	var notifications []Notification
+	path := "/notifications?" + url.Values{"user_id": {userID}}.Encode()
+	if err := c.do(ctx, http.MethodGet, path, nil, &notifications, nil); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// SeatMap returns the seat layout of the event with the status of each seat.
+// General admission events fail with ErrNotFound.
+func (c *Client) SeatMap(ctx context.Context, eventID int) (*SeatLayout, error) {
+	var layout SeatLayout
+	if err := c.do(ctx, http.MethodGet, eventPath(eventID, "/seats"), nil, &layout, nil); err != nil {
+		return nil, err
+	}
+
+	return &layout, nil
+}
+
+// CreateVenue creates a venue and returns its id.
+func (c *Client) CreateVenue(ctx context.Context, in VenueInput) (int, error) {
+	var resp struct {
+		VenueID int `json:"venue_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/venues", in, &resp, nil); err != nil {
+		return 0, err
+	}
+
+	return resp.VenueID, nil
+}
+
+// Venues returns all venues ordered by name.
+func (c *Client) Venues(ctx context.Context) ([]Venue, error) {
+	var venues []Venue
+	if err := c.do(ctx, http.MethodGet, "/venues", nil, &venues, nil); err != nil {
+		return nil, err
+	}
+
+	return venues, nil
+}
+
+// Venue returns the venue.
+func (c *Client) Venue(ctx context.Context, venueID int) (*Venue, error) {
+	var venue Venue
+	if err := c.do(ctx, http.MethodGet, venuePath(venueID), nil, &venue, nil); err != nil {
+		return nil, err
+	}
+
+	return &venue, nil
+}
+
+// UpdateVenue replaces the venue's details. A capacity below the seats of
+// an event at the venue fails with ErrConflict.
+func (c *Client) UpdateVenue(ctx context.Context, venueID int, in VenueInput) error {
+	return c.do(ctx, http.MethodPut, venuePath(venueID), in, nil, nil)
+}
+
+// DeleteVenue deletes the venue. Venues with events fail with ErrConflict.
+func (c *Client) DeleteVenue(ctx context.Context, venueID int) error {
+	return c.do(ctx, http.MethodDelete, venuePath(venueID), nil, nil, nil)
+}
+
+// CreateSeries creates a series and returns its id and the ids of the
+// occurrences created so far, up to the server's horizon.
+func (c *Client) CreateSeries(ctx context.Context, in SeriesInput) (int, []int, error) {
+	var resp struct {
+		SeriesID int   `json:"series_id"`
+		EventIDs []int `json:"event_ids"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/series", in, &resp, nil); err != nil {
+		return 0, nil, err
+	}
+
+	return resp.SeriesID, resp.EventIDs, nil
+}
+
+// Series returns the series with the occurrences created so far, ordered by date.
+func (c *Client) Series(ctx context.Context, seriesID int) (*Series, []Event, error) {
+	var resp struct {
+		Series *Series `json:"series"`
+		Events []Event `json:"events"`
+	}
+	if err := c.do(ctx, http.MethodGet, seriesPath(seriesID, ""), nil, &resp, nil); err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Series, resp.Events, nil
+}
+
+// UpdateSeriesEvent edits the occurrence eventID of the series, alone with
+// ScopeThis or together with all later ones with ScopeFuture, and returns
+// the id of the series it belongs to afterwards. ScopeFuture splits the
+// series at the occurrence unless it is the first one.
+func (c *Client) UpdateSeriesEvent(ctx context.Context, seriesID, eventID int, scope string, in SeriesEventInput) (int, error) {
+	req := struct {
+		SeriesEventInput
+		Scope string `json:"scope"`
+	}{in, scope}
+
+	var resp struct {
+		SeriesID int `json:"series_id"`
+	}
+	if err := c.do(ctx, http.MethodPut, seriesPath(seriesID, "/events/"+strconv.Itoa(eventID)), req, &resp, nil); err != nil {
+		return 0, err
+	}
+
+	return resp.SeriesID, nil
+}
+
+// SkipSeriesOccurrence skips the occurrence of the series starting at date
+// and cancels its event with its bookings.
+func (c *Client) SkipSeriesOccurrence(ctx context.Context, seriesID int, date time.Time) error {
+	req := struct {
+		Date time.Time `json:"date"`
+	}{date}
+
+	return c.do(ctx, http.MethodPost, seriesPath(seriesID, "/exceptions"), req, nil, nil)
+}
+
+// BookSeries creates a pending booking of every upcoming occurrence of the
+// series for the user and returns the ids of the events booked. If one of
+// them is full or already booked, none is booked and the call fails with
+// ErrNoAvailableSeats or ErrDuplicateBooking.
+func (c *Client) BookSeries(ctx context.Context, seriesID int, userID string) ([]int, error) {
+	var resp struct {
+		EventIDs []int `json:"event_ids"`
+	}
+	if err := c.do(ctx, http.MethodPost, seriesPath(seriesID, "/book"), userRequest{UserID: userID}, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return resp.EventIDs, nil
+}
+
+// CreatePromoCode creates a promo code and returns its id. The client's API
+// key must authenticate a user with the admin role, see WithAPIKey.
+func (c *Client) CreatePromoCode(ctx context.Context, in PromoCodeInput) (int, error) {
+	var resp struct {
+		PromoCodeID int `json:"promo_code_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/promo-codes", in, &resp, nil); err != nil {
+		return 0, err
+	}
+
+	return resp.PromoCodeID, nil
+}
+
+// PromoCodes returns all promo codes, oldest first, with their uses. The
+// client's API key must authenticate a user with the admin role.
+func (c *Client) PromoCodes(ctx context.Context) ([]PromoCode, error) {
+	var codes []PromoCode
+	if err := c.do(ctx, http.MethodGet, "/promo-codes", nil, &codes, nil); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Confirm confirms the user's pending booking of the event. When payments
+// are enabled, bookings of priced ticket types fail with ErrPaymentRequired
+// and are confirmed by paying for them, see Pay.
+func (c *Client) Confirm(ctx context.Context, eventID int, userID string) error {
+	return c.do(ctx, http.MethodPost, eventPath(eventID, "/confirm"), userRequest{UserID: userID}, nil, nil)
+}
+
+// Pay starts the payment of the user's pending booking of a priced ticket type.
+func (c *Client) Pay(ctx context.Context, eventID int, userID string) (*Checkout, error) {
+	var checkout Checkout
+	if err := c.do(ctx, http.MethodPost, eventPath(eventID, "/pay"), userRequest{UserID: userID}, &checkout, nil); err != nil {
+		return nil, err
+	}
+
+	return &checkout, nil
+}
+
+// Cancel cancels the user's booking of the event, pending or confirmed.
+// A paid booking is refunded according to the event's cancellation policy.
+func (c *Client) Cancel(ctx context.Context, eventID int, userID string) error {
+	return c.do(ctx, http.MethodPost, eventPath(eventID, "/cancel"), userRequest{UserID: userID}, nil, nil)
+}
+
+// SetCancellationPolicy sets the cancellation policy of the event p.EventID.
+func (c *Client) SetCancellationPolicy(ctx context.Context, p CancellationPolicy) error {
+	return c.do(ctx, http.MethodPut, eventPath(p.EventID, "/cancellation-policy"), p, nil, nil)
+}
+
+// CancellationPolicy returns the cancellation policy of the event.
+func (c *Client) CancellationPolicy(ctx context.Context, eventID int) (*CancellationPolicy, error) {
+	var p CancellationPolicy
+	if err := c.do(ctx, http.MethodGet, eventPath(eventID, "/cancellation-policy"), nil, &p, nil); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// RefundPayment refunds amount of the payment, or the rest of it when amount
+// is zero. The client's API key must authenticate a user with the admin
+// role, see WithAPIKey, who is recorded with the refund. Other users get
+// ErrForbidden.
+func (c *Client) RefundPayment(ctx context.Context, paymentID int, amount int64, note string) (*Refund, error) {
+	in := struct {
+		Amount int64  `json:"amount,omitempty"`
+		Note   string `json:"note"`
+	}{Amount: amount, Note: note}
+
+	var refund Refund
+	if err := c.do(ctx, http.MethodPost, "/payments/"+strconv.Itoa(paymentID)+"/refunds", in, &refund, nil); err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// Refunds returns the refunds of the event's payments, oldest first.
+func (c *Client) Refunds(ctx context.Context, eventID int) ([]Refund, error) {
+	var refunds []Refund
+	if err := c.do(ctx, http.MethodGet, eventPath(eventID, "/refunds"), nil, &refunds, nil); err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+// Ticket returns the signed ticket of the confirmed booking of the event of
+// the user authenticated by the client's API key, see WithAPIKey.
+func (c *Client) Ticket(ctx context.Context, eventID int) (string, error) {
+	var resp struct {
+		Ticket string `json:"ticket"`
+	}
+	if err := c.do(ctx, http.MethodGet, eventPath(eventID, "/ticket"), nil, &resp, nil); err != nil {
+		return "", err
+	}
+
+	return resp.Ticket, nil
+}
+
+// CheckIn checks in the holder of ticket and returns the booking. A non-zero
+// eventID rejects tickets for other events. Each ticket is accepted once,
+// later attempts fail with ErrTicketUsed.
+func (c *Client) CheckIn(ctx context.Context, ticket string, eventID int) (*Booking, error) {
+	in := struct {
+		Ticket  string `json:"ticket"`
+		EventID int    `json:"event_id,omitempty"`
+	}{Ticket: ticket, EventID: eventID}
+
+	var resp struct {
+		Booking *Booking `json:"booking"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/checkin", in, &resp, nil); err != nil {
+		return nil, err
+	}
+
+	return resp.Booking, nil
+}
+
+type bookingRequest struct {
+	UserID       string `json:"user_id"`
+	TicketTypeID int    `json:"ticket_type_id,omitempty"`
+	PromoCode    string `json:"promo_code,omitempty"`
+	SeatID       int    `json:"seat_id,omitempty"`
+}
+
+type userRequest struct {
+	UserID string `json:"user_id"`
+}
+
+func venuePath(venueID int) string {
+	return "/venues/" + strconv.Itoa(venueID)
+}
+
+func seriesPath(seriesID int, action string) string {
+	return "/series/" + strconv.Itoa(seriesID) + action
+}
+
+func eventPath(eventID int, action string) string {
+	return "/events/" + strconv.Itoa(eventID) + action
+}
+
+// do sends the request, retrying it on server and network errors, and decodes
+// the envelope into data and meta. Mutating requests carry one Idempotency-Key
+// across all attempts, so a retry never books twice.
+func (c *Client) do(ctx context.Context, method, path string, in, data any, meta *response.Meta) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("client: encode request: %w", err)
+		}
+	}
+
+	var key string
+	if method != http.MethodGet {
+		key = newIdempotencyKey()
+	}
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, path, key, body, data, meta)
+		if err == nil || attempt >= c.retries || !retryable(err) {
+			return err
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-t.C:
+		}
+
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path, key string, body []byte, data any, meta *response.Meta) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("client: build request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		req.Header.Set(idempotencyHeader, key)
+	}
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &networkError{err: err}
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &networkError{err: err}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp.StatusCode, raw)
+	}
+
+	env := response.Envelope{Data: data}
+	if err = json.Unmarshal(raw, &env); err != nil {
+		return fmt.Errorf("client: decode response: %w", err)
+	}
+	if meta != nil {
+		*meta = env.Meta
+	}
+
+	return nil
+}
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
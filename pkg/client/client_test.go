@@ -0,0 +1,954 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"eventBooker/internal/http-server/router/routertest"
+	"eventBooker/pkg/client"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var eventDate = time.Date(2099, 6, 1, 19, 0, 0, 0, time.UTC)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	ctx := context.Background()
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{
+		Title:      "Go meetup",
+		Date:       eventDate,
+		TotalSeats: 1,
+		Deadline:   30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, eventID)
+
+	require.NoError(t, c.Book(ctx, eventID, "alice"))
+	assert.ErrorIs(t, c.Book(ctx, eventID, "alice"), client.ErrDuplicateBooking)
+	require.NoError(t, c.Confirm(ctx, eventID, "alice"))
+
+	assert.ErrorIs(t, c.Book(ctx, eventID, "bob"), client.ErrNoAvailableSeats)
+
+	require.NoError(t, c.Cancel(ctx, eventID, "alice"))
+	assert.ErrorIs(t, c.Cancel(ctx, eventID, "alice"), client.ErrNotFound)
+
+	require.NoError(t, c.Book(ctx, eventID, "bob"))
+	require.NoError(t, c.Confirm(ctx, eventID, "bob"))
+
+	event, bookings, err := c.GetEvent(ctx, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, "Go meetup", event.Title)
+	assert.True(t, event.Date.Equal(eventDate))
+	assert.Equal(t, 1, event.BookedSeats)
+	require.Len(t, bookings, 1)
+	assert.Equal(t, "bob", bookings[0].UserID)
+	assert.True(t, bookings[0].Confirmed)
+
+	_, _, err = c.GetEvent(ctx, 42)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestCheckIn(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	ctx := context.Background()
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{Title: "Go meetup", Date: eventDate, TotalSeats: 10, Deadline: 30})
+	require.NoError(t, err)
+
+	user := client.New(srv.URL, client.WithAPIKey(routertest.UserKey))
+	require.NoError(t, c.Book(ctx, eventID, routertest.UserID))
+	_, err = user.Ticket(ctx, eventID)
+	assert.ErrorIs(t, err, client.ErrNotFound, "pending bookings have no ticket")
+
+	require.NoError(t, c.Confirm(ctx, eventID, routertest.UserID))
+	_, err = c.Ticket(ctx, eventID)
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+	_, err = client.New(srv.URL, client.WithAPIKey(routertest.AdminKey)).Ticket(ctx, eventID)
+	assert.ErrorIs(t, err, client.ErrNotFound, "the ticket is only served to its owner")
+	ticket, err := user.Ticket(ctx, eventID)
+	require.NoError(t, err)
+
+	_, err = c.CheckIn(ctx, ticket, eventID+1)
+	assert.ErrorIs(t, err, client.ErrInvalidTicket)
+	_, err = c.CheckIn(ctx, ticket[:len(ticket)-2]+"AA", eventID)
+	assert.ErrorIs(t, err, client.ErrInvalidTicket)
+
+	booking, err := c.CheckIn(ctx, ticket, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, routertest.UserID, booking.UserID)
+	assert.NotNil(t, booking.CheckedInAt)
+
+	_, err = c.CheckIn(ctx, ticket, eventID)
+	assert.ErrorIs(t, err, client.ErrTicketUsed)
+}
+
+func TestTicketTypes(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{Title: "Go conference", Date: eventDate, TotalSeats: 2, Deadline: 30})
+	require.NoError(t, err)
+
+	standard, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "Standard", Price: 1500, Currency: "EUR", Capacity: 2})
+	require.NoError(t, err)
+	vip, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "VIP", Price: 9900, Currency: "EUR", Capacity: 1})
+	require.NoError(t, err)
+	salesEnd := time.Now().Add(-time.Hour)
+	closed, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "Early bird", Price: 900, Currency: "EUR", Capacity: 1, SalesEnd: &salesEnd})
+	require.NoError(t, err)
+
+	_, err = admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "VIP", Price: 100, Currency: "EUR", Capacity: 1})
+	assert.ErrorIs(t, err, client.ErrConflict)
+
+	assert.Error(t, c.Book(ctx, eventID, "alice"), "events with ticket types need one")
+	assert.ErrorIs(t, c.BookTicketType(ctx, eventID, closed, "alice"), client.ErrSalesClosed)
+	assert.ErrorIs(t, c.BookTicketType(ctx, eventID, 42, "alice"), client.ErrNotFound)
+
+	require.NoError(t, c.BookTicketType(ctx, eventID, vip, "alice"))
+	assert.ErrorIs(t, c.Confirm(ctx, eventID, "alice"), client.ErrPaymentRequired)
+	pay(t, srv, c, eventID, "alice", "pay")
+	assert.ErrorIs(t, c.BookTicketType(ctx, eventID, vip, "bob"), client.ErrNoAvailableSeats, "the tier is sold out")
+
+	require.NoError(t, c.BookTicketType(ctx, eventID, standard, "bob"))
+	pay(t, srv, c, eventID, "bob", "pay")
+	assert.ErrorIs(t, c.BookTicketType(ctx, eventID, standard, "carol"), client.ErrNoAvailableSeats, "the event is sold out")
+
+	ticketTypes, err := c.TicketTypes(ctx, eventID)
+	require.NoError(t, err)
+	require.Len(t, ticketTypes, 3)
+	assert.Equal(t, "Early bird", ticketTypes[0].Name)
+	assert.Equal(t, "Standard", ticketTypes[1].Name)
+	assert.Equal(t, 1, ticketTypes[1].BookedSeats)
+	assert.Zero(t, ticketTypes[1].RemainingSeats, "the event cap applies to every tier")
+	assert.Equal(t, "VIP", ticketTypes[2].Name)
+
+	_, bookings, err := c.GetEvent(ctx, eventID)
+	require.NoError(t, err)
+	require.Len(t, bookings, 2)
+	require.NotNil(t, bookings[0].TicketTypeID)
+	assert.Equal(t, standard, *bookings[0].TicketTypeID)
+}
+
+func TestPayments(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{Title: "Go conference", Date: eventDate, TotalSeats: 1, Deadline: 30})
+	require.NoError(t, err)
+	free, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "Speaker", Price: 0, Currency: "EUR", Capacity: 1})
+	require.NoError(t, err)
+	paid, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "Standard", Price: 1500, Currency: "EUR", Capacity: 5})
+	require.NoError(t, err)
+
+	_, err = c.Pay(ctx, eventID, "alice")
+	assert.ErrorIs(t, err, client.ErrNotFound, "no pending booking")
+
+	require.NoError(t, c.BookTicketType(ctx, eventID, free, "alice"))
+	_, err = c.Pay(ctx, eventID, "alice")
+	assert.ErrorIs(t, err, client.ErrConflict, "free bookings are confirmed directly")
+	require.NoError(t, c.Cancel(ctx, eventID, "alice"))
+
+	require.NoError(t, c.BookTicketType(ctx, eventID, paid, "alice"))
+	require.NoError(t, c.BookTicketType(ctx, eventID, paid, "bob"))
+
+	checkout := pay(t, srv, c, eventID, "alice", "decline")
+	assert.Equal(t, int64(1500), checkout.Amount)
+	assert.Equal(t, "EUR", checkout.Currency)
+	assertConfirmed(t, c, eventID, map[string]bool{"alice": false, "bob": false})
+
+	bobCheckout, err := c.Pay(ctx, eventID, "bob")
+	require.NoError(t, err)
+	aliceAgain, err := c.Pay(ctx, eventID, "alice")
+	require.NoError(t, err)
+
+	pay(t, srv, c, eventID, "alice", "pay")
+	assertConfirmed(t, c, eventID, map[string]bool{"alice": true, "bob": false})
+
+	// The booking is paid already, so alice's second payment is refunded.
+	submitCheckout(t, srv, aliceAgain, "pay")
+	refunds, err := admin.Refunds(ctx, eventID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 1)
+	assert.Equal(t, aliceAgain.PaymentID, refunds[0].PaymentID)
+	assert.Equal(t, "unconfirmable", refunds[0].Reason)
+	assert.Equal(t, "succeeded", refunds[0].Status)
+
+	// The last seat is taken, so bob's payment is refunded and his booking stays pending.
+	submitCheckout(t, srv, bobCheckout, "pay")
+	assertConfirmed(t, c, eventID, map[string]bool{"alice": true, "bob": false})
+}
+
+func TestRefunds(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{Title: "Go conference", Date: time.Now().Add(48 * time.Hour), TotalSeats: 10, Deadline: 30})
+	require.NoError(t, err)
+	paid, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "Standard", Price: 1500, Currency: "EUR", Capacity: 5})
+	require.NoError(t, err)
+
+	policy, err := c.CancellationPolicy(ctx, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, client.CancellationPolicy{EventID: eventID}, *policy, "refunds in full by default")
+
+	want := client.CancellationPolicy{EventID: eventID, FullRefundHours: 72, PartialRefundPercent: 50, CutoffHours: 24}
+	require.NoError(t, c.SetCancellationPolicy(ctx, want))
+	policy, err = c.CancellationPolicy(ctx, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, want, *policy)
+
+	require.NoError(t, c.BookTicketType(ctx, eventID, paid, "alice"))
+	alice := pay(t, srv, c, eventID, "alice", "pay")
+	require.NoError(t, c.BookTicketType(ctx, eventID, paid, "bob"))
+	bob := pay(t, srv, c, eventID, "bob", "pay")
+
+	// The event is 48 hours away, between the full refund deadline and the cutoff.
+	require.NoError(t, c.Cancel(ctx, eventID, "alice"))
+
+	_, err = c.RefundPayment(ctx, bob.PaymentID, 0, "event moved")
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+	_, err = client.New(srv.URL, client.WithAPIKey(routertest.UserKey)).RefundPayment(ctx, bob.PaymentID, 0, "event moved")
+	assert.ErrorIs(t, err, client.ErrForbidden)
+
+	refund, err := admin.RefundPayment(ctx, bob.PaymentID, 500, "event moved")
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), refund.Amount)
+	assert.Equal(t, "succeeded", refund.Status)
+
+	_, err = admin.RefundPayment(ctx, bob.PaymentID, 1500, "event moved")
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr, "refund exceeds the rest of the payment")
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+
+	refund, err = admin.RefundPayment(ctx, bob.PaymentID, 0, "event moved")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), refund.Amount, "zero amount refunds the rest")
+
+	_, err = admin.RefundPayment(ctx, bob.PaymentID, 0, "event moved")
+	assert.ErrorIs(t, err, client.ErrConflict, "payment refunded in full")
+
+	refunds, err := admin.Refunds(ctx, eventID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 3)
+
+	assert.Equal(t, alice.PaymentID, refunds[0].PaymentID)
+	assert.Equal(t, int64(750), refunds[0].Amount)
+	assert.Equal(t, "cancellation", refunds[0].Reason)
+	assert.Equal(t, "succeeded", refunds[0].Status)
+
+	for _, r := range refunds[1:] {
+		assert.Equal(t, bob.PaymentID, r.PaymentID)
+		assert.Equal(t, "override", r.Reason)
+		assert.Equal(t, routertest.AdminID, r.CreatedBy)
+		assert.Equal(t, "event moved", r.Note)
+	}
+}
+
+func TestPromoCodes(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{Title: "Go conference", Date: eventDate, TotalSeats: 10, Deadline: 30})
+	require.NoError(t, err)
+	standard, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "Standard", Price: 2000, Currency: "EUR", Capacity: 5})
+	require.NoError(t, err)
+	vip, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "VIP", Price: 5000, Currency: "EUR", Capacity: 5})
+	require.NoError(t, err)
+
+	_, err = c.CreatePromoCode(ctx, client.PromoCodeInput{Code: "FREE", Kind: "percent", Value: 100})
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+	_, err = client.New(srv.URL, client.WithAPIKey(routertest.UserKey)).CreatePromoCode(ctx, client.PromoCodeInput{Code: "FREE", Kind: "percent", Value: 100})
+	assert.ErrorIs(t, err, client.ErrForbidden, "only admins create promo codes")
+	_, err = client.New(srv.URL, client.WithAPIKey(routertest.UserKey)).PromoCodes(ctx)
+	assert.ErrorIs(t, err, client.ErrForbidden, "only admins list promo codes")
+
+	expired := time.Now().Add(-time.Hour)
+	for _, in := range []client.PromoCodeInput{
+		{Code: "earlybird", Kind: "percent", Value: 25, MaxUses: 2, MaxUsesPerUser: 1, EventID: eventID},
+		{Code: "PARTNER", Kind: "fixed", Value: 500, Currency: "EUR", TicketTypeID: vip},
+		{Code: "LASTYEAR", Kind: "percent", Value: 50, ValidUntil: &expired},
+	} {
+		_, err = admin.CreatePromoCode(ctx, in)
+		require.NoError(t, err)
+	}
+
+	_, err = admin.CreatePromoCode(ctx, client.PromoCodeInput{Code: "EarlyBird", Kind: "percent", Value: 10})
+	assert.ErrorIs(t, err, client.ErrConflict, "codes are unique regardless of case")
+
+	require.NoError(t, c.BookWithPromoCode(ctx, eventID, standard, "alice", "EarlyBird"))
+	checkout := pay(t, srv, c, eventID, "alice", "pay")
+	assert.Equal(t, int64(1500), checkout.Amount)
+
+	err = c.BookWithPromoCode(ctx, eventID, standard, "bob", "PARTNER")
+	assert.ErrorIs(t, err, client.ErrPromoCodeRejected, "restricted to VIP")
+	err = c.BookWithPromoCode(ctx, eventID, standard, "bob", "LASTYEAR")
+	assert.ErrorIs(t, err, client.ErrPromoCodeRejected, "expired")
+	err = c.BookWithPromoCode(ctx, eventID, standard, "bob", "NOPE")
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	require.NoError(t, c.BookWithPromoCode(ctx, eventID, vip, "bob", "PARTNER"))
+	checkout, err = c.Pay(ctx, eventID, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4500), checkout.Amount)
+
+	err = c.BookWithPromoCode(ctx, eventID, standard, "alice", "EARLYBIRD")
+	assert.ErrorIs(t, err, client.ErrPromoCodeRejected, "once per user")
+
+	require.NoError(t, c.BookWithPromoCode(ctx, eventID, standard, "carol", "EARLYBIRD"))
+	err = c.BookWithPromoCode(ctx, eventID, standard, "dave", "EARLYBIRD")
+	assert.ErrorIs(t, err, client.ErrPromoCodeRejected, "used up")
+
+	// A cancelled booking gives its use back.
+	require.NoError(t, c.Cancel(ctx, eventID, "carol"))
+	require.NoError(t, c.BookWithPromoCode(ctx, eventID, standard, "dave", "EARLYBIRD"))
+
+	codes, err := admin.PromoCodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, codes, 3)
+	assert.Equal(t, "EARLYBIRD", codes[0].Code)
+	assert.Equal(t, 2, codes[0].Uses)
+	assert.Equal(t, 1, codes[1].Uses)
+	assert.Equal(t, 0, codes[2].Uses)
+}
+
+func TestPromoCodeConcurrentUses(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{Title: "Go conference", Date: eventDate, TotalSeats: 50, Deadline: 30})
+	require.NoError(t, err)
+	standard, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "Standard", Price: 2000, Currency: "EUR", Capacity: 50})
+	require.NoError(t, err)
+	_, err = admin.CreatePromoCode(ctx, client.PromoCodeInput{Code: "FLASH", Kind: "percent", Value: 50, MaxUses: 3})
+	require.NoError(t, err)
+
+	var (
+		wg       sync.WaitGroup
+		redeemed atomic.Int32
+	)
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := c.BookWithPromoCode(ctx, eventID, standard, fmt.Sprintf("user%d", i), "FLASH")
+			if err == nil {
+				redeemed.Add(1)
+				return
+			}
+			assert.ErrorIs(t, err, client.ErrPromoCodeRejected)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), redeemed.Load())
+}
+
+func TestConcurrentBookAndConfirm(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{Title: "Go conference", Date: eventDate, TotalSeats: 5, Deadline: 30})
+	require.NoError(t, err)
+	free, err := admin.CreateTicketType(ctx, eventID, client.TicketTypeInput{Name: "Free", Price: 0, Currency: "EUR", Capacity: 3})
+	require.NoError(t, err)
+
+	var (
+		wg        sync.WaitGroup
+		confirmed atomic.Int32
+	)
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			userID := fmt.Sprintf("user%d", i)
+			if err := c.BookTicketType(ctx, eventID, free, userID); err != nil {
+				assert.ErrorIs(t, err, client.ErrNoAvailableSeats)
+				return
+			}
+			if err := c.Confirm(ctx, eventID, userID); err != nil {
+				assert.ErrorIs(t, err, client.ErrNoAvailableSeats)
+				return
+			}
+			confirmed.Add(1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), confirmed.Load(), "bookings and confirmations never exceed the tier")
+
+	ticketTypes, err := c.TicketTypes(ctx, eventID)
+	require.NoError(t, err)
+	require.Len(t, ticketTypes, 1)
+	assert.Equal(t, 3, ticketTypes[0].BookedSeats)
+}
+
+func TestReservedSeating(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	layoutID, err := admin.CreateSeatLayout(ctx, client.SeatLayoutInput{
+		Name: "Small hall",
+		Sections: []client.SeatSectionInput{
+			{Name: "Stalls", Rows: []client.SeatRowInput{{Name: "A", Seats: 2}, {Name: "B", Seats: 1}}},
+		},
+	})
+	require.NoError(t, err)
+
+	layout, err := c.SeatLayout(ctx, layoutID)
+	require.NoError(t, err)
+	require.Len(t, layout.Sections, 1)
+	require.Len(t, layout.Sections[0].Rows, 2)
+	a1, a2 := layout.Sections[0].Rows[0].Seats[0], layout.Sections[0].Rows[0].Seats[1]
+
+	eventID, err := c.CreateEvent(ctx, client.EventInput{Title: "Hamlet", Date: eventDate, TotalSeats: 100, Deadline: 30})
+	require.NoError(t, err)
+
+	_, err = c.SeatMap(ctx, eventID)
+	assert.ErrorIs(t, err, client.ErrNotFound, "general admission events have no seat map")
+
+	require.NoError(t, c.SetEventLayout(ctx, eventID, layoutID))
+
+	event, _, err := c.GetEvent(ctx, eventID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, event.TotalSeats)
+
+	var apiErr *client.APIError
+	err = c.Book(ctx, eventID, "alice")
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode, "seat is required")
+
+	require.NoError(t, c.BookSeat(ctx, eventID, a1.ID, "alice"))
+	assert.ErrorIs(t, c.BookSeat(ctx, eventID, a1.ID, "bob"), client.ErrSeatTaken)
+	assert.ErrorIs(t, c.BookSeat(ctx, eventID, 999, "bob"), client.ErrNotFound)
+	require.NoError(t, c.BookSeat(ctx, eventID, a2.ID, "bob"))
+	require.NoError(t, c.Confirm(ctx, eventID, "alice"))
+
+	assert.ErrorIs(t, c.SetEventLayout(ctx, eventID, layoutID), client.ErrConflict, "events with bookings keep their layout")
+
+	seatMap, err := c.SeatMap(ctx, eventID)
+	require.NoError(t, err)
+	statuses := map[int]string{}
+	for _, row := range seatMap.Sections[0].Rows {
+		for _, seat := range row.Seats {
+			statuses[seat.ID] = seat.Status
+		}
+	}
+	b1 := layout.Sections[0].Rows[1].Seats[0]
+	assert.Equal(t, map[int]string{a1.ID: "booked", a2.ID: "held", b1.ID: "available"}, statuses)
+
+	// Cancelling frees the seat for someone else.
+	require.NoError(t, c.Cancel(ctx, eventID, "bob"))
+	require.NoError(t, c.BookSeat(ctx, eventID, a2.ID, "carol"))
+}
+
+func TestVenues(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	var apiErr *client.APIError
+	_, err := admin.CreateVenue(ctx, client.VenueInput{Name: "Nowhere", Timezone: "Mars/Olympus", Capacity: 10})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode, "unknown time zones are rejected")
+
+	hallID, err := admin.CreateVenue(ctx, client.VenueInput{Name: "Main hall", Address: "1 Theatre Sq", Timezone: "Europe/Moscow", Capacity: 300})
+	require.NoError(t, err)
+	clubID, err := admin.CreateVenue(ctx, client.VenueInput{Name: "Club", Capacity: 80})
+	require.NoError(t, err)
+
+	club, err := c.Venue(ctx, clubID)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", club.Timezone)
+
+	venues, err := c.Venues(ctx)
+	require.NoError(t, err)
+	require.Len(t, venues, 2)
+	assert.Equal(t, []string{"Club", "Main hall"}, []string{venues[0].Name, venues[1].Name})
+
+	// Events at a venue default to its capacity and may not exceed it.
+	hamletID, err := c.CreateEvent(ctx, client.EventInput{Title: "Hamlet", Date: eventDate, Deadline: 30, VenueID: hallID})
+	require.NoError(t, err)
+	_, err = c.CreateEvent(ctx, client.EventInput{Title: "Gig", Date: eventDate, TotalSeats: 81, Deadline: 30, VenueID: clubID})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	_, err = c.CreateEvent(ctx, client.EventInput{Title: "Elsewhere", Date: eventDate, TotalSeats: 10, Deadline: 30})
+	require.NoError(t, err)
+
+	hamlet, _, err := c.GetEvent(ctx, hamletID)
+	require.NoError(t, err)
+	assert.Equal(t, 300, hamlet.TotalSeats)
+	require.NotNil(t, hamlet.VenueID)
+	assert.Equal(t, hallID, *hamlet.VenueID)
+
+	events, page, err := c.ListVenueEvents(ctx, hallID, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	require.Len(t, events, 1)
+	assert.Equal(t, hamletID, events[0].ID)
+
+	assert.ErrorIs(t, admin.UpdateVenue(ctx, hallID, client.VenueInput{Name: "Main hall", Capacity: 200}), client.ErrConflict,
+		"the capacity cannot drop below an event's seats")
+	require.NoError(t, admin.UpdateVenue(ctx, hallID, client.VenueInput{Name: "Grand hall", Capacity: 400}))
+
+	assert.ErrorIs(t, admin.DeleteVenue(ctx, hallID), client.ErrConflict, "venues with events are kept")
+	require.NoError(t, admin.DeleteVenue(ctx, clubID))
+	_, err = c.Venue(ctx, clubID)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestEventTimezones(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	// 20:00 UTC is already the next day in Tokyo.
+	date := time.Date(2099, 6, 1, 20, 0, 0, 0, time.UTC)
+
+	var apiErr *client.APIError
+	_, err := c.CreateEvent(ctx, client.EventInput{Title: "Nowhere", Date: date, TotalSeats: 10, Deadline: 30, Timezone: "Mars/Olympus"})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode, "unknown time zones are rejected")
+
+	venueID, err := admin.CreateVenue(ctx, client.VenueInput{Name: "Budokan", Timezone: "Asia/Tokyo", Capacity: 100})
+	require.NoError(t, err)
+
+	tokyoID, err := c.CreateEvent(ctx, client.EventInput{Title: "Tokyo", Date: date, Deadline: 30, VenueID: venueID})
+	require.NoError(t, err)
+	newYorkID, err := c.CreateEvent(ctx, client.EventInput{Title: "New York", Date: date, TotalSeats: 10, Deadline: 30, Timezone: "America/New_York"})
+	require.NoError(t, err)
+	_, err = c.CreateEvent(ctx, client.EventInput{Title: "London", Date: date.In(time.FixedZone("", 3*60*60)), TotalSeats: 10, Deadline: 30})
+	require.NoError(t, err)
+
+	tests := []struct {
+		id        int
+		timezone  string
+		localDate string
+	}{
+		{id: tokyoID, timezone: "Asia/Tokyo", localDate: "2099-06-02T05:00:00+09:00"},
+		{id: newYorkID, timezone: "America/New_York", localDate: "2099-06-01T16:00:00-04:00"},
+		{id: newYorkID + 1, timezone: "UTC", localDate: "2099-06-01T20:00:00Z"},
+	}
+	for _, tc := range tests {
+		event, _, err := c.GetEvent(ctx, tc.id)
+		require.NoError(t, err)
+		assert.Equal(t, tc.timezone, event.Timezone)
+		assert.Equal(t, time.UTC, event.Date.Location(), "dates are returned in UTC")
+		require.NotNil(t, event.LocalDate)
+		assert.Equal(t, tc.localDate, event.LocalDate.Format(time.RFC3339))
+	}
+
+	events, page, err := c.ListEventsOnDay(ctx, "2099-06-02", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	require.Len(t, events, 1)
+	assert.Equal(t, tokyoID, events[0].ID)
+
+	events, _, err = c.ListEventsOnDay(ctx, "2099-06-01", 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	_, _, err = c.ListEventsOnDay(ctx, "June 1", 0, 0)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestSeries(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	// Occurrences are created up to a horizon from now, so the series starts soon.
+	start := time.Now().UTC().Truncate(time.Hour).AddDate(0, 0, 7)
+	week := func(n int) time.Time { return start.AddDate(0, 0, 7*n) }
+
+	var apiErr *client.APIError
+	_, _, err := admin.CreateSeries(ctx, client.SeriesInput{Title: "Workshop", Start: start, Rule: "FREQ=HOURLY", TotalSeats: 10, Deadline: 30})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode, "unsupported rules are rejected")
+
+	seriesID, eventIDs, err := admin.CreateSeries(ctx, client.SeriesInput{
+		Title:      "Workshop",
+		Start:      start,
+		Rule:       "FREQ=WEEKLY;COUNT=4",
+		TotalSeats: 1,
+		Deadline:   30,
+		Exceptions: []time.Time{week(1)},
+	})
+	require.NoError(t, err)
+	require.Len(t, eventIDs, 3, "the skipped occurrence is not created")
+
+	series, events, err := c.Series(ctx, seriesID)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", series.Timezone)
+	require.Len(t, events, 3)
+	for i, want := range []time.Time{week(0), week(2), week(3)} {
+		assert.True(t, want.Equal(events[i].Date), "occurrence %d", i)
+		require.NotNil(t, events[i].SeriesID)
+		assert.Equal(t, seriesID, *events[i].SeriesID)
+	}
+
+	// Skipping cancels the occurrence and its bookings, twice is fine, other
+	// dates are rejected.
+	require.NoError(t, c.Book(ctx, eventIDs[1], "u3"))
+	require.NoError(t, c.Confirm(ctx, eventIDs[1], "u3"))
+	require.NoError(t, admin.SkipSeriesOccurrence(ctx, seriesID, week(2)))
+	require.NoError(t, admin.SkipSeriesOccurrence(ctx, seriesID, week(2)))
+	err = admin.SkipSeriesOccurrence(ctx, seriesID, week(2).Add(time.Hour))
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	_, events, err = c.Series(ctx, seriesID)
+	require.NoError(t, err)
+	require.Len(t, events, 3, "the skipped occurrence is kept on record")
+	assert.Equal(t, eventIDs[1], events[1].ID)
+	assert.Equal(t, "cancelled", events[1].Status)
+	assertConfirmed(t, c, eventIDs[1], map[string]bool{})
+	notifications, err := admin.Notifications(ctx, "u3")
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, eventIDs[1], notifications[0].EventID)
+
+	// A single full occurrence fails the whole series.
+	require.NoError(t, c.Book(ctx, eventIDs[2], "u2"))
+	require.NoError(t, c.Confirm(ctx, eventIDs[2], "u2"))
+	_, err = c.BookSeries(ctx, seriesID, "u1")
+	assert.ErrorIs(t, err, client.ErrNoAvailableSeats)
+	assertConfirmed(t, c, eventIDs[0], map[string]bool{})
+
+	// Editing one occurrence keeps it in the series.
+	id, err := admin.UpdateSeriesEvent(ctx, seriesID, eventIDs[0], client.ScopeThis, client.SeriesEventInput{
+		Title: "Opening workshop", Date: start.Add(-time.Hour), TotalSeats: 5, Deadline: 30,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, seriesID, id)
+
+	_, err = c.BookSeries(ctx, seriesID, "u1")
+	assert.ErrorIs(t, err, client.ErrNoAvailableSeats, "the last occurrence is still full")
+
+	// Editing all future occurrences from the last one splits the series.
+	_, err = admin.UpdateSeriesEvent(ctx, seriesID, eventIDs[2], client.ScopeFuture, client.SeriesEventInput{
+		Title: "Workshop", Date: week(4), TotalSeats: 5, Deadline: 30,
+	})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode, "future occurrences keep their days")
+
+	newID, err := admin.UpdateSeriesEvent(ctx, seriesID, eventIDs[2], client.ScopeFuture, client.SeriesEventInput{
+		Title: "Late workshop", Date: week(3).Add(2 * time.Hour), TotalSeats: 5, Deadline: 30,
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, seriesID, newID)
+
+	split, events, err := c.Series(ctx, newID)
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;COUNT=1", split.Rule, "the split series keeps the rest of the count")
+	require.Len(t, events, 1)
+	assert.Equal(t, "Late workshop", events[0].Title)
+	assert.True(t, week(3).Add(2*time.Hour).Equal(events[0].Date))
+	assert.Equal(t, 5, events[0].TotalSeats)
+
+	series, events, err = c.Series(ctx, seriesID)
+	require.NoError(t, err)
+	require.NotNil(t, series.EndsAt)
+	assert.True(t, week(3).Equal(*series.EndsAt))
+	require.Len(t, events, 2)
+	assert.Equal(t, "Opening workshop", events[0].Title)
+	assert.Equal(t, eventIDs[1], events[1].ID, "the skipped occurrence stays with the series")
+
+	booked, err := c.BookSeries(ctx, newID, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []int{eventIDs[2]}, booked)
+	_, err = c.BookSeries(ctx, newID, "u1")
+	assert.ErrorIs(t, err, client.ErrDuplicateBooking)
+
+	// A series booking has no ticket type, so occurrences with them are booked one by one.
+	_, err = admin.CreateTicketType(ctx, eventIDs[0], client.TicketTypeInput{Name: "Standard", Price: 1500, Currency: "EUR", Capacity: 5})
+	require.NoError(t, err)
+	_, err = c.BookSeries(ctx, seriesID, "u3")
+	assert.ErrorIs(t, err, client.ErrConflict)
+
+	_, _, err = c.Series(ctx, 99)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+// pay pays for the user's pending booking on the fake provider's checkout
+// page, or declines the payment.
+func pay(t *testing.T, srv *httptest.Server, c *client.Client, eventID int, userID, result string) *client.Checkout {
+	t.Helper()
+
+	checkout, err := c.Pay(context.Background(), eventID, userID)
+	require.NoError(t, err)
+
+	submitCheckout(t, srv, checkout, result)
+
+	return checkout
+}
+
+func submitCheckout(t *testing.T, srv *httptest.Server, checkout *client.Checkout, result string) {
+	t.Helper()
+
+	resp, err := http.PostForm(srv.URL+checkout.CheckoutURL, url.Values{"result": {result}})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotContains(t, string(body), "Не удалось", "callback delivery failed")
+}
+
+func assertConfirmed(t *testing.T, c *client.Client, eventID int, want map[string]bool) {
+	t.Helper()
+
+	_, bookings, err := c.GetEvent(context.Background(), eventID)
+	require.NoError(t, err)
+
+	got := map[string]bool{}
+	for _, b := range bookings {
+		got[b.UserID] = b.Confirmed
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestEventLifecycle(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	admin := client.New(srv.URL, client.WithAPIKey(routertest.AdminKey))
+	ctx := context.Background()
+
+	date := time.Now().Add(48 * time.Hour)
+	draftID, err := c.CreateEvent(ctx, client.EventInput{Title: "Draft", Date: date, TotalSeats: 10, Deadline: 30, Status: client.EventDraft})
+	require.NoError(t, err)
+	closesAt := time.Now().Add(-time.Hour)
+	closedID, err := c.CreateEvent(ctx, client.EventInput{Title: "Closed", Date: date, TotalSeats: 10, Deadline: 30, SalesCloseAt: &closesAt})
+	require.NoError(t, err)
+
+	events, _, err := c.ListEvents(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1, "drafts are hidden from the public")
+	assert.Equal(t, closedID, events[0].ID)
+
+	events, _, err = admin.ListAllEvents(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, client.EventDraft, events[0].Status)
+
+	_, _, err = c.ListAllEvents(ctx, 0, 0)
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+	_, _, err = client.New(srv.URL, client.WithAPIKey(routertest.UserKey)).ListAllEvents(ctx, 0, 0)
+	assert.ErrorIs(t, err, client.ErrForbidden)
+	_, _, err = client.New(srv.URL, client.WithAPIKey("unknown")).ListEvents(ctx, 0, 0)
+	assert.ErrorIs(t, err, client.ErrUnauthorized, "unknown keys are rejected")
+
+	assert.ErrorIs(t, c.Book(ctx, draftID, "alice"), client.ErrSalesClosed, "drafts are not on sale")
+	assert.ErrorIs(t, c.Book(ctx, closedID, "alice"), client.ErrSalesClosed, "sales have closed")
+
+	_, err = c.SetEventStatus(ctx, draftID, client.EventPublished)
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+	_, err = client.New(srv.URL, client.WithAPIKey(routertest.UserKey)).SetEventStatus(ctx, draftID, client.EventPublished)
+	assert.ErrorIs(t, err, client.ErrForbidden, "only admins move events through their lifecycle")
+
+	_, err = admin.SetEventStatus(ctx, draftID, client.EventCompleted)
+	assert.ErrorIs(t, err, client.ErrConflict)
+	_, err = admin.SetEventStatus(ctx, draftID, client.EventPublished)
+	require.NoError(t, err)
+
+	paid, err := admin.CreateTicketType(ctx, draftID, client.TicketTypeInput{Name: "Standard", Price: 1500, Currency: "EUR", Capacity: 5})
+	require.NoError(t, err)
+	require.NoError(t, c.BookTicketType(ctx, draftID, paid, "alice"))
+	alice := pay(t, srv, c, draftID, "alice", "pay")
+	require.NoError(t, c.BookTicketType(ctx, draftID, paid, "bob"))
+
+	cancelled, err := admin.SetEventStatus(ctx, draftID, client.EventCancelled)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cancelled)
+
+	_, bookings, err := c.GetEvent(ctx, draftID)
+	require.NoError(t, err)
+	assert.Empty(t, bookings)
+
+	refunds, err := admin.Refunds(ctx, draftID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 1, "only paid bookings are refunded")
+	assert.Equal(t, alice.PaymentID, refunds[0].PaymentID)
+	assert.Equal(t, int64(1500), refunds[0].Amount, "refunds in full")
+	assert.Equal(t, "event_cancelled", refunds[0].Reason)
+
+	for _, userID := range []string{"alice", "bob"} {
+		notifications, err := admin.Notifications(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, draftID, notifications[0].EventID)
+		assert.Equal(t, "event_cancelled", notifications[0].Kind)
+	}
+
+	_, err = admin.SetEventStatus(ctx, draftID, client.EventPublished)
+	assert.ErrorIs(t, err, client.ErrConflict, "cancelled events stay cancelled")
+}
+
+func TestListEvents(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+	ctx := context.Background()
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		_, err := c.CreateEvent(ctx, client.EventInput{Title: title, Date: eventDate, TotalSeats: 10, Deadline: 30})
+		require.NoError(t, err)
+	}
+
+	events, page, err := c.ListEvents(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, client.Pagination{Limit: 2, Offset: 1, Total: 3}, page)
+	require.Len(t, events, 2)
+	assert.Equal(t, "Second", events[0].Title)
+	assert.Equal(t, "Third", events[1].Title)
+}
+
+func TestValidationError(t *testing.T) {
+	t.Parallel()
+
+	srv := routertest.NewServer(t)
+	c := client.New(srv.URL)
+
+	_, err := c.CreateEvent(context.Background(), client.EventInput{Title: "Go meetup", Date: eventDate, Deadline: 30})
+	require.ErrorIs(t, err, client.ErrValidation)
+
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.NotEmpty(t, apiErr.RequestID)
+	require.Len(t, apiErr.Errors, 1)
+	assert.Equal(t, "total_seats", apiErr.Errors[0].Field)
+	assert.Equal(t, "required_without", apiErr.Errors[0].Tag)
+}
+
+func TestRetries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		failures  int32
+		status    int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "Recovers after server errors", failures: 2, status: http.StatusServiceUnavailable, wantCalls: 3},
+		{name: "Gives up after retries", failures: 10, status: http.StatusBadGateway, wantCalls: 4, wantErr: true},
+		{name: "Client errors are not retried", failures: 10, status: http.StatusNotFound, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				calls int32
+				keys  = make(chan string, 10)
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				keys <- r.Header.Get("Idempotency-Key")
+				if atomic.AddInt32(&calls, 1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":null,"meta":{}}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			c := client.New(srv.URL, client.WithRetries(3, time.Millisecond))
+
+			err := c.Book(context.Background(), 1, "alice")
+			if tt.wantErr {
+				var apiErr *client.APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tt.status, apiErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+
+			close(keys)
+			first := <-keys
+			assert.NotEmpty(t, first)
+			for key := range keys {
+				assert.Equal(t, first, key)
+			}
+		})
+	}
+}
+
+func TestRetriesStopOnCancel(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := client.New(srv.URL, client.WithRetries(10, time.Hour))
+
+	err := c.Book(ctx, 1, "alice")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
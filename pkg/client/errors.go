@@ -0,0 +1,105 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"eventBooker/internal/lib/api/response"
+	"fmt"
+	"net/http"
+)
+
+type FieldError = response.FieldError
+
+// Errors reported by the API, matched with errors.Is against an *APIError.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrForbidden         = errors.New("forbidden")
+	ErrValidation        = errors.New("validation failed")
+	ErrConflict          = errors.New("conflict")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrNoAvailableSeats  = errors.New("no available seats")
+	ErrDuplicateBooking  = errors.New("duplicate booking")
+	ErrInvalidTicket     = errors.New("invalid ticket")
+	ErrTicketUsed        = errors.New("ticket already used")
+	ErrSalesClosed       = errors.New("not on sale")
+	ErrPaymentRequired   = errors.New("payment required")
+	ErrPromoCodeRejected = errors.New("promo code rejected")
+	ErrSeatTaken         = errors.New("seat already taken")
+)
+
+var codeErrors = map[string]error{
+	response.CodeNotFound:          ErrNotFound,
+	response.CodeUnauthorized:      ErrUnauthorized,
+	response.CodeForbidden:         ErrForbidden,
+	response.CodeValidationFailed:  ErrValidation,
+	response.CodeConflict:          ErrConflict,
+	response.CodeRateLimited:       ErrRateLimited,
+	response.CodeNoAvailableSeats:  ErrNoAvailableSeats,
+	response.CodeDuplicateBooking:  ErrDuplicateBooking,
+	response.CodeInvalidTicket:     ErrInvalidTicket,
+	response.CodeTicketUsed:        ErrTicketUsed,
+	response.CodeSalesClosed:       ErrSalesClosed,
+	response.CodePaymentRequired:   ErrPaymentRequired,
+	response.CodePromoCodeRejected: ErrPromoCodeRejected,
+	response.CodeSeatTaken:         ErrSeatTaken,
+}
+
+// APIError is an error response of the API.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Detail     string
+	// RequestID identifies the request in the server logs.
+	RequestID string
+	// Errors lists the failed rules of a validation error.
+	Errors []FieldError
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("client: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Detail)
+	}
+
+	return fmt.Sprintf("client: %d %s: %s", e.StatusCode, e.Code, e.Detail)
+}
+
+func (e *APIError) Is(target error) bool {
+	return codeErrors[e.Code] == target
+}
+
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: status}
+
+	var p response.Problem
+	if err := json.Unmarshal(body, &p); err != nil || p.Code == "" {
+		apiErr.Detail = http.StatusText(status)
+		return apiErr
+	}
+
+	apiErr.Code = p.Code
+	apiErr.Detail = p.Detail
+	apiErr.RequestID = p.Instance
+	apiErr.Errors = p.Errors
+
+	return apiErr
+}
+
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string { return "client: " + e.err.Error() }
+
+func (e *networkError) Unwrap() error { return e.err }
+
+// retryable reports whether the request may succeed if sent again.
+func retryable(err error) bool {
+	var netErr *networkError
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
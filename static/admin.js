@@ -1,5 +1,6 @@
 document.addEventListener('DOMContentLoaded', function() {
     loadAdminEvents();
+    loadVenues();
     setupAdminEventListeners();
 });
 
@@ -8,10 +9,15 @@ function setupAdminEventListeners() {
         e.preventDefault();
         createEvent();
     });
+
+    document.getElementById('import-events-form').addEventListener('submit', function(e) {
+        e.preventDefault();
+        importEvents();
+    });
 }
 
 function loadAdminEvents() {
-    fetch('/events')
+    fetch('/api/v1/events')
         .then(response => {
             if (!response.ok) {
                 throw new Error('Network response was not ok');
@@ -20,7 +26,7 @@ function loadAdminEvents() {
         })
         .then(data => {
             console.log('Received admin data:', data);
-            displayAdminEvents(data); // передаем весь объект
+            displayAdminEvents(data.data); // передаем data из конверта
         })
         .catch(error => {
             console.error('Error loading events:', error);
@@ -57,18 +63,18 @@ function displayAdminEvents(responseData) {
     let html = '';
     eventsArray.forEach(event => {
         const freeSeats = event.total_seats ? event.total_seats - (event.booked_seats || 0) : 0;
-        const date = event.date ? new Date(event.date) : new Date();
         const deadline = event.deadline_minutes ? `${event.deadline_minutes} минут` : 'Не указан';
 
         html += `
             <div class="event-card">
                 <div class="event-title">${event.title || 'Без названия'}</div>
                 <div class="event-info">
-                    <div class="event-date">📅 ${date.toLocaleString('ru-RU')}</div>
+                    <div class="event-date">📅 ${event.date ? formatEventDate(event) : 'Дата не указана'}</div>
                     <div class="event-seats">🪑 Всего мест: ${event.total_seats || 0}, свободно: ${freeSeats}</div>
                     <div class="event-deadline">⏰ Дедлайн: ${deadline}</div>
                     <div class="event-id">🆔 ID: ${event.id || 'N/A'}</div>
                 </div>
+                <a class="download-link" href="/api/v1/events/${event.id}/attendees.csv" download>Скачать список гостей (CSV)</a>
             </div>
         `;
     });
@@ -76,25 +82,70 @@ function displayAdminEvents(responseData) {
     container.innerHTML = html;
 }
 
+function loadVenues() {
+    fetch('/api/v1/venues')
+        .then(response => response.json())
+        .then(result => {
+            const select = document.getElementById('venue');
+            (result.data || []).forEach(venue => {
+                const option = document.createElement('option');
+                option.value = venue.id;
+                option.dataset.timezone = venue.timezone;
+                option.textContent = `${venue.name} (${venue.capacity} мест)`;
+                select.appendChild(option);
+            });
+        })
+        .catch(error => console.error('Error loading venues:', error));
+}
+
 function createEvent() {
     const title = document.getElementById('title').value;
     const date = document.getElementById('date').value;
+    const venue = document.getElementById('venue');
+    const venueId = venue.value;
+    const timezone = document.getElementById('timezone').value.trim();
     const totalSeats = document.getElementById('total-seats').value;
     const deadline = document.getElementById('deadline').value;
+    const rrule = document.getElementById('rrule').value.trim();
+
+    clearFieldErrors();
 
-    if (!title || !date || !totalSeats || !deadline) {
+    // Мероприятие на площадке по умолчанию получает ее вместимость.
+    if (!title || !date || (!totalSeats && !venueId) || !deadline) {
         showError('Пожалуйста, заполните все поля');
         return;
     }
 
+    // Дата вводится по местным часам мероприятия, а не браузера.
+    const zone = timezone || (venueId && venue.selectedOptions[0].dataset.timezone) || 'UTC';
+    let utcDate;
+    try {
+        utcDate = zonedTimeToISO(date, zone);
+    } catch (e) {
+        highlightFieldErrors([{field: 'timezone'}]);
+        showError('Неизвестный часовой пояс: ' + zone);
+        return;
+    }
+
     const data = {
         title: title,
-        date: new Date(date).toISOString(),
-        total_seats: parseInt(totalSeats),
+        date: utcDate,
         deadline: parseInt(deadline)
     };
+    if (totalSeats) data.total_seats = parseInt(totalSeats);
+    if (venueId) data.venue_id = parseInt(venueId);
+    if (timezone) data.timezone = timezone;
+
+    // С правилом повторения создается серия: дата — первое занятие.
+    let url = '/api/v1/events';
+    if (rrule) {
+        url = '/api/v1/series';
+        data.start = data.date;
+        data.rrule = rrule;
+        delete data.date;
+    }
 
-    fetch('/events', {
+    fetch(url, {
         method: 'POST',
         headers: {
             'Content-Type': 'application/json',
@@ -103,12 +154,15 @@ function createEvent() {
     })
         .then(response => response.json())
         .then(result => {
-            if (result.status === 'OK') {
-                showSuccess('Мероприятие успешно создано!');
+            if ('data' in result) {
+                showSuccess(rrule
+                    ? `Серия создана, занятий: ${result.data.event_ids.length}`
+                    : 'Мероприятие успешно создано!');
                 document.getElementById('create-event-form').reset();
                 loadAdminEvents();
             } else {
-                showError('Ошибка создания: ' + result.error);
+                highlightFieldErrors(result.errors || []);
+                showError('Ошибка создания: ' + result.detail);
             }
         })
         .catch(error => {
@@ -117,6 +171,122 @@ function createEvent() {
         });
 }
 
+// Время мероприятия показывается по его местным часам с названием пояса.
+function formatEventDate(event) {
+    const timeZone = event.timezone || 'UTC';
+    return new Date(event.date).toLocaleString('ru-RU', {timeZone: timeZone}) + ` (${timeZone})`;
+}
+
+// Переводит значение datetime-local по часам пояса zone в UTC. Смещение
+// считается дважды, чтобы учесть переход на летнее время между ними.
+function zonedTimeToISO(local, zone) {
+    const wall = new Date(local + 'Z').getTime();
+    const guess = wall - zoneOffset(wall, zone);
+    return new Date(wall - zoneOffset(guess, zone)).toISOString();
+}
+
+// Смещение пояса zone от UTC в миллисекундах в момент time.
+function zoneOffset(time, zone) {
+    const parts = {};
+    new Intl.DateTimeFormat('en-US', {
+        timeZone: zone, hourCycle: 'h23',
+        year: 'numeric', month: '2-digit', day: '2-digit',
+        hour: '2-digit', minute: '2-digit', second: '2-digit'
+    }).formatToParts(new Date(time)).forEach(part => parts[part.type] = part.value);
+
+    return Date.UTC(parts.year, parts.month - 1, parts.day, parts.hour, parts.minute, parts.second) - time;
+}
+
+function importEvents() {
+    const file = document.getElementById('import-file').files[0];
+    const seats = document.getElementById('import-seats').value;
+    const deadline = document.getElementById('import-deadline').value;
+    const dryRun = document.getElementById('import-dry-run').checked;
+
+    showImportErrors([]);
+
+    if (!file) {
+        showError('Выберите файл для импорта');
+        return;
+    }
+
+    const params = new URLSearchParams();
+    if (dryRun) params.set('dry_run', 'true');
+    if (seats) params.set('total_seats', seats);
+    if (deadline) params.set('deadline', deadline);
+
+    const formData = new FormData();
+    formData.append('file', file);
+
+    fetch('/api/v1/events/import?' + params.toString(), {
+        method: 'POST',
+        body: formData
+    })
+        .then(response => response.json())
+        .then(result => {
+            if ('data' in result) {
+                const count = result.data.events.length;
+                if (result.data.dry_run) {
+                    showSuccess(`Проверка пройдена: ${count} мероприятий готовы к импорту`);
+                } else {
+                    showSuccess(`Импортировано мероприятий: ${count}`);
+                    document.getElementById('import-events-form').reset();
+                    loadAdminEvents();
+                }
+            } else {
+                showImportErrors(result.details || []);
+                showError('Ошибка импорта: ' + result.detail);
+            }
+        })
+        .catch(error => {
+            console.error('Error:', error);
+            showError('Ошибка сети при импорте мероприятий');
+        });
+}
+
+// Ошибки импорта приходят по строкам файла
+function showImportErrors(rows) {
+    const list = document.getElementById('import-errors');
+    list.innerHTML = '';
+
+    rows.forEach(row => {
+        row.errors.forEach(err => {
+            const item = document.createElement('li');
+            item.textContent = `Строка ${row.line}: ${err.message}`;
+            list.appendChild(item);
+        });
+    });
+}
+
+// Поля ошибок валидации API называются как в JSON запроса
+const fieldInputs = {
+    title: 'title',
+    date: 'date',
+    total_seats: 'total-seats',
+    venue_id: 'venue',
+    timezone: 'timezone',
+    deadline: 'deadline',
+    start: 'date',
+    rrule: 'rrule'
+};
+
+function highlightFieldErrors(errors) {
+    errors.forEach(err => {
+        const input = document.getElementById(fieldInputs[err.field]);
+        if (input) {
+            input.classList.add('input-error');
+            input.title = err.message;
+        }
+    });
+}
+
+function clearFieldErrors() {
+    document.querySelectorAll('#create-event-form .input-error').forEach(input => {
+        input.classList.remove('input-error');
+        input.removeAttribute('title');
+    });
+}
+
 function showSuccess(message) {
     const successDiv = document.createElement('div');
     successDiv.className = 'success';
@@ -16,7 +16,7 @@ function setupEventListeners() {
 }
 
 function loadEvents() {
-    fetch('/events')
+    fetch('/api/v1/events')
         .then(response => {
             if (!response.ok) {
                 throw new Error('Network response was not ok');
@@ -25,7 +25,7 @@ function loadEvents() {
         })
         .then(data => {
             console.log('Received data:', data);
-            displayEvents(data.events || []);
+            displayEvents(data.data.events || []);
         })
         .catch(error => {
             console.error('Error loading events:', error);
@@ -70,7 +70,7 @@ function displayEvents(events) {
     eventsArray.forEach(event => {
         // Добавляем проверки на существование полей
         const freeSeats = event.total_seats ? event.total_seats - (event.booked_seats || 0) : 0;
-        const eventDate = event.date ? new Date(event.date).toLocaleString('ru-RU') : 'Дата не указана';
+        const eventDate = event.date ? formatEventDate(event) : 'Дата не указана';
         const deadline = event.deadline_minutes ? `${event.deadline_minutes} минут` : 'Не указан';
 
         html += `
@@ -96,6 +96,87 @@ function showBookingForm(eventId) {
     document.getElementById('booking-section').style.display = 'block';
     document.getElementById('confirmation-section').style.display = 'none';
     document.getElementById('user-id').focus();
+    loadTicketTypes(eventId);
+    loadSeats(eventId);
+}
+
+// Events with a seat layout are booked seat by seat; others have no seat map.
+function loadSeats(eventId) {
+    const group = document.getElementById('seat-group');
+    const select = document.getElementById('seat');
+    group.style.display = 'none';
+    select.innerHTML = '';
+
+    fetch(`/api/v1/events/${eventId}/seats`)
+        .then(response => response.ok ? response.json() : null)
+        .then(result => {
+            if (!result || !result.data) {
+                return;
+            }
+
+            for (const section of result.data.sections) {
+                const optgroup = document.createElement('optgroup');
+                optgroup.label = section.name;
+                for (const row of section.rows) {
+                    for (const seat of row.seats) {
+                        const option = document.createElement('option');
+                        option.value = seat.id;
+                        option.textContent = `Ряд ${row.name}, место ${seat.number}`;
+                        option.disabled = seat.status !== 'available';
+                        optgroup.appendChild(option);
+                    }
+                }
+                select.appendChild(optgroup);
+            }
+            const free = select.querySelector('option:not([disabled])');
+            if (free) {
+                free.selected = true;
+            }
+            group.style.display = 'block';
+        })
+        .catch(error => console.error('Error:', error));
+}
+
+function loadTicketTypes(eventId) {
+    const group = document.getElementById('ticket-type-group');
+    const promoGroup = document.getElementById('promo-code-group');
+    const select = document.getElementById('ticket-type');
+    group.style.display = 'none';
+    promoGroup.style.display = 'none';
+    select.innerHTML = '';
+    document.getElementById('promo-code').value = '';
+
+    fetch(`/api/v1/events/${eventId}`)
+        .then(response => response.json())
+        .then(result => {
+            const ticketTypes = (result.data && result.data.ticket_types) || [];
+            if (ticketTypes.length === 0) {
+                return;
+            }
+
+            for (const t of ticketTypes) {
+                const option = document.createElement('option');
+                option.value = t.id;
+                option.textContent = `${t.name} — ${formatPrice(t.price, t.currency)} (осталось ${t.remaining_seats})`;
+                option.disabled = t.remaining_seats === 0;
+                select.appendChild(option);
+            }
+            group.style.display = 'block';
+            promoGroup.style.display = 'block';
+        })
+        .catch(error => console.error('Error:', error));
+}
+
+// Events are shown on their own wall clock, not the browser's, with the zone name.
+function formatEventDate(event) {
+    const timeZone = event.timezone || 'UTC';
+    return new Date(event.date).toLocaleString('ru-RU', {timeZone: timeZone}) + ` (${timeZone})`;
+}
+
+// Prices are in minor units, so divide by the currency's own number of decimals.
+function formatPrice(price, currency) {
+    const format = new Intl.NumberFormat('ru-RU', {style: 'currency', currency: currency});
+    return format.format(price / 10 ** format.resolvedOptions().maximumFractionDigits);
 }
 
 function bookEvent() {
@@ -110,8 +191,18 @@ function bookEvent() {
     const data = {
         user_id: userId
     };
+    if (document.getElementById('ticket-type-group').style.display !== 'none') {
+        data.ticket_type_id = Number(document.getElementById('ticket-type').value);
+        const promoCode = document.getElementById('promo-code').value.trim();
+        if (promoCode) {
+            data.promo_code = promoCode;
+        }
+    }
+    if (document.getElementById('seat-group').style.display !== 'none') {
+        data.seat_id = Number(document.getElementById('seat').value);
+    }
 
-    fetch(`/events/${eventId}/book`, {
+    fetch(`/api/v1/events/${eventId}/book`, {
         method: 'POST',
         headers: {
             'Content-Type': 'application/json',
@@ -120,12 +211,12 @@ function bookEvent() {
     })
         .then(response => response.json())
         .then(result => {
-            if (result.status === 'OK') {
+            if ('data' in result) {
                 showSuccess('Место успешно забронировано! Не забудьте подтвердить бронь.');
                 showConfirmationForm(eventId);
                 loadEvents();
             } else {
-                showError('Ошибка бронирования: ' + result.error);
+                showError('Ошибка бронирования: ' + result.detail);
             }
         })
         .catch(error => {
@@ -155,7 +246,7 @@ function confirmBooking() {
         user_id: userId
     };
 
-    fetch(`/events/${eventId}/confirm`, {
+    fetch(`/api/v1/events/${eventId}/confirm`, {
         method: 'POST',
         headers: {
             'Content-Type': 'application/json',
@@ -164,12 +255,15 @@ function confirmBooking() {
     })
         .then(response => response.json())
         .then(result => {
-            if (result.status === 'OK') {
+            if ('data' in result) {
                 showSuccess('Бронь успешно подтверждена!');
                 document.getElementById('confirmation-section').style.display = 'none';
+                showTicket(result.data);
                 loadEvents();
+            } else if (result.code === 'payment_required') {
+                payBooking(eventId, userId);
             } else {
-                showError('Ошибка подтверждения: ' + result.error);
+                showError('Ошибка подтверждения: ' + result.detail);
             }
         })
         .catch(error => {
@@ -178,6 +272,46 @@ function confirmBooking() {
         });
 }
 
+function payBooking(eventId, userId) {
+    fetch(`/api/v1/events/${eventId}/pay`, {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ user_id: userId })
+    })
+        .then(response => response.json())
+        .then(result => {
+            if ('data' in result) {
+                window.location.href = result.data.checkout_url;
+            } else {
+                showError('Ошибка оплаты: ' + result.detail);
+            }
+        })
+        .catch(error => {
+            console.error('Error:', error);
+            showError('Ошибка сети при оплате');
+        });
+}
+
+function showTicket(links) {
+    if (!links || !links.ticket_url) {
+        return;
+    }
+
+    document.getElementById('ticket-qr').src = links.ticket_url;
+
+    const calendarLink = document.getElementById('calendar-link');
+    if (links.calendar_url) {
+        calendarLink.href = links.calendar_url;
+        calendarLink.style.display = 'inline';
+    } else {
+        calendarLink.style.display = 'none';
+    }
+
+    document.getElementById('ticket-section').style.display = 'block';
+}
+
 function showSuccess(message) {
     const successDiv = document.createElement('div');
     successDiv.className = 'success';